@@ -1,18 +1,30 @@
 package main
 
 import (
+	"context"
 	"log"
 	"os"
 
 	"github.com/joho/godotenv"
 	"github.com/othersidedrl/portfolio/backend/internal/about"
+	"github.com/othersidedrl/portfolio/backend/internal/apikey"
+	"github.com/othersidedrl/portfolio/backend/internal/audit"
 	"github.com/othersidedrl/portfolio/backend/internal/auth"
+	"github.com/othersidedrl/portfolio/backend/internal/content"
 	"github.com/othersidedrl/portfolio/backend/internal/database"
 	"github.com/othersidedrl/portfolio/backend/internal/hero"
 	"github.com/othersidedrl/portfolio/backend/internal/image"
+	"github.com/othersidedrl/portfolio/backend/internal/mailer"
+	"github.com/othersidedrl/portfolio/backend/internal/middleware"
 	"github.com/othersidedrl/portfolio/backend/internal/project"
+	"github.com/othersidedrl/portfolio/backend/internal/resume"
+	"github.com/othersidedrl/portfolio/backend/internal/scheduler"
+	"github.com/othersidedrl/portfolio/backend/internal/search"
 	"github.com/othersidedrl/portfolio/backend/internal/server"
+	"github.com/othersidedrl/portfolio/backend/internal/technology"
 	"github.com/othersidedrl/portfolio/backend/internal/testimony"
+	"github.com/othersidedrl/portfolio/backend/internal/translation"
+	"github.com/othersidedrl/portfolio/backend/internal/user"
 	"github.com/othersidedrl/portfolio/backend/internal/utils"
 )
 
@@ -22,32 +34,96 @@ func main() {
 	utils.InitRedis()
 
 	// Utils
-	jwt := utils.NewJWTService()
+	jwt, err := utils.NewJWTService()
+	if err != nil {
+		log.Fatal("Failed to load JWT keys:", err)
+	}
+
+	// Audit
+	auditRepo := audit.NewGormAuditRepository(db)
+	auditService := audit.NewService(auditRepo)
+	auditHandler := audit.NewHandler(auditService)
+
+	// Users
+	userRepo := user.NewGormUserRepository(db)
+	userService := user.NewService(userRepo, auditService)
+	userHandler := user.NewHandler(userService)
+	if err := userService.EnsureOwner(context.Background(), os.Getenv("ADMIN_EMAIL"), os.Getenv("ADMIN_PASSWORD_HASH")); err != nil {
+		log.Fatal("Failed to seed owner account:", err)
+	}
+
+	// API keys
+	apiKeyRepo := apikey.NewGormAPIKeyRepository(db)
+	apiKeyService := apikey.NewService(apiKeyRepo, auditService)
+	apiKeyHandler := apikey.NewHandler(apiKeyService)
+
+	// Mailer
+	mail, err := mailer.New()
+	if err != nil {
+		log.Fatal("Failed to configure mailer:", err)
+	}
 
 	// Auth
-	authService := auth.NewService(jwt)
+	tokenStore := auth.NewTokenStore(utils.RedisClient, jwt.TTL())
+	loginThrottle := auth.NewLoginThrottle(utils.RedisClient)
+	loginAttemptRepo := auth.NewGormLoginAttemptRepository(db)
+	oidcProvider, err := auth.NewOIDCProvider(utils.RedisClient)
+	if err != nil {
+		log.Fatal("Failed to configure OIDC:", err)
+	}
+	authService := auth.NewService(jwt, userService, tokenStore, loginThrottle, loginAttemptRepo, mail, oidcProvider)
 	authHandler := auth.NewHandler(authService)
 
+	// Content drafts and revisions
+	draftRepo := content.NewGormDraftRepository(db)
+	revisionRepo := content.NewGormRevisionRepository(db)
+	contentService := content.NewService(draftRepo, revisionRepo, auditService)
+	contentHandler := content.NewHandler(contentService)
+
+	// Translations
+	translationRepo := translation.NewGormTranslationRepository(db)
+	translationService := translation.NewService(translationRepo, middleware.LocaleConfigFromEnv(), auditService)
+	translationHandler := translation.NewHandler(translationService)
+
+	// Technologies
+	technologyRepo := technology.NewGormTechnologyRepository(db)
+	technologyService := technology.NewService(technologyRepo, auditService)
+	technologyHandler := technology.NewHandler(technologyService)
+
 	// Hero
 	heroRepo := hero.NewGormHeroRepository(db)
-	heroService := hero.NewService(heroRepo)
+	heroService := hero.NewService(heroRepo, contentService, translationService, auditService)
 	heroHandler := hero.NewHandler(heroService)
 
 	// About
 	aboutRepo := about.NewGormAboutRepository(db)
-	aboutService := about.NewService(aboutRepo)
+	aboutService := about.NewService(aboutRepo, contentService, translationService, technologyService, auditService)
 	aboutHandler := about.NewHandler(aboutService)
 
 	// Testimony
 	testimonyRepo := testimony.NewGormTestimonyRepository(db)
-	testimonyService := testimony.NewService(testimonyRepo)
+	testimonyService := testimony.NewService(testimonyRepo, contentService, translationService, auditService)
 	testimonyHandler := testimony.NewHandler(testimonyService)
 
 	// Project
 	projectRepo := project.NewGormProjectRepository(db)
-	projectService := project.NewService(projectRepo)
+	projectService := project.NewService(projectRepo, contentService, translationService, technologyService, auditService)
 	projectHandler := project.NewHandler(projectService)
 
+	translationService.RegisterSource(heroService.TranslatableFields)
+	translationService.RegisterSource(aboutService.TranslatableFields)
+	translationService.RegisterSource(testimonyService.TranslatableFields)
+	translationService.RegisterSource(projectService.TranslatableFields)
+
+	// Resume
+	resumeService := resume.NewService(db, heroService, aboutService, projectService, technologyService)
+	resumeHandler := resume.NewHandler(resumeService)
+
+	// Search
+	searchRepo := search.NewGormSearchRepository(db)
+	searchService := search.NewService(searchRepo)
+	searchHandler := search.NewHandler(searchService)
+
 	// Image
 	imageService, err := image.NewService()
 	if err != nil {
@@ -55,9 +131,36 @@ func main() {
 	}
 	imageHandler := image.NewHandler(imageService)
 
+	if err := projectService.EnsureSlugs(context.Background()); err != nil {
+		log.Fatal("Failed to generate project slugs:", err)
+	}
+	if err := projectService.EnsureTechnologies(context.Background()); err != nil {
+		log.Fatal("Failed to link project technologies:", err)
+	}
+	if err := aboutService.EnsureTechnologies(context.Background()); err != nil {
+		log.Fatal("Failed to link skill technologies:", err)
+	}
+	if err := search.Rebuild(db); err != nil {
+		log.Fatal("Failed to build the search index:", err)
+	}
+
+	// Scheduler
+	trashRetention := utils.DurationFromEnv("TRASH_RETENTION", scheduler.DefaultTrashRetention)
+	scheduler.New(utils.RedisClient,
+		scheduler.Job{Name: "hero page", Run: heroService.RunSchedule, CacheKeys: []string{"hero_page_cache"}},
+		scheduler.Job{Name: "about page", Run: aboutService.RunAboutPageSchedule, CacheKeys: []string{"about_page_cache"}},
+		scheduler.Job{Name: "testimony page", Run: testimonyService.RunTestimonyPageSchedule, CacheKeys: []string{"testimony_page_cache"}},
+		scheduler.Job{Name: "project page", Run: projectService.RunProjectPageSchedule, CacheKeys: []string{"project_page_cache"}},
+		scheduler.Job{Name: "projects", Run: projectService.RunProjectSchedule, CacheKeys: []string{"project_items_cache", "project_featured_cache", "project_item_cache", "technologies_cache", "about_skills_cache"}},
+		scheduler.PurgeJob("projects", trashRetention, projectService.PurgeProjects),
+		scheduler.PurgeJob("skills", trashRetention, aboutService.PurgeTechnicalSkills),
+		scheduler.PurgeJob("careers", trashRetention, aboutService.PurgeCareers),
+		scheduler.PurgeJob("testimonies", trashRetention, testimonyService.PurgeTestimonies),
+	).Start(context.Background())
+
 	PORT := os.Getenv("PORT")
 
-	router := server.NewRouter(authHandler, heroHandler, aboutHandler, testimonyHandler, projectHandler, imageHandler, jwt)
+	router := server.NewRouter(authHandler, heroHandler, aboutHandler, testimonyHandler, projectHandler, technologyHandler, searchHandler, resumeHandler, imageHandler, userHandler, apiKeyHandler, auditHandler, contentHandler, translationHandler, jwt, tokenStore, apiKeyService)
 	srv := server.StartServer(":"+PORT, router)
 
 	log.Printf("🚀 Server running on http://localhost:%s", PORT)
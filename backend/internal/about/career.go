@@ -0,0 +1,144 @@
+package about
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/othersidedrl/portfolio/backend/internal/models"
+)
+
+var (
+	ErrInvalidCareerType  = errors.New("type must be Education or Job")
+	ErrInvalidCareerDate  = errors.New("dates must be months formatted as YYYY-MM")
+	ErrMissingCareerStart = errors.New("start date is required")
+	ErrInvalidCareerEnd   = errors.New("end date is required, and must be left empty for an ongoing entry")
+	ErrCareerDateOrder    = errors.New("start date must not be after the end date")
+)
+
+// monthLayout is how career dates are sent and returned. Full dates are
+// accepted too and cut down to their month.
+const monthLayout = "2006-01"
+
+func parseMonth(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	for _, layout := range []string{monthLayout, time.DateOnly} {
+		if t, err := time.Parse(layout, value); err == nil {
+			month := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+			return &month, nil
+		}
+	}
+	return nil, ErrInvalidCareerDate
+}
+
+func formatMonth(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(monthLayout)
+}
+
+// dates parses the start and end month of the entry
+func (c CareerItemDto) dates() (start, end *time.Time, err error) {
+	if start, err = parseMonth(c.StartedAt); err != nil {
+		return nil, nil, err
+	}
+	if end, err = parseMonth(c.EndedAt); err != nil {
+		return nil, nil, err
+	}
+	return start, end, nil
+}
+
+// ValidateCareer checks the type and that the dates make a period: a start,
+// then an end unless the entry is ongoing. Dates are normalised to YYYY-MM.
+func ValidateCareer(data *CareerItemDto) error {
+	if !models.CareerType(data.Type).Valid() {
+		return ErrInvalidCareerType
+	}
+
+	start, end, err := data.dates()
+	if err != nil {
+		return err
+	}
+	if start == nil {
+		return ErrMissingCareerStart
+	}
+	if data.Ongoing != (end == nil) {
+		return ErrInvalidCareerEnd
+	}
+	if end != nil && start.After(*end) {
+		return ErrCareerDateOrder
+	}
+
+	data.StartedAt, data.EndedAt = formatMonth(start), formatMonth(end)
+	return nil
+}
+
+// MergeCareer applies an update to an entry. Empty fields keep their
+// current value; marking the entry ongoing clears its end date and giving
+// an end date ends it.
+func MergeCareer(current, update CareerItemDto) CareerItemDto {
+	merged := current
+	for _, field := range []struct{ to, from *string }{
+		{&merged.Title, &update.Title},
+		{&merged.Affiliation, &update.Affiliation},
+		{&merged.Description, &update.Description},
+		{&merged.Location, &update.Location},
+		{&merged.Type, &update.Type},
+		{&merged.StartedAt, &update.StartedAt},
+	} {
+		if *field.from != "" {
+			*field.to = *field.from
+		}
+	}
+
+	switch {
+	case update.Ongoing:
+		merged.Ongoing, merged.EndedAt = true, update.EndedAt
+	case update.EndedAt != "":
+		merged.Ongoing, merged.EndedAt = false, update.EndedAt
+	}
+	return merged
+}
+
+// totalYears adds up the time spent in each type of entry, in years rounded
+// to one decimal. Overlapping entries of the same type count once, and
+// ongoing entries run to the current month.
+func totalYears(careers []CareerItemDto, now time.Time) map[string]float64 {
+	type period struct{ start, end int }
+	monthIndex := func(t time.Time) int { return t.Year()*12 + int(t.Month()) - 1 }
+
+	periods := map[string][]period{}
+	for _, career := range careers {
+		start, end, err := career.dates()
+		if err != nil || start == nil {
+			continue
+		}
+		last := monthIndex(now)
+		if end != nil {
+			last = monthIndex(*end)
+		}
+		periods[career.Type] = append(periods[career.Type], period{monthIndex(*start), last})
+	}
+
+	totals := map[string]float64{string(models.Education): 0, string(models.Job): 0}
+	for careerType, list := range periods {
+		sort.Slice(list, func(i, j int) bool { return list[i].start < list[j].start })
+
+		months, covered := 0, -1
+		for _, p := range list {
+			if p.start <= covered {
+				p.start = covered + 1
+			}
+			if p.end >= p.start {
+				months += p.end - p.start + 1
+				covered = p.end
+			}
+		}
+		totals[careerType] = math.Round(float64(months)/12*10) / 10
+	}
+	return totals
+}
@@ -0,0 +1,119 @@
+package about
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestValidateCareer(t *testing.T) {
+	tests := []struct {
+		name      string
+		career    CareerItemDto
+		wantErr   error
+		wantStart string
+		wantEnd   string
+	}{
+		{"finished job", CareerItemDto{Type: "Job", StartedAt: "2020-01", EndedAt: "2021-06"}, nil, "2020-01", "2021-06"},
+		{"ongoing job", CareerItemDto{Type: "Job", StartedAt: "2020-01", Ongoing: true}, nil, "2020-01", ""},
+		{"full dates cut to months", CareerItemDto{Type: "Education", StartedAt: "2016-09-01", EndedAt: "2020-07-15"}, nil, "2016-09", "2020-07"},
+		{"same month", CareerItemDto{Type: "Job", StartedAt: "2020-01", EndedAt: "2020-01"}, nil, "2020-01", "2020-01"},
+		{"unknown type", CareerItemDto{Type: "Hobby", StartedAt: "2020-01", Ongoing: true}, ErrInvalidCareerType, "", ""},
+		{"bad date", CareerItemDto{Type: "Job", StartedAt: "January 2020", Ongoing: true}, ErrInvalidCareerDate, "", ""},
+		{"month out of range", CareerItemDto{Type: "Job", StartedAt: "2020-13", Ongoing: true}, ErrInvalidCareerDate, "", ""},
+		{"no start", CareerItemDto{Type: "Job", EndedAt: "2020-01"}, ErrMissingCareerStart, "", ""},
+		{"no end and not ongoing", CareerItemDto{Type: "Job", StartedAt: "2020-01"}, ErrInvalidCareerEnd, "", ""},
+		{"ongoing with an end", CareerItemDto{Type: "Job", StartedAt: "2020-01", EndedAt: "2021-01", Ongoing: true}, ErrInvalidCareerEnd, "", ""},
+		{"end before start", CareerItemDto{Type: "Job", StartedAt: "2021-01", EndedAt: "2020-12"}, ErrCareerDateOrder, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			career := tt.career
+			err := ValidateCareer(&career)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if career.StartedAt != tt.wantStart || career.EndedAt != tt.wantEnd {
+				t.Errorf("dates = %q to %q, want %q to %q", career.StartedAt, career.EndedAt, tt.wantStart, tt.wantEnd)
+			}
+		})
+	}
+}
+
+func TestMergeCareer(t *testing.T) {
+	current := CareerItemDto{Title: "Engineer", Type: "Job", StartedAt: "2020-01", EndedAt: "2021-06"}
+
+	tests := []struct {
+		name   string
+		update CareerItemDto
+		want   CareerItemDto
+	}{
+		{"empty fields are kept", CareerItemDto{Title: "Senior Engineer"},
+			CareerItemDto{Title: "Senior Engineer", Type: "Job", StartedAt: "2020-01", EndedAt: "2021-06"}},
+		{"ongoing clears the end", CareerItemDto{Ongoing: true},
+			CareerItemDto{Title: "Engineer", Type: "Job", StartedAt: "2020-01", Ongoing: true}},
+		{"new end", CareerItemDto{EndedAt: "2022-03"},
+			CareerItemDto{Title: "Engineer", Type: "Job", StartedAt: "2020-01", EndedAt: "2022-03"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MergeCareer(current, tt.update); got != tt.want {
+				t.Errorf("merged = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	ongoing := CareerItemDto{Type: "Job", StartedAt: "2020-01", Ongoing: true}
+	if got := MergeCareer(ongoing, CareerItemDto{EndedAt: "2024-12"}); got.Ongoing || got.EndedAt != "2024-12" {
+		t.Errorf("ending an ongoing entry = %+v", got)
+	}
+}
+
+func TestTotalYears(t *testing.T) {
+	now := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	job := func(start, end string) CareerItemDto {
+		return CareerItemDto{Type: "Job", StartedAt: start, EndedAt: end, Ongoing: end == ""}
+	}
+
+	tests := []struct {
+		name    string
+		careers []CareerItemDto
+		wantJob float64
+	}{
+		{"none", nil, 0},
+		// Both the first and the last month count
+		{"one full year", []CareerItemDto{job("2020-01", "2020-12")}, 1},
+		{"single month", []CareerItemDto{job("2020-01", "2020-01")}, 0.1},
+		{"ongoing runs to this month", []CareerItemDto{job("2024-07", "")}, 1},
+		{"back to back", []CareerItemDto{job("2020-01", "2020-12"), job("2021-01", "2021-12")}, 2},
+		{"overlap counts once", []CareerItemDto{job("2020-01", "2021-12"), job("2021-01", "2022-12")}, 3},
+		{"nested counts once", []CareerItemDto{job("2020-01", "2022-12"), job("2021-01", "2021-06")}, 3},
+		{"gap is left out", []CareerItemDto{job("2022-01", "2022-12"), job("2020-01", "2020-12")}, 2},
+		{"bad dates are skipped", []CareerItemDto{job("2020-01", "2020-12"), job("nope", "2021-01")}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			totals := totalYears(tt.careers, now)
+			if totals["Job"] != tt.wantJob {
+				t.Errorf("job years = %v, want %v", totals["Job"], tt.wantJob)
+			}
+			if totals["Education"] != 0 {
+				t.Errorf("education years = %v, want 0", totals["Education"])
+			}
+		})
+	}
+
+	mixed := totalYears([]CareerItemDto{
+		job("2020-01", "2020-12"),
+		{Type: "Education", StartedAt: "2016-09", EndedAt: "2020-08"},
+	}, now)
+	if mixed["Job"] != 1 || mixed["Education"] != 4 {
+		t.Errorf("totals = %v, want Job 1 and Education 4", mixed)
+	}
+}
@@ -0,0 +1,187 @@
+package about
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/othersidedrl/portfolio/backend/internal/database"
+	"github.com/othersidedrl/portfolio/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrUnknownProject = errors.New("skill references a project that does not exist")
+	ErrUnknownCareer  = errors.New("skill references a career entry that does not exist")
+)
+
+// skillLink is a join table between skills and the content they were used in
+type skillLink struct {
+	table  string
+	column string
+	model  interface{}
+	err    error
+}
+
+var (
+	skillProjects = skillLink{"skill_projects", "project_id", &models.Project{}, ErrUnknownProject}
+	skillCareers  = skillLink{"skill_careers", "career_journey_id", &models.CareerJourney{}, ErrUnknownCareer}
+)
+
+// set replaces the skill's links, after checking every ID exists. Trashed
+// content can still be linked so restoring it brings the evidence back.
+func (l skillLink) set(tx *gorm.DB, skillID uint, ids []uint) error {
+	ids = uniqueIDs(ids)
+	if len(ids) > 0 {
+		var found int64
+		if err := tx.Unscoped().Model(l.model).Where("id IN ?", ids).Count(&found).Error; err != nil {
+			return err
+		}
+		if found != int64(len(ids)) {
+			return l.err
+		}
+	}
+
+	if err := tx.Exec("DELETE FROM "+l.table+" WHERE technical_skills_id = ?", skillID).Error; err != nil {
+		return err
+	}
+	for _, id := range ids {
+		err := tx.Exec("INSERT INTO "+l.table+" (technical_skills_id, "+l.column+") VALUES (?, ?)", skillID, id).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// unlink removes the links of the given content, a list of IDs or a subquery selecting them
+func (l skillLink) unlink(tx *gorm.DB, ids interface{}) error {
+	return tx.Exec("DELETE FROM "+l.table+" WHERE "+l.column+" IN (?)", ids).Error
+}
+
+// unlinkSkills removes the links of the given skills
+func (l skillLink) unlinkSkills(tx *gorm.DB, skillIDs interface{}) error {
+	return tx.Exec("DELETE FROM "+l.table+" WHERE technical_skills_id IN (?)", skillIDs).Error
+}
+
+// ids maps each of the given skills to the IDs it links to
+func (l skillLink) ids(db *gorm.DB, skillIDs []uint) (map[uint][]uint, error) {
+	var rows []struct {
+		SkillID  uint
+		TargetID uint
+	}
+	err := db.Table(l.table).
+		Select("technical_skills_id AS skill_id, "+l.column+" AS target_id").
+		Where("technical_skills_id IN ?", skillIDs).
+		Order(l.column).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	linked := make(map[uint][]uint)
+	for _, row := range rows {
+		linked[row.SkillID] = append(linked[row.SkillID], row.TargetID)
+	}
+	return linked, nil
+}
+
+func uniqueIDs(ids []uint) []uint {
+	seen := make(map[uint]bool, len(ids))
+	unique := make([]uint, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
+
+// setSkillLinks replaces the links given in data. Nil lists are left alone.
+func setSkillLinks(tx *gorm.DB, skillID uint, data *SkillItemDto) error {
+	if data.ProjectIDs != nil {
+		if err := skillProjects.set(tx, skillID, data.ProjectIDs); err != nil {
+			return err
+		}
+	}
+	if data.CareerIDs != nil {
+		if err := skillCareers.set(tx, skillID, data.CareerIDs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fillSkillLinks sets the linked project and career IDs of the skills
+func fillSkillLinks(db *gorm.DB, skills []SkillItemDto) error {
+	if len(skills) == 0 {
+		return nil
+	}
+	ids := make([]uint, len(skills))
+	for i, skill := range skills {
+		ids[i] = skill.ID
+	}
+
+	projects, err := skillProjects.ids(db, ids)
+	if err != nil {
+		return err
+	}
+	careers, err := skillCareers.ids(db, ids)
+	if err != nil {
+		return err
+	}
+	for i := range skills {
+		skills[i].ProjectIDs = projects[skills[i].ID]
+		skills[i].CareerIDs = careers[skills[i].ID]
+	}
+	return nil
+}
+
+// GetSkillEvidence returns, per skill, the public projects and the career
+// entries it was used in. Trashed and unpublished content is left out.
+func (r *GormAboutRepository) GetSkillEvidence(ctx context.Context) (map[uint]*EvidenceDto, error) {
+	var projects []struct {
+		SkillID uint
+		EvidenceProjectDto
+	}
+	err := database.Conn(ctx, r.db).Model(&models.Project{}).
+		Select("sp.technical_skills_id AS skill_id, projects.id, projects.name, projects.slug").
+		Joins("JOIN skill_projects sp ON sp.project_id = projects.id").
+		Scopes(models.PublicProjects(time.Now())).
+		Order("projects.position, projects.id").
+		Scan(&projects).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var careers []struct {
+		SkillID uint
+		EvidenceCareerDto
+	}
+	err = database.Conn(ctx, r.db).Model(&models.CareerJourney{}).
+		Select("sc.technical_skills_id AS skill_id, career_journeys.id, career_journeys.title, career_journeys.affiliation, career_journeys.type").
+		Joins("JOIN skill_careers sc ON sc.career_journey_id = career_journeys.id").
+		Order("career_journeys.started_at, career_journeys.id").
+		Scan(&careers).Error
+	if err != nil {
+		return nil, err
+	}
+
+	evidence := make(map[uint]*EvidenceDto)
+	forSkill := func(id uint) *EvidenceDto {
+		if evidence[id] == nil {
+			evidence[id] = &EvidenceDto{Projects: []EvidenceProjectDto{}, Careers: []EvidenceCareerDto{}}
+		}
+		return evidence[id]
+	}
+	for _, p := range projects {
+		e := forSkill(p.SkillID)
+		e.Projects = append(e.Projects, p.EvidenceProjectDto)
+	}
+	for _, c := range careers {
+		e := forSkill(c.SkillID)
+		e.Careers = append(e.Careers, c.EvidenceCareerDto)
+	}
+	return evidence, nil
+}
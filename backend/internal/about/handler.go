@@ -2,12 +2,15 @@ package about
 
 import (
 	"encoding/json"
+	"errors"
 	"log"
 	"net/http"
 	"strconv"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/othersidedrl/portfolio/backend/internal/content"
 	"github.com/othersidedrl/portfolio/backend/internal/utils"
+	"gorm.io/gorm"
 )
 
 type Handler struct {
@@ -29,6 +32,45 @@ func (h *Handler) GetAboutPage(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(about)
 }
 
+func (h *Handler) GetPublicAboutPage(w http.ResponseWriter, r *http.Request) {
+	about, err := h.service.FindPublic(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(about)
+}
+
+func (h *Handler) GetAboutPageSchedule(w http.ResponseWriter, r *http.Request) {
+	schedule, err := h.service.GetAboutPageSchedule(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(schedule)
+}
+
+func (h *Handler) UpdateAboutPageSchedule(w http.ResponseWriter, r *http.Request) {
+	var body content.ScheduleDto
+	if err := utils.DecodeBody(r, &body); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.SetAboutPageSchedule(r.Context(), body); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"message": "About page schedule updated"})
+}
+
 func (h *Handler) UpdateAboutPage(w http.ResponseWriter, r *http.Request) {
 	var body AboutPageDto
 
@@ -37,14 +79,60 @@ func (h *Handler) UpdateAboutPage(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.service.Update(r.Context(), body); err != nil {
+	if err := h.service.SaveAboutPageDraft(r.Context(), &body); err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
 	w.WriteHeader(http.StatusOK)
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]string{"message": "About page updated"})
+	json.NewEncoder(w).Encode(map[string]string{"message": "About page draft saved"})
+}
+
+func (h *Handler) PreviewAboutPage(w http.ResponseWriter, r *http.Request) {
+	page, err := h.service.PreviewAboutPage(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(page)
+}
+
+func (h *Handler) PublishAboutPage(w http.ResponseWriter, r *http.Request) {
+	if err := h.service.PublishAboutPage(r.Context()); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"message": "About page published"})
+}
+
+func (h *Handler) DiscardAboutPageDraft(w http.ResponseWriter, r *http.Request) {
+	if err := h.service.DiscardAboutPageDraft(r.Context()); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) RestoreAboutPage(w http.ResponseWriter, r *http.Request) {
+	revisionID, err := strconv.Atoi(chi.URLParam(r, "revisionID"))
+	if err != nil {
+		http.Error(w, "Invalid revision ID", http.StatusBadRequest)
+		return
+	}
+	if err := h.service.RestoreAboutPage(r.Context(), uint(revisionID)); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"message": "About page restored"})
 }
 
 func (h *Handler) GetTechnicalSkills(w http.ResponseWriter, r *http.Request) {
@@ -53,7 +141,21 @@ func (h *Handler) GetTechnicalSkills(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
+	writeSkills(w, skills)
+}
+
+// GetPublicTechnicalSkills lists the skills with the projects and career
+// entries that back them
+func (h *Handler) GetPublicTechnicalSkills(w http.ResponseWriter, r *http.Request) {
+	skills, err := h.service.GetPublicTechnicalSkills(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeSkills(w, skills)
+}
 
+func writeSkills(w http.ResponseWriter, skills *TechnicalSkillDto) {
 	if skills == nil {
 		skills = &TechnicalSkillDto{Skills: []SkillItemDto{}}
 	}
@@ -77,7 +179,7 @@ func (h *Handler) CreateTechnicalSkill(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := h.service.CreateTechnicalSkill(r.Context(), body); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, err)
 		return
 	}
 
@@ -101,7 +203,7 @@ func (h *Handler) UpdateTechnicalSkill(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := h.service.UpdateTechnicalSkill(r.Context(), body, uint(id)); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, err)
 		return
 	}
 
@@ -120,7 +222,7 @@ func (h *Handler) DeleteTechnicalSkill(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := h.service.DeleteTechnicalSkill(r.Context(), uint(id)); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, err)
 		return
 	}
 
@@ -130,6 +232,40 @@ func (h *Handler) DeleteTechnicalSkill(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 }
 
+func (h *Handler) GetTrashedTechnicalSkills(w http.ResponseWriter, r *http.Request) {
+	trashed, err := h.service.GetTrashedTechnicalSkills(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response := map[string]interface{}{
+		"length": len(trashed),
+		"data":   trashed,
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+func (h *Handler) RestoreTrashedTechnicalSkill(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid skill ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.RestoreTrashedTechnicalSkill(r.Context(), uint(id)); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"message": "Skill restored"})
+}
+
 func (h *Handler) GetCareers(w http.ResponseWriter, r *http.Request) {
 	careerJourney, err := h.service.GetCareers(r.Context())
 	if err != nil {
@@ -138,13 +274,18 @@ func (h *Handler) GetCareers(w http.ResponseWriter, r *http.Request) {
 	}
 
 	careers := []CareerItemDto{}
+	totalYears := map[string]float64{}
 	if careerJourney != nil {
-		careers = careerJourney.Careers
+		if careerJourney.Careers != nil {
+			careers = careerJourney.Careers
+		}
+		totalYears = careerJourney.TotalYears
 	}
 
 	response := map[string]interface{}{
-		"length": len(careers),
-		"data":   careers,
+		"length":      len(careers),
+		"data":        careers,
+		"total_years": totalYears,
 	}
 
 	w.WriteHeader(http.StatusOK)
@@ -161,7 +302,7 @@ func (h *Handler) CreateCareer(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := h.service.CreateCareer(r.Context(), body); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, err)
 		return
 	}
 
@@ -185,7 +326,7 @@ func (h *Handler) UpdateCareer(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := h.service.UpdateCareer(r.Context(), body, uint(id)); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, err)
 		return
 	}
 
@@ -204,7 +345,7 @@ func (h *Handler) DeleteCareer(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := h.service.DeleteCareer(r.Context(), uint(id)); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, err)
 		return
 	}
 
@@ -213,3 +354,52 @@ func (h *Handler) DeleteCareer(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 	w.Header().Set("Content-Type", "application/json")
 }
+
+func (h *Handler) GetTrashedCareers(w http.ResponseWriter, r *http.Request) {
+	trashed, err := h.service.GetTrashedCareers(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response := map[string]interface{}{
+		"length": len(trashed),
+		"data":   trashed,
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+func (h *Handler) RestoreTrashedCareer(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid career ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.RestoreTrashedCareer(r.Context(), uint(id)); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"message": "Career restored"})
+}
+
+// writeError maps service errors to HTTP status codes
+func writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, ErrUnknownProject), errors.Is(err, ErrUnknownCareer),
+		errors.Is(err, ErrInvalidCareerType), errors.Is(err, ErrInvalidCareerDate),
+		errors.Is(err, ErrMissingCareerStart), errors.Is(err, ErrInvalidCareerEnd),
+		errors.Is(err, ErrCareerDateOrder):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		content.WriteError(w, err)
+	}
+}
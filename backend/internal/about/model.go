@@ -1,6 +1,11 @@
 package about
 
+import "time"
+
+// CardDto is a card of the about page. A saved card with an ID updates that
+// card; one without is added.
 type CardDto struct {
+	ID          uint   `json:"id,omitempty"`
 	Title       string `json:"title"`
 	Description string `json:"description"`
 }
@@ -13,19 +18,51 @@ type AboutPageDto struct {
 	Available    bool      `json:"available"`
 }
 
+// SkillItemDto is a technical skill. ProjectIDs and CareerIDs are the
+// projects and career entries it was used in; the public site gets them as
+// Evidence instead.
 type SkillItemDto struct {
-	ID           uint     `json:"id"`
-	Name         string   `json:"name"`
-	Description  string   `json:"description"`
-	Specialities []string `json:"specialities"`
-	Level        string   `json:"level"`
-	Category     string   `json:"category"`
+	ID           uint         `json:"id"`
+	Name         string       `json:"name"`
+	Description  string       `json:"description"`
+	Specialities []string     `json:"specialities"`
+	Level        string       `json:"level"`
+	Category     string       `json:"category"`
+	ProjectIDs   []uint       `json:"project_ids,omitempty"`
+	CareerIDs    []uint       `json:"career_ids,omitempty"`
+	Evidence     *EvidenceDto `json:"evidence,omitempty"`
+}
+
+// EvidenceDto lists the public projects and the career entries a skill was used in
+type EvidenceDto struct {
+	Projects []EvidenceProjectDto `json:"projects"`
+	Careers  []EvidenceCareerDto  `json:"careers"`
+}
+
+type EvidenceProjectDto struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+type EvidenceCareerDto struct {
+	ID          uint   `json:"id"`
+	Title       string `json:"title"`
+	Affiliation string `json:"affiliation"`
+	Type        string `json:"type"`
+}
+
+type TrashedSkillDto struct {
+	SkillItemDto
+	DeletedAt time.Time `json:"deleted_at"`
 }
 
 type TechnicalSkillDto struct {
 	Skills []SkillItemDto `json:"skills"`
 }
 
+// CareerItemDto is an education or job entry. StartedAt and EndedAt are
+// months formatted as YYYY-MM; EndedAt is empty while Ongoing.
 type CareerItemDto struct {
 	ID          uint   `json:"id"`
 	Title       string `json:"title"`
@@ -35,8 +72,17 @@ type CareerItemDto struct {
 	Type        string `json:"type"`
 	StartedAt   string `json:"started_at"`
 	EndedAt     string `json:"ended_at"`
+	Ongoing     bool   `json:"ongoing"`
+}
+
+type TrashedCareerDto struct {
+	CareerItemDto
+	DeletedAt time.Time `json:"deleted_at"`
 }
 
+// CareerJourneyDto lists the career entries, oldest first, with the years
+// spent in each type of entry
 type CareerJourneyDto struct {
-	Careers []CareerItemDto `json:"career"`
+	Careers    []CareerItemDto    `json:"career"`
+	TotalYears map[string]float64 `json:"total_years"`
 }
@@ -3,22 +3,42 @@ package about
 import (
 	"context"
 	"errors"
+	"time"
 
+	"github.com/lib/pq"
+	"github.com/othersidedrl/portfolio/backend/internal/database"
 	"github.com/othersidedrl/portfolio/backend/internal/models"
+	"github.com/othersidedrl/portfolio/backend/internal/search"
+	"github.com/othersidedrl/portfolio/backend/internal/technology"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type AboutRepository interface {
 	Find(ctx context.Context) (*AboutPageDto, error)
+	FindPublic(ctx context.Context) (*AboutPageDto, error)
+	GetAboutPageSchedule(ctx context.Context) (*models.Schedule, error)
+	SetAboutPageSchedule(ctx context.Context, schedule models.Schedule) error
 	Update(ctx context.Context, data *AboutPageDto) error
 	GetTechnicalSkills(ctx context.Context) (*TechnicalSkillDto, error)
+	GetTechnicalSkill(ctx context.Context, id uint) (*SkillItemDto, error)
+	GetSkillEvidence(ctx context.Context) (map[uint]*EvidenceDto, error)
 	CreateTechnicalSkill(ctx context.Context, data *SkillItemDto) error
 	UpdateTechnicalSkill(ctx context.Context, data *SkillItemDto, id uint) error
 	DeleteTechnicalSkill(ctx context.Context, id uint) error
+	GetTrashedTechnicalSkills(ctx context.Context) ([]TrashedSkillDto, error)
+	RestoreTrashedTechnicalSkill(ctx context.Context, id uint) error
+	PurgeTechnicalSkills(ctx context.Context, before time.Time) (int64, error)
+	GetSkillsWithoutTechnologies(ctx context.Context) ([]SkillItemDto, error)
+	SetSkillTechnologies(ctx context.Context, id uint, names []string) error
 	GetCareers(ctx context.Context) (*CareerJourneyDto, error)
+	GetCareer(ctx context.Context, id uint) (*CareerItemDto, error)
 	CreateCareer(ctx context.Context, data *CareerItemDto) error
 	UpdateCareer(ctx context.Context, data *CareerItemDto, id uint) error
 	DeleteCareer(ctx context.Context, id uint) error
+	GetTrashedCareers(ctx context.Context) ([]TrashedCareerDto, error)
+	RestoreTrashedCareer(ctx context.Context, id uint) error
+	PurgeCareers(ctx context.Context, before time.Time) (int64, error)
 }
 
 type GormAboutRepository struct {
@@ -30,11 +50,20 @@ func NewGormAboutRepository(db *gorm.DB) *GormAboutRepository {
 }
 
 func (r *GormAboutRepository) Find(ctx context.Context) (*AboutPageDto, error) {
+	return r.findAboutPage(ctx)
+}
+
+// FindPublic retrieves the about page unless its unpublish time has passed
+func (r *GormAboutRepository) FindPublic(ctx context.Context) (*AboutPageDto, error) {
+	return r.findAboutPage(ctx, models.NotUnpublished(time.Now()))
+}
+
+func (r *GormAboutRepository) findAboutPage(ctx context.Context, scopes ...func(*gorm.DB) *gorm.DB) (*AboutPageDto, error) {
 	var about models.AboutPage
 
 	// Load AboutPage along with its related AboutCards
-	if err := r.db.WithContext(ctx).
-		Preload("Cards"). // This loads the []AboutCard slice
+	if err := database.Conn(ctx, r.db).Scopes(scopes...).
+		Preload("Cards", orderCards). // This loads the []AboutCard slice
 		First(&about).Error; err != nil {
 		return nil, err
 	}
@@ -43,6 +72,7 @@ func (r *GormAboutRepository) Find(ctx context.Context) (*AboutPageDto, error) {
 	cards := make([]CardDto, len(about.Cards))
 	for i, c := range about.Cards {
 		cards[i] = CardDto{
+			ID:          c.ID,
 			Title:       c.Title,
 			Description: c.Description,
 		}
@@ -59,74 +89,117 @@ func (r *GormAboutRepository) Find(ctx context.Context) (*AboutPageDto, error) {
 	return dto, nil
 }
 
-func (r *GormAboutRepository) Update(ctx context.Context, data *AboutPageDto) error {
-	var existing models.AboutPage
+// orderCards keeps the cards in the order they were saved in
+func orderCards(db *gorm.DB) *gorm.DB {
+	return db.Order("position, id")
+}
 
-	err := r.db.WithContext(ctx).Preload("Cards").First(&existing).Error
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			cards := make([]models.AboutCard, len(data.Cards))
-			for i, c := range cards {
-				cards[i] = models.AboutCard{
-					Title:       c.Title,
-					Description: c.Description,
-				}
-			}
+func (r *GormAboutRepository) GetAboutPageSchedule(ctx context.Context) (*models.Schedule, error) {
+	var page models.AboutPage
+	if err := database.Conn(ctx, r.db).First(&page).Error; err != nil {
+		return nil, err
+	}
+	return &page.Schedule, nil
+}
 
-			aboutPage := models.AboutPage{
-				Description:  data.Description,
-				GithubLink:   data.GithubLink,
-				LinkedinLink: data.LinkedinLink,
-				Available:    data.Available,
-				Cards:        cards,
-			}
-			return r.db.WithContext(ctx).Create(&aboutPage).Error
-		}
+func (r *GormAboutRepository) SetAboutPageSchedule(ctx context.Context, schedule models.Schedule) error {
+	var page models.AboutPage
+	if err := database.Conn(ctx, r.db).First(&page).Error; err != nil {
 		return err
 	}
+	return database.Conn(ctx, r.db).Model(&page).
+		Select("publish_at", "unpublish_at").
+		Updates(&models.AboutPage{Schedule: schedule}).Error
+}
 
-	existing.Description = data.Description
-	existing.GithubLink = data.GithubLink
-	existing.LinkedinLink = data.LinkedinLink
-	existing.Available = data.Available
+// Update saves the about page. Cards are updated in place by ID, so their
+// translations stay with them when cards are added, removed or reordered.
+func (r *GormAboutRepository) Update(ctx context.Context, data *AboutPageDto) error {
+	return database.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		var existing models.AboutPage
+		err := tx.Preload("Cards", orderCards).First(&existing).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
 
-	// Delete old cards and insert new ones (simplest approach)
-	if err := r.db.WithContext(ctx).Unscoped().Where("about_page_id = ?", existing.ID).Delete(&models.AboutCard{}).Error; err != nil {
-		return err
+		existing.Description = data.Description
+		existing.GithubLink = data.GithubLink
+		existing.LinkedinLink = data.LinkedinLink
+		existing.Available = data.Available
+		if err := tx.Omit(clause.Associations).Save(&existing).Error; err != nil {
+			return err
+		}
+		return saveCards(tx, existing.ID, existing.Cards, data.Cards)
+	})
+}
+
+// saveCards writes the cards of a page in order. A card sent with the ID of
+// one of the page's cards updates it and any other card is added, getting
+// its new ID. Cards no longer sent are deleted along with their translations.
+func saveCards(tx *gorm.DB, pageID uint, current []models.AboutCard, cards []CardDto) error {
+	stored := make(map[uint]bool, len(current))
+	for _, card := range current {
+		stored[card.ID] = true
 	}
 
-	cards := make([]models.AboutCard, len(data.Cards))
-	for i, c := range data.Cards {
-		cards[i] = models.AboutCard{
+	kept := make(map[uint]bool, len(cards))
+	for i, c := range cards {
+		if stored[c.ID] && !kept[c.ID] {
+			kept[c.ID] = true
+			err := tx.Model(&models.AboutCard{}).Where("id = ?", c.ID).Updates(map[string]interface{}{
+				"title":       c.Title,
+				"description": c.Description,
+				"position":    i,
+			}).Error
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		card := models.AboutCard{
 			Title:       c.Title,
 			Description: c.Description,
+			AboutPageID: pageID,
+			Position:    i,
+		}
+		if err := tx.Create(&card).Error; err != nil {
+			return err
 		}
+		cards[i].ID = card.ID
 	}
 
-	existing.Cards = cards
-
-	// Save changes
-	return r.db.WithContext(ctx).Save(&existing).Error
+	var removed []uint
+	for _, card := range current {
+		if !kept[card.ID] {
+			removed = append(removed, card.ID)
+		}
+	}
+	if len(removed) == 0 {
+		return nil
+	}
+	err := tx.Where("entity_type = ? AND entity_id IN ?", models.EntityAboutCard, removed).
+		Delete(&models.Translation{}).Error
+	if err != nil {
+		return err
+	}
+	return tx.Unscoped().Where("id IN ?", removed).Delete(&models.AboutCard{}).Error
 }
 
 func (r *GormAboutRepository) GetTechnicalSkills(ctx context.Context) (*TechnicalSkillDto, error) {
 	var skills []models.TechnicalSkills
 
-	if err := r.db.WithContext(ctx).Find(&skills).Error; err != nil {
+	if err := database.Conn(ctx, r.db).Find(&skills).Error; err != nil {
 		return nil, err
 	}
 
 	// Map to DTO
 	var dtoSkills []SkillItemDto
 	for _, skill := range skills {
-		dtoSkills = append(dtoSkills, SkillItemDto{
-			ID:           skill.ID,
-			Name:         skill.Name,
-			Description:  skill.Description,
-			Specialities: skill.Specialities,
-			Level:        string(skill.Level),
-			Category:     string(skill.Category),
-		})
+		dtoSkills = append(dtoSkills, toSkillItemDto(skill))
+	}
+	if err := fillSkillLinks(database.Conn(ctx, r.db), dtoSkills); err != nil {
+		return nil, err
 	}
 
 	return &TechnicalSkillDto{
@@ -134,6 +207,32 @@ func (r *GormAboutRepository) GetTechnicalSkills(ctx context.Context) (*Technica
 	}, nil
 }
 
+func (r *GormAboutRepository) GetTechnicalSkill(ctx context.Context, id uint) (*SkillItemDto, error) {
+	var skill models.TechnicalSkills
+	if err := database.Conn(ctx, r.db).First(&skill, id).Error; err != nil {
+		return nil, err
+	}
+
+	dtos := []SkillItemDto{toSkillItemDto(skill)}
+	if err := fillSkillLinks(database.Conn(ctx, r.db), dtos); err != nil {
+		return nil, err
+	}
+	return &dtos[0], nil
+}
+
+func toSkillItemDto(skill models.TechnicalSkills) SkillItemDto {
+	return SkillItemDto{
+		ID:           skill.ID,
+		Name:         skill.Name,
+		Description:  skill.Description,
+		Specialities: skill.Specialities,
+		Level:        string(skill.Level),
+		Category:     string(skill.Category),
+	}
+}
+
+// CreateTechnicalSkill inserts the skill, links its specialities, projects
+// and career entries and sets data.ID to the new ID
 func (r *GormAboutRepository) CreateTechnicalSkill(ctx context.Context, data *SkillItemDto) error {
 	skill := models.TechnicalSkills{
 		Name:         data.Name,
@@ -143,43 +242,142 @@ func (r *GormAboutRepository) CreateTechnicalSkill(ctx context.Context, data *Sk
 		Category:     models.Cateogry(data.Category),
 	}
 
-	return r.db.WithContext(ctx).Create(&skill).Error
+	return database.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&skill).Error; err != nil {
+			return err
+		}
+		data.ID = skill.ID
+		if err := technology.SkillLinks.Link(tx, skill.ID, data.Specialities); err != nil {
+			return err
+		}
+		if err := search.Skills.Refresh(tx, skill.ID); err != nil {
+			return err
+		}
+		return setSkillLinks(tx, skill.ID, data)
+	})
 }
 
+// UpdateTechnicalSkill only changes the fields that are set. Nil lists,
+// specialities included, are left alone with their links; an empty list
+// clears them.
 func (r *GormAboutRepository) UpdateTechnicalSkill(ctx context.Context, data *SkillItemDto, id uint) error {
-	return r.db.WithContext(ctx).Where("id = ?", id).Updates(
-		models.TechnicalSkills{
-			Name:         data.Name,
-			Description:  data.Description,
-			Specialities: data.Specialities,
-			Level:        models.SkillLevel(data.Level),
-			Category:     models.Cateogry(data.Category),
-		}).Error
+	return database.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("id = ?", id).Updates(
+			models.TechnicalSkills{
+				Name:         data.Name,
+				Description:  data.Description,
+				Specialities: data.Specialities,
+				Level:        models.SkillLevel(data.Level),
+				Category:     models.Cateogry(data.Category),
+			}).Error
+		if err != nil {
+			return err
+		}
+		if data.Specialities != nil {
+			if err := technology.SkillLinks.Link(tx, id, data.Specialities); err != nil {
+				return err
+			}
+		}
+		if err := search.Skills.Refresh(tx, id); err != nil {
+			return err
+		}
+		return setSkillLinks(tx, id, data)
+	})
 }
 
+// DeleteTechnicalSkill moves the skill to the trash
 func (r *GormAboutRepository) DeleteTechnicalSkill(ctx context.Context, id uint) error {
-	return r.db.WithContext(ctx).Where("id = ?", id).Unscoped().Delete(&models.TechnicalSkills{}).Error
+	return database.Conn(ctx, r.db).Where("id = ?", id).Delete(&models.TechnicalSkills{}).Error
+}
+
+func (r *GormAboutRepository) GetTrashedTechnicalSkills(ctx context.Context) ([]TrashedSkillDto, error) {
+	var skills []models.TechnicalSkills
+	if err := database.Conn(ctx, r.db).Scopes(models.Trashed).Order("deleted_at DESC").Find(&skills).Error; err != nil {
+		return nil, err
+	}
+	trashed := make([]TrashedSkillDto, 0, len(skills))
+	for _, skill := range skills {
+		trashed = append(trashed, TrashedSkillDto{
+			SkillItemDto: toSkillItemDto(skill),
+			DeletedAt:    skill.DeletedAt.Time,
+		})
+	}
+	return trashed, nil
+}
+
+// RestoreTrashedTechnicalSkill takes the skill out of the trash
+func (r *GormAboutRepository) RestoreTrashedTechnicalSkill(ctx context.Context, id uint) error {
+	return r.restoreTrashed(ctx, &models.TechnicalSkills{}, id)
+}
+
+// PurgeTechnicalSkills permanently deletes the skills trashed before the
+// given time, along with their links and translations
+func (r *GormAboutRepository) PurgeTechnicalSkills(ctx context.Context, before time.Time) (int64, error) {
+	var purged int64
+	err := database.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		trashed := tx.Model(&models.TechnicalSkills{}).Scopes(models.TrashedBefore(before)).Select("id")
+		if err := technology.SkillLinks.Unlink(tx, trashed); err != nil {
+			return err
+		}
+		if err := skillProjects.unlinkSkills(tx, trashed); err != nil {
+			return err
+		}
+		if err := skillCareers.unlinkSkills(tx, trashed); err != nil {
+			return err
+		}
+		if err := models.PurgeEntityContent(tx, models.EntityTechnicalSkill, trashed); err != nil {
+			return err
+		}
+		result := tx.Scopes(models.TrashedBefore(before)).Delete(&models.TechnicalSkills{})
+		purged = result.RowsAffected
+		return result.Error
+	})
+	return purged, err
+}
+
+// GetSkillsWithoutTechnologies returns the skills listing specialities they
+// are not linked to yet, from before technologies were tracked
+func (r *GormAboutRepository) GetSkillsWithoutTechnologies(ctx context.Context) ([]SkillItemDto, error) {
+	var skills []models.TechnicalSkills
+	err := database.Conn(ctx, r.db).Unscoped().
+		Where("cardinality(specialities) > 0").
+		Where("NOT EXISTS (SELECT 1 FROM skill_technologies st WHERE st.technical_skills_id = technical_skills.id)").
+		Find(&skills).Error
+	if err != nil {
+		return nil, err
+	}
+	dtos := make([]SkillItemDto, 0, len(skills))
+	for _, skill := range skills {
+		dtos = append(dtos, toSkillItemDto(skill))
+	}
+	return dtos, nil
+}
+
+// SetSkillTechnologies stores the canonical specialities of a skill and links them
+func (r *GormAboutRepository) SetSkillTechnologies(ctx context.Context, id uint, names []string) error {
+	return database.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		err := tx.Unscoped().Model(&models.TechnicalSkills{}).Where("id = ?", id).
+			UpdateColumn("specialities", pq.StringArray(names)).Error
+		if err != nil {
+			return err
+		}
+		if err := technology.SkillLinks.Link(tx, id, names); err != nil {
+			return err
+		}
+		return search.Skills.Refresh(tx, id)
+	})
 }
 
 func (r *GormAboutRepository) GetCareers(ctx context.Context) (*CareerJourneyDto, error) {
 	var careers []models.CareerJourney
 
-	if err := r.db.WithContext(ctx).Find(&careers).Error; err != nil {
+	if err := database.Conn(ctx, r.db).Order("started_at, ended_at, id").Find(&careers).Error; err != nil {
 		return nil, err
 	}
 
 	var dtoCareers []CareerItemDto
 	for _, career := range careers {
-		dtoCareers = append(dtoCareers, CareerItemDto{
-			ID:          career.ID,
-			Title:       career.Title,
-			Description: career.Description,
-			Affiliation: career.Affiliation,
-			Location:    career.Location,
-			Type:        string(career.Type),
-			StartedAt:   career.StartedAt,
-			EndedAt:     career.EndedAt,
-		})
+		dtoCareers = append(dtoCareers, toCareerItemDto(career))
 	}
 
 	return &CareerJourneyDto{
@@ -187,30 +385,136 @@ func (r *GormAboutRepository) GetCareers(ctx context.Context) (*CareerJourneyDto
 	}, nil
 }
 
-func (r *GormAboutRepository) CreateCareer(ctx context.Context, data *CareerItemDto) error {
-	return r.db.WithContext(ctx).Create(&models.CareerJourney{
+func (r *GormAboutRepository) GetCareer(ctx context.Context, id uint) (*CareerItemDto, error) {
+	var career models.CareerJourney
+	if err := database.Conn(ctx, r.db).First(&career, id).Error; err != nil {
+		return nil, err
+	}
+
+	dto := toCareerItemDto(career)
+	return &dto, nil
+}
+
+func toCareerItemDto(career models.CareerJourney) CareerItemDto {
+	return CareerItemDto{
+		ID:          career.ID,
+		Title:       career.Title,
+		Description: career.Description,
+		Affiliation: career.Affiliation,
+		Location:    career.Location,
+		Type:        string(career.Type),
+		StartedAt:   formatMonth(career.StartedAt),
+		EndedAt:     formatMonth(career.EndedAt),
+		Ongoing:     career.Ongoing,
+	}
+}
+
+func toCareerModel(data *CareerItemDto) (models.CareerJourney, error) {
+	start, end, err := data.dates()
+	if err != nil {
+		return models.CareerJourney{}, err
+	}
+	return models.CareerJourney{
 		Title:       data.Title,
 		Description: data.Description,
 		Affiliation: data.Affiliation,
 		Location:    data.Location,
 		Type:        models.CareerType(data.Type),
-		StartedAt:   data.StartedAt,
-		EndedAt:     data.EndedAt,
-	}).Error
+		StartedAt:   start,
+		EndedAt:     end,
+		Ongoing:     data.Ongoing,
+	}, nil
 }
 
+// CreateCareer inserts the career entry and sets data.ID to the new ID
+func (r *GormAboutRepository) CreateCareer(ctx context.Context, data *CareerItemDto) error {
+	career, err := toCareerModel(data)
+	if err != nil {
+		return err
+	}
+
+	return database.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&career).Error; err != nil {
+			return err
+		}
+		data.ID = career.ID
+		return search.Careers.Refresh(tx, career.ID)
+	})
+}
+
+// UpdateCareer overwrites every field of the career entry, so an ended
+// entry can become ongoing
 func (r *GormAboutRepository) UpdateCareer(ctx context.Context, data *CareerItemDto, id uint) error {
-	return r.db.WithContext(ctx).Where("id = ?", id).Updates(&models.CareerJourney{
-		Title:       data.Title,
-		Description: data.Description,
-		Affiliation: data.Affiliation,
-		Location:    data.Location,
-		Type:        models.CareerType(data.Type),
-		StartedAt:   data.StartedAt,
-		EndedAt:     data.EndedAt,
-	}).Error
+	career, err := toCareerModel(data)
+	if err != nil {
+		return err
+	}
+
+	return database.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&models.CareerJourney{}).Where("id = ?", id).
+			Select("title", "description", "affiliation", "location", "type", "started_at", "ended_at", "ongoing", "updated_at").
+			Updates(&career).Error
+		if err != nil {
+			return err
+		}
+		return search.Careers.Refresh(tx, id)
+	})
 }
 
+// DeleteCareer moves the career entry to the trash
 func (r *GormAboutRepository) DeleteCareer(ctx context.Context, id uint) error {
-	return r.db.WithContext(ctx).Where("id = ?", id).Unscoped().Delete(&models.CareerJourney{}).Error
+	return database.Conn(ctx, r.db).Where("id = ?", id).Delete(&models.CareerJourney{}).Error
+}
+
+func (r *GormAboutRepository) GetTrashedCareers(ctx context.Context) ([]TrashedCareerDto, error) {
+	var careers []models.CareerJourney
+	if err := database.Conn(ctx, r.db).Scopes(models.Trashed).Order("deleted_at DESC").Find(&careers).Error; err != nil {
+		return nil, err
+	}
+	trashed := make([]TrashedCareerDto, 0, len(careers))
+	for _, career := range careers {
+		trashed = append(trashed, TrashedCareerDto{
+			CareerItemDto: toCareerItemDto(career),
+			DeletedAt:     career.DeletedAt.Time,
+		})
+	}
+	return trashed, nil
+}
+
+// RestoreTrashedCareer takes the career entry out of the trash
+func (r *GormAboutRepository) RestoreTrashedCareer(ctx context.Context, id uint) error {
+	return r.restoreTrashed(ctx, &models.CareerJourney{}, id)
+}
+
+// PurgeCareers permanently deletes the career entries trashed before the
+// given time, along with their skill links and translations
+func (r *GormAboutRepository) PurgeCareers(ctx context.Context, before time.Time) (int64, error) {
+	var purged int64
+	err := database.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		trashed := tx.Model(&models.CareerJourney{}).Scopes(models.TrashedBefore(before)).Select("id")
+		if err := skillCareers.unlink(tx, trashed); err != nil {
+			return err
+		}
+		if err := models.PurgeEntityContent(tx, models.EntityCareer, trashed); err != nil {
+			return err
+		}
+		result := tx.Scopes(models.TrashedBefore(before)).Delete(&models.CareerJourney{})
+		purged = result.RowsAffected
+		return result.Error
+	})
+	return purged, err
+}
+
+func (r *GormAboutRepository) restoreTrashed(ctx context.Context, model interface{}, id uint) error {
+	result := database.Conn(ctx, r.db).Model(model).
+		Scopes(models.Trashed).
+		Where("id = ?", id).
+		Update("deleted_at", nil)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
 }
@@ -2,52 +2,460 @@ package about
 
 import (
 	"context"
+	"errors"
+	"time"
+
+	"github.com/othersidedrl/portfolio/backend/internal/audit"
+	"github.com/othersidedrl/portfolio/backend/internal/content"
+	"github.com/othersidedrl/portfolio/backend/internal/models"
+	"github.com/othersidedrl/portfolio/backend/internal/technology"
+	"github.com/othersidedrl/portfolio/backend/internal/translation"
+	"gorm.io/gorm"
 )
 
 type Service struct {
-	repo AboutRepository
+	repo         AboutRepository
+	versions     *content.Service
+	translations *translation.Service
+	technologies *technology.Service
+	recorder     audit.Recorder
 }
 
-func NewService(repo AboutRepository) *Service {
-	return &Service{repo}
+func NewService(repo AboutRepository, versions *content.Service, translations *translation.Service, technologies *technology.Service, recorder audit.Recorder) *Service {
+	return &Service{repo, versions, translations, technologies, recorder}
 }
 
 func (s *Service) Find(ctx context.Context) (*AboutPageDto, error) {
 	return s.repo.Find(ctx)
 }
 
-func (s *Service) Update(ctx context.Context, data AboutPageDto) error {
-	return s.repo.Update(ctx, &data)
+// FindPublic returns the about page for the public site
+func (s *Service) FindPublic(ctx context.Context) (*AboutPageDto, error) {
+	about, err := s.repo.FindPublic(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.translations.Translate(ctx, models.EntityAboutPage, 0, aboutFields(about)); err != nil {
+		return nil, err
+	}
+	if err := s.translations.TranslateMany(ctx, models.EntityAboutCard, cardFields(about.Cards)); err != nil {
+		return nil, err
+	}
+	return about, nil
+}
+
+// TranslatableFields lists the about page, card and career text that can be translated
+func (s *Service) TranslatableFields(ctx context.Context) ([]translation.SourceField, error) {
+	var fields []translation.SourceField
+
+	about, err := s.repo.Find(ctx)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	if about != nil {
+		fields = append(fields, translation.SourceFields(models.EntityAboutPage, 0, aboutFields(about))...)
+		for id, card := range cardFields(about.Cards) {
+			fields = append(fields, translation.SourceFields(models.EntityAboutCard, id, card)...)
+		}
+	}
+
+	careers, err := s.repo.GetCareers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for id, career := range careerFields(careers.Careers) {
+		fields = append(fields, translation.SourceFields(models.EntityCareer, id, career)...)
+	}
+	return fields, nil
+}
+
+func aboutFields(about *AboutPageDto) translation.Fields {
+	return translation.Fields{"description": &about.Description}
+}
+
+func cardFields(cards []CardDto) map[uint]translation.Fields {
+	fields := make(map[uint]translation.Fields, len(cards))
+	for i := range cards {
+		fields[cards[i].ID] = translation.Fields{"title": &cards[i].Title, "description": &cards[i].Description}
+	}
+	return fields
+}
+
+func careerFields(careers []CareerItemDto) map[uint]translation.Fields {
+	fields := make(map[uint]translation.Fields, len(careers))
+	for i := range careers {
+		fields[careers[i].ID] = translation.Fields{"title": &careers[i].Title, "description": &careers[i].Description}
+	}
+	return fields
+}
+
+func (s *Service) GetAboutPageSchedule(ctx context.Context) (*content.ScheduleDto, error) {
+	schedule, err := s.repo.GetAboutPageSchedule(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return content.ToScheduleDto(*schedule), nil
+}
+
+// SetAboutPageSchedule sets when the about page draft goes live and when the page goes offline
+func (s *Service) SetAboutPageSchedule(ctx context.Context, data content.ScheduleDto) error {
+	schedule, err := content.ToSchedule(data)
+	if err != nil {
+		return err
+	}
+
+	before, err := s.GetAboutPageSchedule(ctx)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.SetAboutPageSchedule(ctx, schedule); err != nil {
+		return err
+	}
+
+	s.recorder.Record(ctx, models.AuditSchedule, models.EntityAboutPage, nil, before, data)
+	return nil
+}
+
+// RunAboutPageSchedule publishes the about page draft once its publish time has come. It
+// reports whether the public page changed since the previous run.
+func (s *Service) RunAboutPageSchedule(ctx context.Context, since, now time.Time) (bool, error) {
+	schedule, err := s.repo.GetAboutPageSchedule(ctx)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	changed := schedule.UnpublishedBetween(since, now)
+	if schedule.PublishDue(now) {
+		if err := s.PublishAboutPage(ctx); err != nil && !errors.Is(err, content.ErrNoDraft) {
+			return false, err
+		}
+		schedule.PublishAt = nil
+		if err := s.repo.SetAboutPageSchedule(ctx, *schedule); err != nil {
+			return false, err
+		}
+		changed = true
+	}
+	return changed, nil
+}
+
+// SaveAboutPageDraft stores an edit of the about page without changing the live page
+func (s *Service) SaveAboutPageDraft(ctx context.Context, data *AboutPageDto) error {
+	return s.versions.SaveDraft(ctx, models.EntityAboutPage, 0, data)
+}
+
+// PreviewAboutPage returns the draft of the about page, or the live page when there is no draft
+func (s *Service) PreviewAboutPage(ctx context.Context) (*AboutPageDto, error) {
+	var draft AboutPageDto
+	found, err := s.versions.LoadDraft(ctx, models.EntityAboutPage, 0, &draft)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return s.repo.Find(ctx)
+	}
+	return &draft, nil
+}
+
+// PublishAboutPage makes the draft the live about page
+func (s *Service) PublishAboutPage(ctx context.Context) error {
+	var draft AboutPageDto
+	found, err := s.versions.LoadDraft(ctx, models.EntityAboutPage, 0, &draft)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return content.ErrNoDraft
+	}
+
+	if err := s.applyAboutPage(ctx, &draft, models.AuditPublish); err != nil {
+		return err
+	}
+	return s.versions.DeleteDraft(ctx, models.EntityAboutPage, 0)
+}
+
+// RestoreAboutPage rolls the live about page back to one of its revisions
+func (s *Service) RestoreAboutPage(ctx context.Context, revisionID uint) error {
+	var revision AboutPageDto
+	if err := s.versions.LoadRevision(ctx, models.EntityAboutPage, 0, revisionID, &revision); err != nil {
+		return err
+	}
+	return s.applyAboutPage(ctx, &revision, models.AuditRestore)
+}
+
+// ImportAboutPage writes imported content straight to the live about page.
+// A pending draft is kept and still replaces the page when it is published.
+func (s *Service) ImportAboutPage(ctx context.Context, data AboutPageDto) error {
+	return s.applyAboutPage(ctx, &data, models.AuditImport)
+}
+
+// applyAboutPage writes data to the live about page and keeps a revision of the result
+func (s *Service) applyAboutPage(ctx context.Context, data *AboutPageDto, action models.AuditAction) error {
+	before, err := s.repo.Find(ctx)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	if err := s.repo.Update(ctx, data); err != nil {
+		return err
+	}
+
+	after, err := s.repo.Find(ctx)
+	if err != nil {
+		return err
+	}
+	if err := s.versions.SaveRevision(ctx, models.EntityAboutPage, 0, before, after); err != nil {
+		return err
+	}
+	s.recorder.Record(ctx, action, models.EntityAboutPage, nil, before, after)
+	return nil
+}
+
+// DiscardAboutPageDraft throws away the unpublished changes to the about page
+func (s *Service) DiscardAboutPageDraft(ctx context.Context) error {
+	return s.versions.DiscardDraft(ctx, models.EntityAboutPage, 0)
 }
 
 func (s *Service) GetTechnicalSkills(ctx context.Context) (*TechnicalSkillDto, error) {
 	return s.repo.GetTechnicalSkills(ctx)
 }
 
+// GetPublicTechnicalSkills returns the skills for the public site, each with
+// the public projects and career entries that show it in use
+func (s *Service) GetPublicTechnicalSkills(ctx context.Context) (*TechnicalSkillDto, error) {
+	skills, err := s.repo.GetTechnicalSkills(ctx)
+	if err != nil {
+		return nil, err
+	}
+	evidence, err := s.repo.GetSkillEvidence(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// A career entry can back several skills, so its title is translated once
+	titles := make(map[uint]*string)
+	for _, e := range evidence {
+		for _, career := range e.Careers {
+			title := career.Title
+			titles[career.ID] = &title
+		}
+	}
+	fields := make(map[uint]translation.Fields, len(titles))
+	for id, title := range titles {
+		fields[id] = translation.Fields{"title": title}
+	}
+	if err := s.translations.TranslateMany(ctx, models.EntityCareer, fields); err != nil {
+		return nil, err
+	}
+
+	for i := range skills.Skills {
+		skill := &skills.Skills[i]
+		skill.ProjectIDs, skill.CareerIDs = nil, nil
+		skill.Evidence = evidence[skill.ID]
+		if skill.Evidence == nil {
+			skill.Evidence = &EvidenceDto{Projects: []EvidenceProjectDto{}, Careers: []EvidenceCareerDto{}}
+			continue
+		}
+		for j := range skill.Evidence.Careers {
+			skill.Evidence.Careers[j].Title = *titles[skill.Evidence.Careers[j].ID]
+		}
+	}
+	return skills, nil
+}
+
 func (s *Service) CreateTechnicalSkill(ctx context.Context, data SkillItemDto) error {
-	return s.repo.CreateTechnicalSkill(ctx, &data)
+	if err := s.resolveSpecialities(ctx, &data); err != nil {
+		return err
+	}
+	if err := s.repo.CreateTechnicalSkill(ctx, &data); err != nil {
+		return err
+	}
+
+	after, err := s.repo.GetTechnicalSkill(ctx, data.ID)
+	if err != nil {
+		return err
+	}
+	s.recorder.Record(ctx, models.AuditCreate, models.EntityTechnicalSkill, data.ID, nil, after)
+	return nil
 }
 
 func (s *Service) UpdateTechnicalSkill(ctx context.Context, data SkillItemDto, id uint) error {
-	return s.repo.UpdateTechnicalSkill(ctx, &data, id)
+	before, err := s.repo.GetTechnicalSkill(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// Specialities left out of the update are kept, not cleared
+	if data.Specialities != nil {
+		if err := s.resolveSpecialities(ctx, &data); err != nil {
+			return err
+		}
+	}
+	if err := s.repo.UpdateTechnicalSkill(ctx, &data, id); err != nil {
+		return err
+	}
+
+	after, err := s.repo.GetTechnicalSkill(ctx, id)
+	if err != nil {
+		return err
+	}
+	s.recorder.Record(ctx, models.AuditUpdate, models.EntityTechnicalSkill, id, before, after)
+	return nil
+}
+
+// resolveSpecialities swaps the skill's specialities for the canonical technology names
+func (s *Service) resolveSpecialities(ctx context.Context, data *SkillItemDto) error {
+	specialities, err := s.technologies.Resolve(ctx, data.Specialities)
+	if err != nil {
+		return err
+	}
+	data.Specialities = specialities
+	return nil
+}
+
+// EnsureTechnologies links every skill created before technologies were
+// tracked to the technologies in its specialities
+func (s *Service) EnsureTechnologies(ctx context.Context) error {
+	skills, err := s.repo.GetSkillsWithoutTechnologies(ctx)
+	if err != nil {
+		return err
+	}
+	for _, skill := range skills {
+		specialities, err := s.technologies.Resolve(ctx, skill.Specialities)
+		if err != nil {
+			return err
+		}
+		if err := s.repo.SetSkillTechnologies(ctx, skill.ID, specialities); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func (s *Service) DeleteTechnicalSkill(ctx context.Context, id uint) error {
-	return s.repo.DeleteTechnicalSkill(ctx, id)
+	before, err := s.repo.GetTechnicalSkill(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.DeleteTechnicalSkill(ctx, id); err != nil {
+		return err
+	}
+
+	s.recorder.Record(ctx, models.AuditDelete, models.EntityTechnicalSkill, id, before, nil)
+	return nil
+}
+
+// GetTrashedTechnicalSkills lists the deleted skills that have not been purged yet
+func (s *Service) GetTrashedTechnicalSkills(ctx context.Context) ([]TrashedSkillDto, error) {
+	return s.repo.GetTrashedTechnicalSkills(ctx)
+}
+
+// RestoreTrashedTechnicalSkill brings a deleted skill back
+func (s *Service) RestoreTrashedTechnicalSkill(ctx context.Context, id uint) error {
+	if err := s.repo.RestoreTrashedTechnicalSkill(ctx, id); err != nil {
+		return err
+	}
+
+	after, err := s.repo.GetTechnicalSkill(ctx, id)
+	if err != nil {
+		return err
+	}
+	s.recorder.Record(ctx, models.AuditUndelete, models.EntityTechnicalSkill, id, nil, after)
+	return nil
+}
+
+// PurgeTechnicalSkills permanently deletes the skills trashed before the given time
+func (s *Service) PurgeTechnicalSkills(ctx context.Context, before time.Time) (int64, error) {
+	return s.repo.PurgeTechnicalSkills(ctx, before)
 }
 
 func (s *Service) GetCareers(ctx context.Context) (*CareerJourneyDto, error) {
-	return s.repo.GetCareers(ctx)
+	careers, err := s.repo.GetCareers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.translations.TranslateMany(ctx, models.EntityCareer, careerFields(careers.Careers)); err != nil {
+		return nil, err
+	}
+	careers.TotalYears = totalYears(careers.Careers, time.Now())
+	return careers, nil
 }
 
 func (s *Service) CreateCareer(ctx context.Context, data CareerItemDto) error {
-	return s.repo.CreateCareer(ctx, &data)
+	if err := ValidateCareer(&data); err != nil {
+		return err
+	}
+	if err := s.repo.CreateCareer(ctx, &data); err != nil {
+		return err
+	}
+
+	after, err := s.repo.GetCareer(ctx, data.ID)
+	if err != nil {
+		return err
+	}
+	s.recorder.Record(ctx, models.AuditCreate, models.EntityCareer, data.ID, nil, after)
+	return nil
 }
 
 func (s *Service) UpdateCareer(ctx context.Context, data CareerItemDto, id uint) error {
-	return s.repo.UpdateCareer(ctx, &data, id)
+	before, err := s.repo.GetCareer(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	merged := MergeCareer(*before, data)
+	if err := ValidateCareer(&merged); err != nil {
+		return err
+	}
+	if err := s.repo.UpdateCareer(ctx, &merged, id); err != nil {
+		return err
+	}
+
+	after, err := s.repo.GetCareer(ctx, id)
+	if err != nil {
+		return err
+	}
+	s.recorder.Record(ctx, models.AuditUpdate, models.EntityCareer, id, before, after)
+	return nil
 }
 
 func (s *Service) DeleteCareer(ctx context.Context, id uint) error {
-	return s.repo.DeleteCareer(ctx, id)
+	before, err := s.repo.GetCareer(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.DeleteCareer(ctx, id); err != nil {
+		return err
+	}
+
+	s.recorder.Record(ctx, models.AuditDelete, models.EntityCareer, id, before, nil)
+	return nil
+}
+
+// GetTrashedCareers lists the deleted career entries that have not been purged yet
+func (s *Service) GetTrashedCareers(ctx context.Context) ([]TrashedCareerDto, error) {
+	return s.repo.GetTrashedCareers(ctx)
+}
+
+// RestoreTrashedCareer brings a deleted career entry back
+func (s *Service) RestoreTrashedCareer(ctx context.Context, id uint) error {
+	if err := s.repo.RestoreTrashedCareer(ctx, id); err != nil {
+		return err
+	}
+
+	after, err := s.repo.GetCareer(ctx, id)
+	if err != nil {
+		return err
+	}
+	s.recorder.Record(ctx, models.AuditUndelete, models.EntityCareer, id, nil, after)
+	return nil
+}
+
+// PurgeCareers permanently deletes the career entries trashed before the given time
+func (s *Service) PurgeCareers(ctx context.Context, before time.Time) (int64, error) {
+	return s.repo.PurgeCareers(ctx, before)
 }
@@ -0,0 +1,144 @@
+package about
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/othersidedrl/portfolio/backend/internal/middleware"
+	"github.com/othersidedrl/portfolio/backend/internal/models"
+	"github.com/othersidedrl/portfolio/backend/internal/technology"
+	"github.com/othersidedrl/portfolio/backend/internal/translation"
+)
+
+// fakeAboutRepository records skill updates. Methods the tests do not use
+// panic through the nil embedded interface.
+type fakeAboutRepository struct {
+	AboutRepository
+	skill   SkillItemDto
+	updated *SkillItemDto
+}
+
+func (r *fakeAboutRepository) GetTechnicalSkill(ctx context.Context, id uint) (*SkillItemDto, error) {
+	skill := r.skill
+	return &skill, nil
+}
+
+func (r *fakeAboutRepository) UpdateTechnicalSkill(ctx context.Context, data *SkillItemDto, id uint) error {
+	updated := *data
+	r.updated = &updated
+	return nil
+}
+
+// fakeTechnologyRepository knows a single technology, Go, also called golang
+type fakeTechnologyRepository struct {
+	technology.TechnologyRepository
+	lookups int
+}
+
+func (r *fakeTechnologyRepository) FindByKeys(ctx context.Context, keys []string) ([]models.Technology, error) {
+	r.lookups++
+	return []models.Technology{{Name: "Go", Aliases: []string{"golang"}}}, nil
+}
+
+type nopRecorder struct{}
+
+func (nopRecorder) Record(ctx context.Context, action models.AuditAction, entityType models.EntityType, entityID interface{}, before, after interface{}) {
+}
+
+func TestUpdateTechnicalSkillSpecialities(t *testing.T) {
+	tests := []struct {
+		name         string
+		specialities []string
+		want         []string
+		lookups      int
+	}{
+		{"partial update keeps them", nil, nil, 0},
+		{"given list is resolved", []string{"golang"}, []string{"Go"}, 1},
+		{"empty list clears them", []string{}, []string{}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeAboutRepository{skill: SkillItemDto{ID: 1, Name: "Backend", Specialities: []string{"Go"}}}
+			techs := &fakeTechnologyRepository{}
+			service := NewService(repo, nil, nil, technology.NewService(techs, nopRecorder{}), nopRecorder{})
+
+			err := service.UpdateTechnicalSkill(context.Background(), SkillItemDto{Level: "Advanced", Specialities: tt.specialities}, 1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(repo.updated.Specialities, tt.want) {
+				t.Errorf("specialities written = %#v, want %#v", repo.updated.Specialities, tt.want)
+			}
+			if techs.lookups != tt.lookups {
+				t.Errorf("technology lookups = %d, want %d", techs.lookups, tt.lookups)
+			}
+		})
+	}
+}
+
+// fakeAboutPageRepository serves a fixed about page
+type fakeAboutPageRepository struct {
+	AboutRepository
+	page AboutPageDto
+}
+
+func (r *fakeAboutPageRepository) FindPublic(ctx context.Context) (*AboutPageDto, error) {
+	page := r.page
+	page.Cards = append([]CardDto{}, r.page.Cards...)
+	return &page, nil
+}
+
+// fakeTranslationRepository holds French card titles by card ID
+type fakeTranslationRepository struct {
+	translation.TranslationRepository
+	titles map[uint]string
+}
+
+func (r *fakeTranslationRepository) FindForEntities(ctx context.Context, entityType models.EntityType, entityIDs []uint, locale string) ([]models.Translation, error) {
+	var translations []models.Translation
+	for _, id := range entityIDs {
+		if title, ok := r.titles[id]; ok && entityType == models.EntityAboutCard && locale == "fr" {
+			translations = append(translations, models.Translation{EntityType: entityType, EntityID: id, Field: "title", Locale: locale, Value: title})
+		}
+	}
+	return translations, nil
+}
+
+func TestCardTranslationsFollowTheCard(t *testing.T) {
+	locales := middleware.LocaleConfig{Default: "en", Supported: []string{"en", "fr"}}
+	translations := translation.NewService(&fakeTranslationRepository{titles: map[uint]string{
+		7: "Backend (fr)",
+		9: "Frontend (fr)",
+	}}, locales, nopRecorder{})
+
+	// Frontend moved in front of Backend, and a new card without a translation added first
+	repo := &fakeAboutPageRepository{page: AboutPageDto{Cards: []CardDto{
+		{ID: 12, Title: "Mobile"},
+		{ID: 9, Title: "Frontend"},
+		{ID: 7, Title: "Backend"},
+	}}}
+	service := NewService(repo, nil, translations, nil, nopRecorder{})
+
+	var ctx context.Context
+	r := httptest.NewRequest("GET", "/api/v1/about?lang=fr", nil)
+	middleware.Locale(locales)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx = r.Context()
+	})).ServeHTTP(httptest.NewRecorder(), r)
+
+	about, err := service.FindPublic(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var titles []string
+	for _, card := range about.Cards {
+		titles = append(titles, card.Title)
+	}
+	want := []string{"Mobile", "Frontend (fr)", "Backend (fr)"}
+	if !reflect.DeepEqual(titles, want) {
+		t.Errorf("titles = %v, want %v", titles, want)
+	}
+}
@@ -0,0 +1,29 @@
+package apikey
+
+import "time"
+
+type APIKeyDto struct {
+	ID         uint     `json:"id"`
+	Name       string   `json:"name"`
+	Prefix     string   `json:"prefix"`
+	Scopes     []string `json:"scopes"`
+	ExpiresAt  *string  `json:"expires_at"`
+	LastUsedAt *string  `json:"last_used_at"`
+	CreatedAt  string   `json:"created_at"`
+}
+
+type CreateAPIKeyDto struct {
+	Name      string     `json:"name"`
+	Scopes    []string   `json:"scopes"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// CreatedAPIKeyDto is the only response that ever contains the plain key
+type CreatedAPIKeyDto struct {
+	APIKeyDto
+	Key string `json:"key"`
+}
+
+type APIKeysDto struct {
+	Keys []APIKeyDto `json:"keys"`
+}
@@ -0,0 +1,74 @@
+package apikey
+
+import (
+	"context"
+	"time"
+
+	"github.com/othersidedrl/portfolio/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+type APIKeyRepository interface {
+	FindAll(ctx context.Context) ([]models.APIKey, error)
+	FindByID(ctx context.Context, id uint) (*models.APIKey, error)
+	FindByHash(ctx context.Context, hash string) (*models.APIKey, error)
+	FindCreatorRole(ctx context.Context, userID uint) (models.UserRole, error)
+	Create(ctx context.Context, key *models.APIKey) error
+	Delete(ctx context.Context, id uint) (bool, error)
+	TouchLastUsed(ctx context.Context, id uint, at time.Time) error
+}
+
+type GormAPIKeyRepository struct {
+	db *gorm.DB
+}
+
+func NewGormAPIKeyRepository(db *gorm.DB) *GormAPIKeyRepository {
+	return &GormAPIKeyRepository{db: db}
+}
+
+func (r *GormAPIKeyRepository) FindAll(ctx context.Context) ([]models.APIKey, error) {
+	var keys []models.APIKey
+	if err := r.db.WithContext(ctx).Order("created_at DESC").Find(&keys).Error; err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+func (r *GormAPIKeyRepository) FindByID(ctx context.Context, id uint) (*models.APIKey, error) {
+	var key models.APIKey
+	if err := r.db.WithContext(ctx).First(&key, id).Error; err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+func (r *GormAPIKeyRepository) FindByHash(ctx context.Context, hash string) (*models.APIKey, error) {
+	var key models.APIKey
+	if err := r.db.WithContext(ctx).Where("key_hash = ?", hash).First(&key).Error; err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// FindCreatorRole returns the current role of the user who created a key
+func (r *GormAPIKeyRepository) FindCreatorRole(ctx context.Context, userID uint) (models.UserRole, error) {
+	var user models.User
+	if err := r.db.WithContext(ctx).Select("id", "role").First(&user, userID).Error; err != nil {
+		return "", err
+	}
+	return user.Role, nil
+}
+
+func (r *GormAPIKeyRepository) Create(ctx context.Context, key *models.APIKey) error {
+	return r.db.WithContext(ctx).Create(key).Error
+}
+
+// Delete removes the key for good, reporting whether it existed
+func (r *GormAPIKeyRepository) Delete(ctx context.Context, id uint) (bool, error) {
+	result := r.db.WithContext(ctx).Where("id = ?", id).Unscoped().Delete(&models.APIKey{})
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *GormAPIKeyRepository) TouchLastUsed(ctx context.Context, id uint, at time.Time) error {
+	return r.db.WithContext(ctx).Model(&models.APIKey{}).Where("id = ?", id).UpdateColumn("last_used_at", at).Error
+}
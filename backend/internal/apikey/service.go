@@ -0,0 +1,182 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/othersidedrl/portfolio/backend/internal/audit"
+	"github.com/othersidedrl/portfolio/backend/internal/models"
+	"github.com/othersidedrl/portfolio/backend/internal/utils"
+	"gorm.io/gorm"
+)
+
+const (
+	keyPrefix    = "pk_"
+	displayChars = 12
+
+	// lastUsedResolution limits last_used_at writes to one per key per minute
+	lastUsedResolution = time.Minute
+)
+
+var (
+	ErrAPIKeyNotFound = errors.New("api key not found")
+	ErrInvalidAPIKey  = errors.New("invalid api key")
+	ErrInvalidScope   = errors.New("invalid scope")
+	ErrMissingName    = errors.New("name is required")
+	ErrExpiryInPast   = errors.New("expires_at must be in the future")
+)
+
+type Service struct {
+	repo     APIKeyRepository
+	recorder audit.Recorder
+}
+
+func NewService(repo APIKeyRepository, recorder audit.Recorder) *Service {
+	return &Service{repo, recorder}
+}
+
+func (s *Service) GetAPIKeys(ctx context.Context) (*APIKeysDto, error) {
+	keys, err := s.repo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	dtoKeys := make([]APIKeyDto, len(keys))
+	for i, k := range keys {
+		dtoKeys[i] = toDto(&k)
+	}
+	return &APIKeysDto{Keys: dtoKeys}, nil
+}
+
+// CreateAPIKey generates a new key. The plain key is only returned here.
+func (s *Service) CreateAPIKey(ctx context.Context, data *CreateAPIKeyDto, createdByID uint) (*CreatedAPIKeyDto, error) {
+	if strings.TrimSpace(data.Name) == "" {
+		return nil, ErrMissingName
+	}
+	for _, scope := range data.Scopes {
+		if !models.Permission(scope).IsAPIKeyScope() {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidScope, scope)
+		}
+	}
+	if data.ExpiresAt != nil && !data.ExpiresAt.After(time.Now()) {
+		return nil, ErrExpiryInPast
+	}
+
+	secret, err := utils.RandomToken(32)
+	if err != nil {
+		return nil, err
+	}
+	plain := keyPrefix + secret
+
+	key := &models.APIKey{
+		Name:        strings.TrimSpace(data.Name),
+		Prefix:      plain[:displayChars],
+		KeyHash:     utils.HashToken(plain),
+		Scopes:      data.Scopes,
+		ExpiresAt:   data.ExpiresAt,
+		CreatedByID: createdByID,
+	}
+	if err := s.repo.Create(ctx, key); err != nil {
+		return nil, err
+	}
+
+	dto := toDto(key)
+	s.recorder.Record(ctx, models.AuditCreate, models.EntityAPIKey, key.ID, nil, dto)
+	return &CreatedAPIKeyDto{APIKeyDto: dto, Key: plain}, nil
+}
+
+func (s *Service) DeleteAPIKey(ctx context.Context, id uint) error {
+	key, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrAPIKeyNotFound
+		}
+		return err
+	}
+
+	found, err := s.repo.Delete(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return ErrAPIKeyNotFound
+	}
+
+	s.recorder.Record(ctx, models.AuditDelete, models.EntityAPIKey, id, toDto(key), nil)
+	return nil
+}
+
+// Authenticate resolves an X-API-Key header into claims carrying the key's
+// scopes, as far as its creator is still allowed them
+func (s *Service) Authenticate(ctx context.Context, plain string) (*utils.JWTClaims, error) {
+	if !strings.HasPrefix(plain, keyPrefix) {
+		return nil, ErrInvalidAPIKey
+	}
+
+	key, err := s.repo.FindByHash(ctx, utils.HashToken(plain))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvalidAPIKey
+		}
+		return nil, err
+	}
+
+	now := time.Now()
+	if key.ExpiresAt != nil && now.After(*key.ExpiresAt) {
+		return nil, ErrInvalidAPIKey
+	}
+
+	// A key stops working with the account that created it, and never
+	// grants more than that account's role does now
+	role, err := s.repo.FindCreatorRole(ctx, key.CreatedByID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvalidAPIKey
+		}
+		return nil, err
+	}
+	scopes := make([]string, 0, len(key.Scopes))
+	for _, scope := range key.Scopes {
+		if role.Can(models.Permission(scope)) {
+			scopes = append(scopes, scope)
+		}
+	}
+
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) > lastUsedResolution {
+		if err := s.repo.TouchLastUsed(ctx, key.ID, now); err != nil {
+			log.Printf("⚠️ Failed to update last_used_at for api key %d: %v", key.ID, err)
+		}
+	}
+
+	return &utils.JWTClaims{
+		Sub:    utils.APIKeySubjectPrefix + strconv.FormatUint(uint64(key.ID), 10),
+		Scopes: scopes,
+	}, nil
+}
+
+func toDto(k *models.APIKey) APIKeyDto {
+	dto := APIKeyDto{
+		ID:        k.ID,
+		Name:      k.Name,
+		Prefix:    k.Prefix,
+		Scopes:    k.Scopes,
+		CreatedAt: k.CreatedAt.Format(time.RFC3339),
+	}
+	if dto.Scopes == nil {
+		dto.Scopes = []string{}
+	}
+	if k.ExpiresAt != nil {
+		expiresAt := k.ExpiresAt.Format(time.RFC3339)
+		dto.ExpiresAt = &expiresAt
+	}
+	if k.LastUsedAt != nil {
+		lastUsedAt := k.LastUsedAt.Format(time.RFC3339)
+		dto.LastUsedAt = &lastUsedAt
+	}
+	return dto
+}
@@ -0,0 +1,73 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/othersidedrl/portfolio/backend/internal/models"
+	"github.com/othersidedrl/portfolio/backend/internal/utils"
+	"gorm.io/gorm"
+)
+
+const testKey = keyPrefix + "secret"
+
+// fakeAPIKeyRepository holds one key, created by a user with the given role.
+// An empty role means the user has been deleted.
+type fakeAPIKeyRepository struct {
+	APIKeyRepository
+	key  models.APIKey
+	role models.UserRole
+}
+
+func (r *fakeAPIKeyRepository) FindByHash(ctx context.Context, hash string) (*models.APIKey, error) {
+	if hash != utils.HashToken(testKey) {
+		return nil, gorm.ErrRecordNotFound
+	}
+	key := r.key
+	return &key, nil
+}
+
+func (r *fakeAPIKeyRepository) FindCreatorRole(ctx context.Context, userID uint) (models.UserRole, error) {
+	if r.role == "" {
+		return "", gorm.ErrRecordNotFound
+	}
+	return r.role, nil
+}
+
+func (r *fakeAPIKeyRepository) TouchLastUsed(ctx context.Context, id uint, at time.Time) error {
+	return nil
+}
+
+func TestAuthenticate(t *testing.T) {
+	scopes := []string{string(models.PermContentRead), string(models.PermHeroWrite)}
+
+	tests := []struct {
+		name    string
+		role    models.UserRole
+		want    []string
+		wantErr error
+	}{
+		{"creator still allowed", models.Editor, scopes, nil},
+		{"creator demoted", models.Moderator, []string{string(models.PermContentRead)}, nil},
+		{"creator deleted", "", nil, ErrInvalidAPIKey},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeAPIKeyRepository{key: models.APIKey{ID: 1, Scopes: scopes, CreatedByID: 2}, role: tt.role}
+			claims, err := NewService(repo, nil).Authenticate(context.Background(), testKey)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if !reflect.DeepEqual(claims.Scopes, tt.want) {
+				t.Errorf("scopes = %v, want %v", claims.Scopes, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package audit
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type Handler struct {
+	service *Service
+}
+
+func NewHandler(service *Service) *Handler {
+	return &Handler{service}
+}
+
+// GetAuditLogs handles GET /admin/audit.
+// Supports ?page=&limit= plus optional actor, action, entity_type, entity_id
+// and from/to (RFC 3339) filters.
+func (h *Handler) GetAuditLogs(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	query := &AuditQuery{
+		ActorID:    q.Get("actor"),
+		Action:     q.Get("action"),
+		EntityType: q.Get("entity_type"),
+		EntityID:   q.Get("entity_id"),
+		Page:       1,
+		Limit:      50,
+	}
+	if page, err := strconv.Atoi(q.Get("page")); err == nil && page > 0 {
+		query.Page = page
+	}
+	if limit, err := strconv.Atoi(q.Get("limit")); err == nil && limit > 0 && limit <= 200 {
+		query.Limit = limit
+	}
+	for param, dst := range map[string]*string{"from": &query.From, "to": &query.To} {
+		value := q.Get(param)
+		if value == "" {
+			continue
+		}
+		if _, err := time.Parse(time.RFC3339, value); err != nil {
+			http.Error(w, "Invalid "+param+" timestamp, expected RFC 3339", http.StatusBadRequest)
+			return
+		}
+		*dst = value
+	}
+
+	entries, total, err := h.service.GetAuditLogs(r.Context(), query)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response := map[string]interface{}{
+		"length": len(entries),
+		"data":   entries,
+		"page":   query.Page,
+		"limit":  query.Limit,
+		"total":  total,
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
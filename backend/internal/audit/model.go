@@ -0,0 +1,27 @@
+package audit
+
+import "github.com/othersidedrl/portfolio/backend/internal/models"
+
+type AuditQuery struct {
+	ActorID    string
+	Action     string
+	EntityType string
+	EntityID   string
+	From       string
+	To         string
+	Page       int
+	Limit      int
+}
+
+type AuditLogDto struct {
+	ID         uint         `json:"id"`
+	ActorID    string       `json:"actor_id"`
+	Action     string       `json:"action"`
+	EntityType string       `json:"entity_type"`
+	EntityID   string       `json:"entity_id"`
+	Before     models.JSONB `json:"before"`
+	After      models.JSONB `json:"after"`
+	Changes    models.JSONB `json:"changes"`
+	IP         string       `json:"ip"`
+	CreatedAt  string       `json:"created_at"`
+}
@@ -0,0 +1,65 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/othersidedrl/portfolio/backend/internal/database"
+	"github.com/othersidedrl/portfolio/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+type AuditRepository interface {
+	Create(ctx context.Context, entry *models.AuditLog) error
+	FindAll(ctx context.Context, query *AuditQuery) ([]models.AuditLog, int64, error)
+}
+
+type GormAuditRepository struct {
+	db *gorm.DB
+}
+
+func NewGormAuditRepository(db *gorm.DB) *GormAuditRepository {
+	return &GormAuditRepository{db: db}
+}
+
+func (r *GormAuditRepository) Create(ctx context.Context, entry *models.AuditLog) error {
+	return database.Conn(ctx, r.db).Create(entry).Error
+}
+
+// FindAll returns one page of audit entries, newest first, plus the total count
+func (r *GormAuditRepository) FindAll(ctx context.Context, query *AuditQuery) ([]models.AuditLog, int64, error) {
+	tx := database.Conn(ctx, r.db).Model(&models.AuditLog{})
+	if query.ActorID != "" {
+		tx = tx.Where("actor_id = ?", query.ActorID)
+	}
+	if query.Action != "" {
+		tx = tx.Where("action = ?", query.Action)
+	}
+	if query.EntityType != "" {
+		tx = tx.Where("entity_type = ?", query.EntityType)
+	}
+	if query.EntityID != "" {
+		tx = tx.Where("entity_id = ?", query.EntityID)
+	}
+	if query.From != "" {
+		tx = tx.Where("created_at >= ?", query.From)
+	}
+	if query.To != "" {
+		tx = tx.Where("created_at <= ?", query.To)
+	}
+
+	var total int64
+	if err := tx.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var entries []models.AuditLog
+	err := tx.Order("created_at DESC").
+		Offset((query.Page - 1) * query.Limit).
+		Limit(query.Limit).
+		Find(&entries).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return entries, total, nil
+}
@@ -0,0 +1,113 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/othersidedrl/portfolio/backend/internal/middleware"
+	"github.com/othersidedrl/portfolio/backend/internal/models"
+	"github.com/othersidedrl/portfolio/backend/internal/utils"
+)
+
+// Recorder is what the content services use to report their writes
+type Recorder interface {
+	Record(ctx context.Context, action models.AuditAction, entityType models.EntityType, entityID interface{}, before, after interface{})
+}
+
+type Service struct {
+	repo AuditRepository
+}
+
+func NewService(repo AuditRepository) *Service {
+	return &Service{repo}
+}
+
+// Record stores an audit entry for a write that already happened.
+// The actor and IP are taken from the request context, so writes without an
+// authenticated actor (public testimony submissions, seeding) are skipped.
+// Failures are logged rather than returned because the write can't be undone.
+func (s *Service) Record(ctx context.Context, action models.AuditAction, entityType models.EntityType, entityID interface{}, before, after interface{}) {
+	claims := middleware.GetUserFromContext(ctx)
+	if claims == nil {
+		return
+	}
+
+	entry, err := newEntry(action, entityType, entityID, before, after)
+	if err != nil {
+		log.Printf("⚠️ Failed to build audit entry for %s %s: %v", action, entityType, err)
+		return
+	}
+	entry.ActorID = claims.Sub
+	entry.IP = middleware.GetClientIPFromContext(ctx)
+
+	if err := s.repo.Create(ctx, entry); err != nil {
+		log.Printf("⚠️ Failed to record audit entry for %s %s: %v", action, entityType, err)
+	}
+}
+
+// GetAuditLogs returns one page of the audit log
+func (s *Service) GetAuditLogs(ctx context.Context, query *AuditQuery) ([]AuditLogDto, int64, error) {
+	entries, total, err := s.repo.FindAll(ctx, query)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	dtoEntries := make([]AuditLogDto, len(entries))
+	for i, e := range entries {
+		dtoEntries[i] = AuditLogDto{
+			ID:         e.ID,
+			ActorID:    e.ActorID,
+			Action:     string(e.Action),
+			EntityType: string(e.EntityType),
+			EntityID:   e.EntityID,
+			Before:     e.Before,
+			After:      e.After,
+			Changes:    e.Changes,
+			IP:         e.IP,
+			CreatedAt:  e.CreatedAt.Format(time.RFC3339),
+		}
+	}
+	return dtoEntries, total, nil
+}
+
+func newEntry(action models.AuditAction, entityType models.EntityType, entityID interface{}, before, after interface{}) (*models.AuditLog, error) {
+	changes, err := utils.DiffJSON(before, after)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := &models.AuditLog{
+		Action:     action,
+		EntityType: entityType,
+	}
+	if entityID != nil {
+		entry.EntityID = fmt.Sprint(entityID)
+	}
+	if entry.Before, err = marshalSnapshot(before); err != nil {
+		return nil, err
+	}
+	if entry.After, err = marshalSnapshot(after); err != nil {
+		return nil, err
+	}
+	if entry.Changes, err = json.Marshal(changes); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+func marshalSnapshot(value interface{}) (models.JSONB, error) {
+	if value == nil {
+		return nil, nil
+	}
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	if string(raw) == "null" {
+		return nil, nil
+	}
+	return raw, nil
+}
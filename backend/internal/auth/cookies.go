@@ -0,0 +1,96 @@
+package auth
+
+import (
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/othersidedrl/portfolio/backend/internal/middleware"
+	"github.com/othersidedrl/portfolio/backend/internal/utils"
+)
+
+const (
+	// refreshCookiePath keeps the refresh token from being sent anywhere but the auth routes
+	refreshCookiePath = "/api/v1/auth"
+
+	// oidcStateCookieName binds an OIDC login to the browser that started it
+	oidcStateCookieName = "portfolio_oidc_state"
+	oidcCookiePath      = "/api/v1/auth/oidc"
+)
+
+// cookieConfig controls the attributes of the session cookies.
+// COOKIE_SECURE=false is only meant for local development over plain HTTP.
+type cookieConfig struct {
+	domain   string
+	secure   bool
+	sameSite http.SameSite
+}
+
+func newCookieConfig() cookieConfig {
+	config := cookieConfig{
+		domain:   os.Getenv("COOKIE_DOMAIN"),
+		secure:   os.Getenv("COOKIE_SECURE") != "false",
+		sameSite: http.SameSiteStrictMode,
+	}
+
+	switch strings.ToLower(os.Getenv("COOKIE_SAMESITE")) {
+	case "lax":
+		config.sameSite = http.SameSiteLaxMode
+	case "none":
+		config.sameSite = http.SameSiteNoneMode
+		config.secure = true
+	}
+	return config
+}
+
+func (c cookieConfig) cookie(name, value, path string, maxAge time.Duration, httpOnly bool) *http.Cookie {
+	return &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     path,
+		Domain:   c.domain,
+		MaxAge:   int(maxAge.Seconds()),
+		Expires:  time.Now().Add(maxAge),
+		Secure:   c.secure,
+		HttpOnly: httpOnly,
+		SameSite: c.sameSite,
+	}
+}
+
+// setSessionCookies stores the tokens in HttpOnly cookies and issues a fresh
+// CSRF token, which is returned so the CMS doesn't have to parse cookies
+func (c cookieConfig) setSessionCookies(w http.ResponseWriter, tokens *TokenResponse) (string, error) {
+	csrfToken, err := utils.RandomToken(32)
+	if err != nil {
+		return "", err
+	}
+
+	accessTTL := time.Duration(tokens.ExpiresIn) * time.Second
+	refreshTTL := time.Duration(tokens.RefreshExpiresIn) * time.Second
+
+	http.SetCookie(w, c.cookie(middleware.SessionCookieName, tokens.Token, "/", accessTTL, true))
+	http.SetCookie(w, c.cookie(middleware.RefreshCookieName, tokens.RefreshToken, refreshCookiePath, refreshTTL, true))
+	http.SetCookie(w, c.cookie(middleware.CSRFCookieName, csrfToken, "/", refreshTTL, false))
+	return csrfToken, nil
+}
+
+func (c cookieConfig) clearSessionCookies(w http.ResponseWriter) {
+	http.SetCookie(w, c.cookie(middleware.SessionCookieName, "", "/", -time.Second, true))
+	http.SetCookie(w, c.cookie(middleware.RefreshCookieName, "", refreshCookiePath, -time.Second, true))
+	http.SetCookie(w, c.cookie(middleware.CSRFCookieName, "", "/", -time.Second, false))
+}
+
+// setOIDCStateCookie has to be SameSite=Lax: the callback is a cross-site
+// redirect from the identity provider and Strict cookies wouldn't be sent
+func (c cookieConfig) setOIDCStateCookie(w http.ResponseWriter, state string) {
+	cookie := c.cookie(oidcStateCookieName, state, oidcCookiePath, oidcStateTTL, true)
+	cookie.SameSite = http.SameSiteLaxMode
+	http.SetCookie(w, cookie)
+}
+
+func (c cookieConfig) clearOIDCStateCookie(w http.ResponseWriter) {
+	cookie := c.cookie(oidcStateCookieName, "", oidcCookiePath, -time.Second, true)
+	cookie.SameSite = http.SameSiteLaxMode
+	http.SetCookie(w, cookie)
+}
@@ -0,0 +1,51 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/othersidedrl/portfolio/backend/internal/middleware"
+)
+
+func TestSetSessionCookies(t *testing.T) {
+	t.Setenv("COOKIE_SECURE", "")
+	t.Setenv("COOKIE_SAMESITE", "")
+	w := httptest.NewRecorder()
+	csrf, err := newCookieConfig().setSessionCookies(w, &TokenResponse{Token: "access", RefreshToken: "refresh", ExpiresIn: 900, RefreshExpiresIn: 3600})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cookies := map[string]*http.Cookie{}
+	for _, c := range w.Result().Cookies() {
+		cookies[c.Name] = c
+	}
+
+	tests := []struct {
+		name     string
+		value    string
+		path     string
+		httpOnly bool
+	}{
+		{middleware.SessionCookieName, "access", "/", true},
+		{middleware.RefreshCookieName, "refresh", refreshCookiePath, true},
+		{middleware.CSRFCookieName, csrf, "/", false},
+	}
+	for _, tt := range tests {
+		c, ok := cookies[tt.name]
+		if !ok {
+			t.Errorf("%s not set", tt.name)
+			continue
+		}
+		if c.Value != tt.value || c.Path != tt.path || c.HttpOnly != tt.httpOnly {
+			t.Errorf("%s = %q path %q httpOnly %v, want %q path %q httpOnly %v", tt.name, c.Value, c.Path, c.HttpOnly, tt.value, tt.path, tt.httpOnly)
+		}
+		if !c.Secure || c.SameSite != http.SameSiteStrictMode {
+			t.Errorf("%s secure %v samesite %v, want secure strict", tt.name, c.Secure, c.SameSite)
+		}
+	}
+	if csrf == "" {
+		t.Error("empty CSRF token")
+	}
+}
@@ -1,23 +1,37 @@
 package auth
 
 import (
+	"crypto/subtle"
 	"encoding/json"
+	"errors"
+	"log"
 	"net/http"
+	"net/url"
+	"os"
+	"strconv"
 
+	"github.com/go-chi/chi/v5"
 	"github.com/othersidedrl/portfolio/backend/internal/middleware"
+	"github.com/othersidedrl/portfolio/backend/internal/user"
 	"github.com/othersidedrl/portfolio/backend/internal/utils"
 )
 
+const (
+	defaultOIDCSuccessURL = "http://localhost:3001/login/callback"
+	defaultOIDCFailureURL = "http://localhost:3001/login"
+)
+
 // Handler is the controller struct.
 // It's equivalent to a NestJS controller class with a service dependency.
 type Handler struct {
 	service *Service
+	cookies cookieConfig
 }
 
 // NewHandler returns a new instance of the auth handler.
 // It's like injecting AuthService in NestJS.
 func NewHandler(service *Service) *Handler {
-	return &Handler{service}
+	return &Handler{service, newCookieConfig()}
 }
 
 // Login handles POST /auth/login.
@@ -32,17 +46,347 @@ func (h *Handler) Login(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Call the service layer
-	token, err := h.service.Login(req.Email, req.Password)
+	result, err := h.service.Login(r.Context(), req.Email, req.Password, requestMeta(r))
 	if err != nil {
+		if _, locked := IsLocked(err); locked {
+			writeError(w, err)
+			return
+		}
 		http.Error(w, "Unauthorized", http.StatusUnauthorized)
 		return
 	}
 
-	// Return the token in JSON format
+	// The two-factor challenge never sets cookies, the client repeats use_cookies on verify
+	if result.TokenResponse == nil {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(result)
+		return
+	}
+
+	// Return the tokens in JSON format
+	h.writeTokens(w, result.TokenResponse, req.UseCookies)
+}
+
+// VerifyLogin handles POST /auth/login/verify, the second step of a two-factor login.
+func (h *Handler) VerifyLogin(w http.ResponseWriter, r *http.Request) {
+	var req VerifyLoginRequest
+
+	if err := utils.DecodeBody(r, &req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	tokens, err := h.service.VerifyLogin(r.Context(), req.ChallengeToken, req.Code, requestMeta(r))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	h.writeTokens(w, tokens, req.UseCookies)
+}
+
+// OIDCLogin handles GET /auth/oidc/login by redirecting to the identity provider.
+func (h *Handler) OIDCLogin(w http.ResponseWriter, r *http.Request) {
+	redirectURL, state, err := h.service.BeginOIDCLogin(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	h.cookies.setOIDCStateCookie(w, state)
+	http.Redirect(w, r, redirectURL, http.StatusFound)
+}
+
+// OIDCCallback handles GET /auth/oidc/callback. It always answers with a
+// redirect back to the CMS: on success the session cookies are set and the
+// CSRF token is passed in the URL fragment, which never reaches a server.
+func (h *Handler) OIDCCallback(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	h.cookies.clearOIDCStateCookie(w)
+
+	if providerError := q.Get("error"); providerError != "" {
+		log.Printf("⚠️ OIDC provider returned an error: %s %s", providerError, q.Get("error_description"))
+		http.Redirect(w, r, oidcFailureURL("provider_error"), http.StatusFound)
+		return
+	}
+
+	state := q.Get("state")
+	cookie, err := r.Cookie(oidcStateCookieName)
+	if err != nil || state == "" || subtle.ConstantTimeCompare([]byte(cookie.Value), []byte(state)) != 1 {
+		http.Redirect(w, r, oidcFailureURL("invalid_state"), http.StatusFound)
+		return
+	}
+
+	result, err := h.service.CompleteOIDCLogin(r.Context(), q.Get("code"), state, requestMeta(r))
+	if err != nil {
+		log.Printf("⚠️ OIDC login failed: %v", err)
+		reason := "login_failed"
+		if errors.Is(err, ErrEmailNotAllowed) {
+			reason = "not_allowed"
+		} else if _, locked := IsLocked(err); locked {
+			reason = "locked"
+		}
+		http.Redirect(w, r, oidcFailureURL(reason), http.StatusFound)
+		return
+	}
+
+	// Two-factor accounts finish on the login page with /auth/login/verify
+	if result.TokenResponse == nil {
+		http.Redirect(w, r, envOrDefault("OIDC_SUCCESS_URL", defaultOIDCSuccessURL)+"#challenge_token="+url.QueryEscape(result.ChallengeToken), http.StatusFound)
+		return
+	}
+
+	csrfToken, err := h.cookies.setSessionCookies(w, result.TokenResponse)
+	if err != nil {
+		http.Redirect(w, r, oidcFailureURL("login_failed"), http.StatusFound)
+		return
+	}
+	http.Redirect(w, r, envOrDefault("OIDC_SUCCESS_URL", defaultOIDCSuccessURL)+"#csrf_token="+url.QueryEscape(csrfToken), http.StatusFound)
+}
+
+// SetupTOTP handles POST /auth/2fa/setup.
+// The returned provisioning URI is meant to be rendered as a QR code.
+func (h *Handler) SetupTOTP(w http.ResponseWriter, r *http.Request) {
+	claims := middleware.GetUserFromContext(r.Context())
+	if claims == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	setup, err := h.service.SetupTOTP(r.Context(), claims)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]string{
-		"token": token,
-	})
+	json.NewEncoder(w).Encode(setup)
+}
+
+// ConfirmTOTP handles POST /auth/2fa/confirm.
+// Recovery codes are only ever shown in this response.
+func (h *Handler) ConfirmTOTP(w http.ResponseWriter, r *http.Request) {
+	claims := middleware.GetUserFromContext(r.Context())
+	if claims == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req TOTPCodeRequest
+	if err := utils.DecodeBody(r, &req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	codes, err := h.service.ConfirmTOTP(r.Context(), claims, req.Code)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(codes)
+}
+
+// DisableTOTP handles POST /auth/2fa/disable.
+func (h *Handler) DisableTOTP(w http.ResponseWriter, r *http.Request) {
+	claims := middleware.GetUserFromContext(r.Context())
+	if claims == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req DisableTOTPRequest
+	if err := utils.DecodeBody(r, &req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.DisableTOTP(r.Context(), claims, req.Password, req.Code); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Refresh handles POST /auth/refresh.
+// The presented refresh token is rotated and can't be used again.
+// Without a refresh_token in the body the refresh cookie is used instead,
+// which requires a valid CSRF token.
+func (h *Handler) Refresh(w http.ResponseWriter, r *http.Request) {
+	var req RefreshRequest
+
+	if r.ContentLength != 0 {
+		if err := utils.DecodeBody(r, &req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	useCookies := false
+	if req.RefreshToken == "" {
+		cookie, err := r.Cookie(middleware.RefreshCookieName)
+		if err != nil || cookie.Value == "" {
+			http.Error(w, "Unauthorized: missing refresh token", http.StatusUnauthorized)
+			return
+		}
+		if !middleware.ValidCSRF(r) {
+			http.Error(w, "Forbidden: invalid CSRF token", http.StatusForbidden)
+			return
+		}
+		req.RefreshToken = cookie.Value
+		useCookies = true
+	}
+
+	tokens, err := h.service.Refresh(r.Context(), req.RefreshToken, requestMeta(r))
+	if err != nil {
+		if useCookies {
+			h.cookies.clearSessionCookies(w)
+		}
+		writeError(w, err)
+		return
+	}
+
+	h.writeTokens(w, tokens, useCookies)
+}
+
+// Logout handles POST /auth/logout.
+// It revokes the access token in use and the refresh tokens of its session.
+func (h *Handler) Logout(w http.ResponseWriter, r *http.Request) {
+	claims := middleware.GetUserFromContext(r.Context())
+	if claims == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.service.Logout(r.Context(), claims); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.cookies.clearSessionCookies(w)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ChangePassword handles POST /auth/password.
+// Every other session of the user is signed out.
+func (h *Handler) ChangePassword(w http.ResponseWriter, r *http.Request) {
+	claims := middleware.GetUserFromContext(r.Context())
+	if claims == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req ChangePasswordRequest
+	if err := utils.DecodeBody(r, &req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.ChangePassword(r.Context(), claims, req.CurrentPassword, req.NewPassword); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ForgotPassword handles POST /auth/password/forgot.
+// It always answers 202 so it can't be used to find out which emails exist.
+func (h *Handler) ForgotPassword(w http.ResponseWriter, r *http.Request) {
+	var req ForgotPasswordRequest
+	if err := utils.DecodeBody(r, &req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.ForgotPassword(r.Context(), req.Email); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(map[string]string{"message": "If the account exists, a reset link has been sent"})
+}
+
+// ResetPassword handles POST /auth/password/reset with a token from the reset mail.
+func (h *Handler) ResetPassword(w http.ResponseWriter, r *http.Request) {
+	var req ResetPasswordRequest
+	if err := utils.DecodeBody(r, &req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.ResetPassword(r.Context(), req.Token, req.NewPassword); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetSessions handles GET /auth/sessions.
+func (h *Handler) GetSessions(w http.ResponseWriter, r *http.Request) {
+	claims := middleware.GetUserFromContext(r.Context())
+	if claims == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	sessions, err := h.service.ListSessions(r.Context(), claims)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"length": len(sessions),
+		"data":   sessions,
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// RevokeSession handles DELETE /auth/sessions/{id}.
+func (h *Handler) RevokeSession(w http.ResponseWriter, r *http.Request) {
+	claims := middleware.GetUserFromContext(r.Context())
+	if claims == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.service.RevokeSession(r.Context(), claims, chi.URLParam(r, "id")); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RevokeOtherSessions handles DELETE /auth/sessions, signing out everywhere else.
+func (h *Handler) RevokeOtherSessions(w http.ResponseWriter, r *http.Request) {
+	claims := middleware.GetUserFromContext(r.Context())
+	if claims == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.service.RevokeOtherSessions(r.Context(), claims); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// JWKS handles GET /.well-known/jwks.json so other services can verify tokens locally.
+func (h *Handler) JWKS(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	json.NewEncoder(w).Encode(h.service.JWKS())
 }
 
 func (h *Handler) Me(w http.ResponseWriter, r *http.Request) {
@@ -53,9 +397,119 @@ func (h *Handler) Me(w http.ResponseWriter, r *http.Request) {
 	}
 
 	response := map[string]string{
-		"id": claims.Sub,
+		"id":         claims.Sub,
+		"role":       claims.Role,
+		"session_id": claims.SessionID,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// GetLoginAttempts handles GET /admin/security/logins.
+// Supports ?page=&limit= plus optional email, ip and result filters.
+func (h *Handler) GetLoginAttempts(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	query := &LoginAttemptQuery{
+		Email:  normalize(q.Get("email")),
+		IP:     q.Get("ip"),
+		Result: q.Get("result"),
+		Page:   1,
+		Limit:  50,
+	}
+	if page, err := strconv.Atoi(q.Get("page")); err == nil && page > 0 {
+		query.Page = page
+	}
+	if limit, err := strconv.Atoi(q.Get("limit")); err == nil && limit > 0 && limit <= 200 {
+		query.Limit = limit
+	}
+
+	attempts, total, err := h.service.GetLoginAttempts(r.Context(), query)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
 	}
 
+	response := map[string]interface{}{
+		"length": len(attempts),
+		"data":   attempts,
+		"page":   query.Page,
+		"limit":  query.Limit,
+		"total":  total,
+	}
+	w.WriteHeader(http.StatusOK)
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(response)
 }
+
+// writeTokens returns the tokens in the body, or in cookie mode stores them in
+// HttpOnly cookies and only returns the CSRF token
+func (h *Handler) writeTokens(w http.ResponseWriter, tokens *TokenResponse, useCookies bool) {
+	w.Header().Set("Content-Type", "application/json")
+	if !useCookies {
+		json.NewEncoder(w).Encode(tokens)
+		return
+	}
+
+	csrfToken, err := h.cookies.setSessionCookies(w, tokens)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	json.NewEncoder(w).Encode(CookieSessionResponse{
+		CSRFToken:        csrfToken,
+		ExpiresIn:        tokens.ExpiresIn,
+		RefreshExpiresIn: tokens.RefreshExpiresIn,
+	})
+}
+
+func oidcFailureURL(reason string) string {
+	return envOrDefault("OIDC_FAILURE_URL", defaultOIDCFailureURL) + "?error=" + url.QueryEscape(reason)
+}
+
+func envOrDefault(key, def string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return def
+}
+
+func requestMeta(r *http.Request) RequestMeta {
+	return RequestMeta{
+		IP:        middleware.GetClientIP(r),
+		UserAgent: r.UserAgent(),
+	}
+}
+
+// writeError maps service errors to HTTP status codes
+func writeError(w http.ResponseWriter, err error) {
+	if locked, ok := IsLocked(err); ok {
+		w.Header().Set("Retry-After", strconv.Itoa(int(locked.RetryAfter.Seconds())+1))
+		http.Error(w, err.Error(), http.StatusTooManyRequests)
+		return
+	}
+
+	switch {
+	case errors.Is(err, ErrInvalidChallenge),
+		errors.Is(err, ErrInvalidRefreshToken),
+		errors.Is(err, ErrRefreshTokenReused),
+		errors.Is(err, user.ErrInvalidCredentials),
+		errors.Is(err, user.ErrInvalidTOTPCode):
+		http.Error(w, "Unauthorized: "+err.Error(), http.StatusUnauthorized)
+	case errors.Is(err, user.ErrTOTPAlreadyEnabled),
+		errors.Is(err, user.ErrTOTPNotStarted),
+		errors.Is(err, user.ErrTOTPNotEnabled):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, ErrInvalidResetToken),
+		errors.Is(err, user.ErrWeakPassword):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, ErrOIDCDisabled),
+		errors.Is(err, ErrSessionNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, user.ErrUserNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -1,6 +1,96 @@
 package auth
 
+// UseCookies switches the response to the HttpOnly cookie session mode
 type LoginRequest struct {
-	Email    string `json:"email"`
+	Email      string `json:"email"`
+	Password   string `json:"password"`
+	UseCookies bool   `json:"use_cookies"`
+}
+
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+type TokenResponse struct {
+	Token            string `json:"token"`
+	RefreshToken     string `json:"refresh_token"`
+	ExpiresIn        int    `json:"expires_in"`
+	RefreshExpiresIn int    `json:"refresh_expires_in"`
+}
+
+// CookieSessionResponse is returned instead of the tokens in cookie mode
+type CookieSessionResponse struct {
+	CSRFToken        string `json:"csrf_token"`
+	ExpiresIn        int    `json:"expires_in"`
+	RefreshExpiresIn int    `json:"refresh_expires_in"`
+}
+
+// LoginResponse carries either a token pair or a two-factor challenge
+type LoginResponse struct {
+	*TokenResponse
+	TwoFactorRequired bool   `json:"two_factor_required,omitempty"`
+	ChallengeToken    string `json:"challenge_token,omitempty"`
+}
+
+type VerifyLoginRequest struct {
+	ChallengeToken string `json:"challenge_token"`
+	Code           string `json:"code"`
+	UseCookies     bool   `json:"use_cookies"`
+}
+
+type TOTPCodeRequest struct {
+	Code string `json:"code"`
+}
+
+type DisableTOTPRequest struct {
 	Password string `json:"password"`
+	Code     string `json:"code"`
+}
+
+// RequestMeta describes where a login attempt came from
+type RequestMeta struct {
+	IP        string
+	UserAgent string
+}
+
+type LoginAttemptQuery struct {
+	Email  string
+	IP     string
+	Result string
+	Page   int
+	Limit  int
+}
+
+type LoginAttemptDto struct {
+	ID        uint   `json:"id"`
+	Email     string `json:"email"`
+	UserID    *uint  `json:"user_id"`
+	IP        string `json:"ip"`
+	UserAgent string `json:"user_agent"`
+	Result    string `json:"result"`
+	CreatedAt string `json:"created_at"`
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password"`
+	NewPassword     string `json:"new_password"`
+}
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email"`
+}
+
+type ResetPasswordRequest struct {
+	Token       string `json:"token"`
+	NewPassword string `json:"new_password"`
+}
+
+type SessionDto struct {
+	ID         string `json:"id"`
+	Device     string `json:"device"`
+	IP         string `json:"ip"`
+	UserAgent  string `json:"user_agent"`
+	Current    bool   `json:"current"`
+	CreatedAt  string `json:"created_at"`
+	LastSeenAt string `json:"last_seen_at"`
 }
@@ -0,0 +1,379 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/othersidedrl/portfolio/backend/internal/utils"
+	"github.com/redis/go-redis/v9"
+)
+
+var (
+	ErrOIDCDisabled     = errors.New("oidc login is not configured")
+	ErrInvalidOIDCState = errors.New("invalid or expired oidc state")
+	ErrInvalidIDToken   = errors.New("invalid id token")
+	ErrEmailNotAllowed  = errors.New("email is not allowed to sign in")
+)
+
+const (
+	oidcStateTTL       = 10 * time.Minute
+	oidcHTTPTimeout    = 10 * time.Second
+	jwksRefreshBackoff = time.Minute
+)
+
+// oidcDiscovery is the part of /.well-known/openid-configuration we use
+type oidcDiscovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// oidcLoginState is kept in Redis between the redirect and the callback
+type oidcLoginState struct {
+	Nonce        string `json:"nonce"`
+	CodeVerifier string `json:"code_verifier"`
+}
+
+// idTokenClaims are the ID token claims we validate. email_verified is an
+// interface because some providers send it as a string.
+type idTokenClaims struct {
+	Email         string      `json:"email"`
+	EmailVerified interface{} `json:"email_verified"`
+	Nonce         string      `json:"nonce"`
+	AuthorizedBy  string      `json:"azp"`
+	jwt.RegisteredClaims
+}
+
+// OIDCProvider runs the authorization code flow with PKCE against a single
+// identity provider. Discovery and the provider's keys are fetched lazily so
+// the API starts even when the provider is down.
+type OIDCProvider struct {
+	client        *redis.Client
+	httpClient    *http.Client
+	issuer        string
+	clientID      string
+	clientSecret  string
+	redirectURL   string
+	scopes        string
+	allowedEmails map[string]bool
+
+	mu            sync.Mutex
+	discovery     *oidcDiscovery
+	keys          map[string]utils.JWK
+	keysFetchedAt time.Time
+}
+
+// NewOIDCProvider reads the OIDC_* environment variables. It returns nil when
+// OIDC_ISSUER is unset, which disables OIDC login.
+func NewOIDCProvider(client *redis.Client) (*OIDCProvider, error) {
+	issuer := os.Getenv("OIDC_ISSUER")
+	if issuer == "" {
+		return nil, nil
+	}
+
+	clientID := os.Getenv("OIDC_CLIENT_ID")
+	redirectURL := os.Getenv("OIDC_REDIRECT_URL")
+	if clientID == "" || redirectURL == "" {
+		return nil, fmt.Errorf("OIDC_CLIENT_ID and OIDC_REDIRECT_URL are required when OIDC_ISSUER is set")
+	}
+
+	allowed := map[string]bool{}
+	for _, email := range strings.Split(os.Getenv("OIDC_ALLOWED_EMAILS"), ",") {
+		if email = normalize(email); email != "" {
+			allowed[email] = true
+		}
+	}
+	if len(allowed) == 0 {
+		return nil, fmt.Errorf("OIDC_ALLOWED_EMAILS must list at least one email")
+	}
+
+	scopes := os.Getenv("OIDC_SCOPES")
+	if scopes == "" {
+		scopes = "openid email profile"
+	}
+
+	return &OIDCProvider{
+		client:        client,
+		httpClient:    &http.Client{Timeout: oidcHTTPTimeout},
+		issuer:        issuer,
+		clientID:      clientID,
+		clientSecret:  os.Getenv("OIDC_CLIENT_SECRET"),
+		redirectURL:   redirectURL,
+		scopes:        scopes,
+		allowedEmails: allowed,
+	}, nil
+}
+
+func oidcStateKey(state string) string { return "auth:oidc_state:" + state }
+
+// AuthCodeURL starts a login and returns the provider URL to redirect to
+// together with the state, which the caller binds to the browser
+func (p *OIDCProvider) AuthCodeURL(ctx context.Context) (string, string, error) {
+	discovery, err := p.getDiscovery(ctx)
+	if err != nil {
+		return "", "", err
+	}
+
+	state, err := utils.RandomToken(32)
+	if err != nil {
+		return "", "", err
+	}
+	nonce, err := utils.RandomToken(32)
+	if err != nil {
+		return "", "", err
+	}
+	verifier, err := utils.RandomToken(48)
+	if err != nil {
+		return "", "", err
+	}
+
+	record, err := json.Marshal(oidcLoginState{Nonce: nonce, CodeVerifier: verifier})
+	if err != nil {
+		return "", "", err
+	}
+	if err := p.client.Set(ctx, oidcStateKey(state), record, oidcStateTTL).Err(); err != nil {
+		return "", "", err
+	}
+
+	challenge := sha256.Sum256([]byte(verifier))
+	query := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {p.clientID},
+		"redirect_uri":          {p.redirectURL},
+		"scope":                 {p.scopes},
+		"state":                 {state},
+		"nonce":                 {nonce},
+		"code_challenge":        {base64.RawURLEncoding.EncodeToString(challenge[:])},
+		"code_challenge_method": {"S256"},
+	}
+
+	separator := "?"
+	if strings.Contains(discovery.AuthorizationEndpoint, "?") {
+		separator = "&"
+	}
+	return discovery.AuthorizationEndpoint + separator + query.Encode(), state, nil
+}
+
+// Exchange redeems the authorization code and returns the verified, allowed email.
+// On ErrEmailNotAllowed the rejected email is returned too. The state can only be used once.
+func (p *OIDCProvider) Exchange(ctx context.Context, code, state string) (string, error) {
+	raw, err := p.client.GetDel(ctx, oidcStateKey(state)).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return "", ErrInvalidOIDCState
+		}
+		return "", err
+	}
+
+	var login oidcLoginState
+	if err := json.Unmarshal([]byte(raw), &login); err != nil {
+		return "", err
+	}
+
+	idToken, err := p.redeemCode(ctx, code, login.CodeVerifier)
+	if err != nil {
+		return "", err
+	}
+
+	claims, err := p.verifyIDToken(ctx, idToken, login.Nonce)
+	if err != nil {
+		return "", err
+	}
+
+	email := normalize(claims.Email)
+	if email == "" || !p.allowedEmails[email] {
+		return email, ErrEmailNotAllowed
+	}
+	return email, nil
+}
+
+func (p *OIDCProvider) redeemCode(ctx context.Context, code, verifier string) (string, error) {
+	discovery, err := p.getDiscovery(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {p.redirectURL},
+		"client_id":     {p.clientID},
+		"code_verifier": {verifier},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, discovery.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if p.clientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(p.clientID), url.QueryEscape(p.clientSecret))
+	}
+
+	var response struct {
+		IDToken string `json:"id_token"`
+	}
+	if err := p.doJSON(req, &response); err != nil {
+		return "", fmt.Errorf("token exchange failed: %w", err)
+	}
+	if response.IDToken == "" {
+		return "", fmt.Errorf("%w: missing from token response", ErrInvalidIDToken)
+	}
+	return response.IDToken, nil
+}
+
+func (p *OIDCProvider) verifyIDToken(ctx context.Context, idToken, nonce string) (*idTokenClaims, error) {
+	claims := &idTokenClaims{}
+	_, err := jwt.ParseWithClaims(idToken, claims, func(t *jwt.Token) (interface{}, error) {
+		kid, _ := t.Header["kid"].(string)
+		return p.publicKey(ctx, kid)
+	},
+		jwt.WithValidMethods([]string{"RS256", "ES256", "EdDSA"}),
+		jwt.WithIssuer(p.issuer),
+		jwt.WithAudience(p.clientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(30*time.Second),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidIDToken, err)
+	}
+
+	if claims.Nonce == "" || claims.Nonce != nonce {
+		return nil, fmt.Errorf("%w: nonce mismatch", ErrInvalidIDToken)
+	}
+	if len(claims.Audience) > 1 && claims.AuthorizedBy != p.clientID {
+		return nil, fmt.Errorf("%w: unexpected authorized party", ErrInvalidIDToken)
+	}
+	if !emailVerified(claims.EmailVerified) {
+		return nil, ErrEmailNotAllowed
+	}
+	return claims, nil
+}
+
+// publicKey looks the kid up in the cached JWKS, refetching it (at most once
+// a minute) when the provider has rotated its keys
+func (p *OIDCProvider) publicKey(ctx context.Context, kid string) (interface{}, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	key, ok := p.findKey(kid)
+	if !ok && time.Since(p.keysFetchedAt) > jwksRefreshBackoff {
+		if err := p.fetchKeys(ctx); err != nil {
+			return nil, err
+		}
+		key, ok = p.findKey(kid)
+	}
+	if !ok {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+	return key.PublicKey()
+}
+
+// findKey matches by kid, or takes the only key when the token has none
+func (p *OIDCProvider) findKey(kid string) (utils.JWK, bool) {
+	if kid == "" && len(p.keys) == 1 {
+		for _, key := range p.keys {
+			return key, true
+		}
+	}
+	key, ok := p.keys[kid]
+	return key, ok
+}
+
+// fetchKeys must be called with p.mu held
+func (p *OIDCProvider) fetchKeys(ctx context.Context) error {
+	discovery, err := p.loadDiscovery(ctx)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, discovery.JWKSURI, nil)
+	if err != nil {
+		return err
+	}
+	var set utils.JWKSet
+	if err := p.doJSON(req, &set); err != nil {
+		return fmt.Errorf("failed to fetch provider keys: %w", err)
+	}
+
+	keys := make(map[string]utils.JWK, len(set.Keys))
+	for _, key := range set.Keys {
+		if key.Use == "" || key.Use == "sig" {
+			keys[key.Kid] = key
+		}
+	}
+	p.keys = keys
+	p.keysFetchedAt = time.Now()
+	return nil
+}
+
+func (p *OIDCProvider) getDiscovery(ctx context.Context) (*oidcDiscovery, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.loadDiscovery(ctx)
+}
+
+// loadDiscovery must be called with p.mu held
+func (p *OIDCProvider) loadDiscovery(ctx context.Context) (*oidcDiscovery, error) {
+	if p.discovery != nil {
+		return p.discovery, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(p.issuer, "/")+"/.well-known/openid-configuration", nil)
+	if err != nil {
+		return nil, err
+	}
+	var discovery oidcDiscovery
+	if err := p.doJSON(req, &discovery); err != nil {
+		return nil, fmt.Errorf("oidc discovery failed: %w", err)
+	}
+	if discovery.Issuer != p.issuer {
+		return nil, fmt.Errorf("oidc discovery returned issuer %q, expected %q", discovery.Issuer, p.issuer)
+	}
+	if discovery.AuthorizationEndpoint == "" || discovery.TokenEndpoint == "" || discovery.JWKSURI == "" {
+		return nil, errors.New("oidc discovery document is missing endpoints")
+	}
+
+	p.discovery = &discovery
+	return p.discovery, nil
+}
+
+func (p *OIDCProvider) doJSON(req *http.Request, dst interface{}) error {
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("%s returned %d: %s", req.URL.Host, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(dst)
+}
+
+// emailVerified only accepts an explicit true. Accounts are matched by
+// email, so an address the provider has not vouched for must not sign in.
+func emailVerified(value interface{}) bool {
+	switch v := value.(type) {
+	case bool:
+		return v
+	case string:
+		return v == "true"
+	}
+	return false
+}
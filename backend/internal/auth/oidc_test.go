@@ -0,0 +1,179 @@
+package auth
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/othersidedrl/portfolio/backend/internal/utils"
+)
+
+const (
+	testClientID = "cms"
+	testCode     = "auth-code"
+	testVerifier = "code-verifier"
+	testNonce    = "nonce"
+)
+
+// mockProvider is a local OIDC provider: discovery, a token endpoint that
+// hands out idToken for testCode and testVerifier, and its JWKS
+type mockProvider struct {
+	*httptest.Server
+	key     ed25519.PrivateKey
+	idToken string
+}
+
+func newMockProvider(t *testing.T) *mockProvider {
+	t.Helper()
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := &mockProvider{key: private}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(oidcDiscovery{
+			Issuer:                m.URL,
+			AuthorizationEndpoint: m.URL + "/authorize",
+			TokenEndpoint:         m.URL + "/token",
+			JWKSURI:               m.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if r.PostFormValue("code") != testCode || r.PostFormValue("code_verifier") != testVerifier {
+			http.Error(w, `{"error":"invalid_grant"}`, http.StatusBadRequest)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{"id_token": m.idToken})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(utils.JWKSet{Keys: []utils.JWK{{
+			Kty: "OKP",
+			Kid: "test",
+			Use: "sig",
+			Alg: "EdDSA",
+			Crv: "Ed25519",
+			X:   base64.RawURLEncoding.EncodeToString(public),
+		}}})
+	})
+	m.Server = httptest.NewServer(mux)
+	t.Cleanup(m.Close)
+	return m
+}
+
+func (m *mockProvider) sign(t *testing.T, claims jwt.MapClaims) string {
+	t.Helper()
+	token := jwt.NewWithClaims(jwt.SigningMethodEdDSA, claims)
+	token.Header["kid"] = "test"
+	signed, err := token.SignedString(m.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed
+}
+
+func (m *mockProvider) claims(email string) jwt.MapClaims {
+	now := time.Now()
+	return jwt.MapClaims{
+		"iss":            m.URL,
+		"sub":            "user-1",
+		"aud":            testClientID,
+		"exp":            now.Add(time.Minute).Unix(),
+		"iat":            now.Unix(),
+		"nonce":          testNonce,
+		"email":          email,
+		"email_verified": true,
+	}
+}
+
+func TestOIDCLogin(t *testing.T) {
+	tests := []struct {
+		name    string
+		edit    func(jwt.MapClaims)
+		want    string
+		wantErr error
+	}{
+		{"verified email", func(jwt.MapClaims) {}, "admin@example.com", nil},
+		{"verified as string", func(c jwt.MapClaims) { c["email_verified"] = "true" }, "admin@example.com", nil},
+		{"email_verified missing", func(c jwt.MapClaims) { delete(c, "email_verified") }, "", ErrEmailNotAllowed},
+		{"email not verified", func(c jwt.MapClaims) { c["email_verified"] = false }, "", ErrEmailNotAllowed},
+		{"nonce mismatch", func(c jwt.MapClaims) { c["nonce"] = "other" }, "", ErrInvalidIDToken},
+		{"other audience", func(c jwt.MapClaims) { c["aud"] = "other-client" }, "", ErrInvalidIDToken},
+		{"other issuer", func(c jwt.MapClaims) { c["iss"] = "https://evil.example.com" }, "", ErrInvalidIDToken},
+		{"expired", func(c jwt.MapClaims) { c["exp"] = time.Now().Add(-time.Hour).Unix() }, "", ErrInvalidIDToken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := newMockProvider(t)
+			claims := mock.claims("admin@example.com")
+			tt.edit(claims)
+			mock.idToken = mock.sign(t, claims)
+
+			provider := &OIDCProvider{
+				httpClient:  mock.Client(),
+				issuer:      mock.URL,
+				clientID:    testClientID,
+				redirectURL: "http://localhost/auth/oidc/callback",
+			}
+			ctx := context.Background()
+
+			idToken, err := provider.redeemCode(ctx, testCode, testVerifier)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := provider.verifyIDToken(ctx, idToken, testNonce)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("err = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Email != tt.want {
+				t.Errorf("email = %q, want %q", got.Email, tt.want)
+			}
+		})
+	}
+}
+
+func TestOIDCRedeemCodeNeedsVerifier(t *testing.T) {
+	mock := newMockProvider(t)
+	provider := &OIDCProvider{httpClient: mock.Client(), issuer: mock.URL, clientID: testClientID}
+
+	if _, err := provider.redeemCode(context.Background(), testCode, "wrong-verifier"); err == nil {
+		t.Fatal("code redeemed without the PKCE verifier")
+	}
+}
+
+func TestEmailVerified(t *testing.T) {
+	tests := []struct {
+		value interface{}
+		want  bool
+	}{
+		{true, true},
+		{"true", true},
+		{false, false},
+		{"false", false},
+		{nil, false},
+		{"yes", false},
+		{1.0, false},
+	}
+
+	for _, tt := range tests {
+		if got := emailVerified(tt.value); got != tt.want {
+			t.Errorf("emailVerified(%#v) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"time"
+
+	"github.com/othersidedrl/portfolio/backend/internal/mailer"
+	"github.com/othersidedrl/portfolio/backend/internal/user"
+	"github.com/othersidedrl/portfolio/backend/internal/utils"
+)
+
+const (
+	defaultPasswordResetURL = "http://localhost:3001/reset-password"
+	mailSendTimeout         = 30 * time.Second
+)
+
+// ChangePassword updates the signed in user's password and signs out every
+// other session of that user
+func (s *Service) ChangePassword(ctx context.Context, claims *utils.JWTClaims, currentPassword, newPassword string) error {
+	userID, err := parseUserID(claims.Sub)
+	if err != nil {
+		return err
+	}
+	if err := s.users.ChangePassword(ctx, userID, currentPassword, newPassword); err != nil {
+		return err
+	}
+	return s.tokens.RevokeUserFamilies(ctx, userID, claims.SessionID)
+}
+
+// ForgotPassword mails a reset link when the email belongs to an account.
+// The result is the same either way so it can't be used to probe for accounts.
+func (s *Service) ForgotPassword(ctx context.Context, email string) error {
+	account, err := s.users.FindByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, user.ErrUserNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	token, err := s.tokens.IssueResetToken(ctx, account.ID)
+	if err != nil {
+		return err
+	}
+
+	msg := mailer.Message{
+		To:      account.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf(
+			"Someone asked to reset the password of your portfolio CMS account.\n\n"+
+				"Open this link to choose a new password:\n%s\n\n"+
+				"The link expires in %d minutes and can only be used once. "+
+				"If you didn't ask for this, you can ignore this email.\n",
+			passwordResetLink(token), int(s.tokens.ResetTTL().Minutes())),
+	}
+
+	// Send in the background so the response time doesn't reveal whether the account exists
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), mailSendTimeout)
+		defer cancel()
+		if err := s.mailer.Send(ctx, msg); err != nil {
+			log.Printf("⚠️ Failed to send password reset mail: %v", err)
+		}
+	}()
+	return nil
+}
+
+// ResetPassword sets a new password using a reset token and signs out every
+// session of the user
+func (s *Service) ResetPassword(ctx context.Context, token, newPassword string) error {
+	// Check the policy first so a weak password doesn't burn the token
+	if err := user.ValidatePassword(newPassword); err != nil {
+		return err
+	}
+
+	userID, err := s.tokens.ConsumeResetToken(ctx, token)
+	if err != nil {
+		return err
+	}
+
+	account, err := s.users.FindByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if err := s.users.SetPassword(ctx, userID, newPassword); err != nil {
+		return err
+	}
+
+	// The owner just proved access to the mailbox, so lift any lockout
+	if err := s.throttle.RegisterSuccess(ctx, account.Email); err != nil {
+		log.Printf("⚠️ Failed to reset login failures: %v", err)
+	}
+	return s.tokens.RevokeUserFamilies(ctx, userID, "")
+}
+
+func passwordResetLink(token string) string {
+	return envOrDefault("PASSWORD_RESET_URL", defaultPasswordResetURL) + "?token=" + url.QueryEscape(token)
+}
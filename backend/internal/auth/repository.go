@@ -0,0 +1,55 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/othersidedrl/portfolio/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+type LoginAttemptRepository interface {
+	Create(ctx context.Context, attempt *models.LoginAttempt) error
+	FindAll(ctx context.Context, query *LoginAttemptQuery) ([]models.LoginAttempt, int64, error)
+}
+
+type GormLoginAttemptRepository struct {
+	db *gorm.DB
+}
+
+func NewGormLoginAttemptRepository(db *gorm.DB) *GormLoginAttemptRepository {
+	return &GormLoginAttemptRepository{db: db}
+}
+
+func (r *GormLoginAttemptRepository) Create(ctx context.Context, attempt *models.LoginAttempt) error {
+	return r.db.WithContext(ctx).Create(attempt).Error
+}
+
+// FindAll returns one page of attempts, newest first, plus the total count
+func (r *GormLoginAttemptRepository) FindAll(ctx context.Context, query *LoginAttemptQuery) ([]models.LoginAttempt, int64, error) {
+	tx := r.db.WithContext(ctx).Model(&models.LoginAttempt{})
+	if query.Email != "" {
+		tx = tx.Where("email = ?", query.Email)
+	}
+	if query.IP != "" {
+		tx = tx.Where("ip = ?", query.IP)
+	}
+	if query.Result != "" {
+		tx = tx.Where("result = ?", query.Result)
+	}
+
+	var total int64
+	if err := tx.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var attempts []models.LoginAttempt
+	err := tx.Order("created_at DESC").
+		Offset((query.Page - 1) * query.Limit).
+		Limit(query.Limit).
+		Find(&attempts).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return attempts, total, nil
+}
@@ -1,36 +1,381 @@
 package auth
 
 import (
+	"context"
 	"errors"
-	"os"
+	"log"
+	"strconv"
+	"time"
 
-	"github.com/alexedwards/argon2id"
+	"github.com/othersidedrl/portfolio/backend/internal/mailer"
+	"github.com/othersidedrl/portfolio/backend/internal/models"
+	"github.com/othersidedrl/portfolio/backend/internal/user"
 	"github.com/othersidedrl/portfolio/backend/internal/utils"
 )
 
+var ErrInvalidChallenge = errors.New("invalid or expired login challenge")
+
 // Service contains the business logic for auth
 type Service struct {
-	jwt *utils.JWTService
+	jwt      *utils.JWTService
+	users    *user.Service
+	tokens   *TokenStore
+	throttle *LoginThrottle
+	attempts LoginAttemptRepository
+	mailer   mailer.Mailer
+	oidc     *OIDCProvider
 }
 
-func NewService(jwt *utils.JWTService) *Service {
+// NewService wires the auth dependencies. oidc may be nil when OIDC login is disabled.
+func NewService(jwt *utils.JWTService, users *user.Service, tokens *TokenStore, throttle *LoginThrottle, attempts LoginAttemptRepository, mailer mailer.Mailer, oidc *OIDCProvider) *Service {
 	return &Service{
-		jwt: jwt,
+		jwt:      jwt,
+		users:    users,
+		tokens:   tokens,
+		throttle: throttle,
+		attempts: attempts,
+		mailer:   mailer,
+		oidc:     oidc,
+	}
+}
+
+// Login checks the credentials against the users table and starts a new session.
+// Accounts with two-factor enabled get a challenge token instead.
+func (s *Service) Login(ctx context.Context, email, password string, meta RequestMeta) (*LoginResponse, error) {
+	if err := s.throttle.Check(ctx, email, meta.IP); err != nil {
+		s.recordAttempt(ctx, email, nil, meta, models.LoginLocked)
+		return nil, err
+	}
+
+	account, err := s.users.Authenticate(ctx, email, password)
+	if err != nil {
+		if errors.Is(err, user.ErrInvalidCredentials) {
+			if err := s.throttle.RegisterFailure(ctx, email, meta.IP); err != nil {
+				log.Printf("⚠️ Failed to register login failure: %v", err)
+			}
+			s.recordAttempt(ctx, email, nil, meta, models.LoginInvalidCredentials)
+			return nil, errors.New("Unauthorized")
+		}
+		return nil, err
+	}
+
+	return s.startLogin(ctx, account, meta)
+}
+
+// startLogin starts a session for an account whose first factor checked
+// out, or returns a challenge token when it has two-factor enabled
+func (s *Service) startLogin(ctx context.Context, account *models.User, meta RequestMeta) (*LoginResponse, error) {
+	if account.TOTPEnabled {
+		challenge, err := s.jwt.GenerateChallengeToken(formatUserID(account.ID))
+		if err != nil {
+			return nil, err
+		}
+		s.recordAttempt(ctx, account.Email, &account.ID, meta, models.LoginTwoFactorRequired)
+		return &LoginResponse{TwoFactorRequired: true, ChallengeToken: challenge}, nil
+	}
+
+	tokens, err := s.completeLogin(ctx, account, meta)
+	if err != nil {
+		return nil, err
+	}
+	return &LoginResponse{TokenResponse: tokens}, nil
+}
+
+// VerifyLogin exchanges a challenge token and a TOTP or recovery code for a session
+func (s *Service) VerifyLogin(ctx context.Context, challengeToken, code string, meta RequestMeta) (*TokenResponse, error) {
+	claims, err := s.jwt.VerifyChallengeToken(challengeToken)
+	if err != nil {
+		return nil, ErrInvalidChallenge
+	}
+
+	// Challenge tokens are single use
+	revoked, err := s.tokens.IsRevoked(ctx, claims)
+	if err != nil {
+		return nil, err
+	}
+	if revoked {
+		return nil, ErrInvalidChallenge
+	}
+
+	userID, err := parseUserID(claims.Sub)
+	if err != nil {
+		return nil, ErrInvalidChallenge
+	}
+	account, err := s.users.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.throttle.Check(ctx, account.Email, meta.IP); err != nil {
+		s.recordAttempt(ctx, account.Email, &account.ID, meta, models.LoginLocked)
+		return nil, err
+	}
+
+	if err := s.users.VerifySecondFactor(ctx, userID, code); err != nil {
+		if errors.Is(err, user.ErrInvalidTOTPCode) {
+			if err := s.throttle.RegisterFailure(ctx, account.Email, meta.IP); err != nil {
+				log.Printf("⚠️ Failed to register login failure: %v", err)
+			}
+			if err := s.tokens.RegisterChallengeFailure(ctx, claims); err != nil {
+				log.Printf("⚠️ Failed to register challenge failure: %v", err)
+			}
+			s.recordAttempt(ctx, account.Email, &account.ID, meta, models.LoginTwoFactorFailed)
+		}
+		return nil, err
+	}
+	if err := s.tokens.RevokeAccessToken(ctx, claims); err != nil {
+		return nil, err
+	}
+
+	return s.completeLogin(ctx, account, meta)
+}
+
+// GetLoginAttempts returns one page of the login history
+func (s *Service) GetLoginAttempts(ctx context.Context, query *LoginAttemptQuery) ([]LoginAttemptDto, int64, error) {
+	attempts, total, err := s.attempts.FindAll(ctx, query)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	dtoAttempts := make([]LoginAttemptDto, len(attempts))
+	for i, a := range attempts {
+		dtoAttempts[i] = LoginAttemptDto{
+			ID:        a.ID,
+			Email:     a.Email,
+			UserID:    a.UserID,
+			IP:        a.IP,
+			UserAgent: a.UserAgent,
+			Result:    string(a.Result),
+			CreatedAt: a.CreatedAt.Format(time.RFC3339),
+		}
+	}
+	return dtoAttempts, total, nil
+}
+
+// JWKS returns the public keys access tokens can be verified with
+func (s *Service) JWKS() utils.JWKSet {
+	return s.jwt.JWKS()
+}
+
+// BeginOIDCLogin returns the identity provider URL to send the browser to and
+// the state that has to come back on the callback
+func (s *Service) BeginOIDCLogin(ctx context.Context) (string, string, error) {
+	if s.oidc == nil {
+		return "", "", ErrOIDCDisabled
+	}
+	return s.oidc.AuthCodeURL(ctx)
+}
+
+// CompleteOIDCLogin finishes the authorization code flow for the account
+// with the same email. Like a password login it is refused while the account
+// is locked, and accounts with two-factor enabled get a challenge token
+// instead of a session.
+func (s *Service) CompleteOIDCLogin(ctx context.Context, code, state string, meta RequestMeta) (*LoginResponse, error) {
+	if s.oidc == nil {
+		return nil, ErrOIDCDisabled
+	}
+
+	email, err := s.oidc.Exchange(ctx, code, state)
+	if err != nil {
+		if errors.Is(err, ErrEmailNotAllowed) {
+			s.recordAttempt(ctx, email, nil, meta, models.LoginOIDCRejected)
+		}
+		return nil, err
+	}
+
+	account, err := s.users.FindByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, user.ErrUserNotFound) {
+			s.recordAttempt(ctx, email, nil, meta, models.LoginOIDCRejected)
+			return nil, ErrEmailNotAllowed
+		}
+		return nil, err
+	}
+
+	if err := s.throttle.Check(ctx, account.Email, meta.IP); err != nil {
+		s.recordAttempt(ctx, account.Email, &account.ID, meta, models.LoginLocked)
+		return nil, err
+	}
+	return s.startLogin(ctx, account, meta)
+}
+
+// SetupTOTP starts two-factor enrollment for the signed in user
+func (s *Service) SetupTOTP(ctx context.Context, claims *utils.JWTClaims) (*user.TOTPSetupDto, error) {
+	userID, err := parseUserID(claims.Sub)
+	if err != nil {
+		return nil, err
+	}
+	return s.users.BeginTOTPEnrollment(ctx, userID)
+}
+
+// ConfirmTOTP finishes two-factor enrollment and returns the recovery codes
+func (s *Service) ConfirmTOTP(ctx context.Context, claims *utils.JWTClaims, code string) (*user.RecoveryCodesDto, error) {
+	userID, err := parseUserID(claims.Sub)
+	if err != nil {
+		return nil, err
+	}
+	return s.users.ConfirmTOTP(ctx, userID, code)
+}
+
+// DisableTOTP turns two-factor authentication off for the signed in user
+func (s *Service) DisableTOTP(ctx context.Context, claims *utils.JWTClaims, password, code string) error {
+	userID, err := parseUserID(claims.Sub)
+	if err != nil {
+		return err
+	}
+	return s.users.DisableTOTP(ctx, userID, password, code)
+}
+
+// Refresh rotates a refresh token and returns a fresh token pair
+func (s *Service) Refresh(ctx context.Context, refreshToken string, meta RequestMeta) (*TokenResponse, error) {
+	record, err := s.tokens.ConsumeRefreshToken(ctx, refreshToken)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.tokens.RefreshSession(ctx, record.FamilyID, meta.IP); err != nil {
+		log.Printf("⚠️ Failed to update session: %v", err)
+	}
+
+	// Reload the account so role changes and deletions apply on refresh
+	account, err := s.users.FindByID(ctx, record.UserID)
+	if err != nil {
+		if errors.Is(err, user.ErrUserNotFound) {
+			_ = s.tokens.RevokeFamily(ctx, record.FamilyID)
+			return nil, ErrInvalidRefreshToken
+		}
+		return nil, err
+	}
+
+	return s.issueTokens(ctx, account, record.FamilyID)
+}
+
+// Logout revokes the current access token and every refresh token of its session
+func (s *Service) Logout(ctx context.Context, claims *utils.JWTClaims) error {
+	if err := s.tokens.RevokeAccessToken(ctx, claims); err != nil {
+		return err
+	}
+	if claims.SessionID == "" {
+		return nil
+	}
+	return s.tokens.RevokeFamily(ctx, claims.SessionID)
+}
+
+// ListSessions returns the signed in user's sessions, flagging the current one
+func (s *Service) ListSessions(ctx context.Context, claims *utils.JWTClaims) ([]SessionDto, error) {
+	userID, err := parseUserID(claims.Sub)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions, err := s.tokens.ListSessions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	dtoSessions := make([]SessionDto, len(sessions))
+	for i, session := range sessions {
+		dtoSessions[i] = SessionDto{
+			ID:         session.ID,
+			Device:     session.Device,
+			IP:         session.IP,
+			UserAgent:  session.UserAgent,
+			Current:    session.ID == claims.SessionID,
+			CreatedAt:  time.Unix(session.CreatedAt, 0).UTC().Format(time.RFC3339),
+			LastSeenAt: time.Unix(session.LastSeenAt, 0).UTC().Format(time.RFC3339),
+		}
+	}
+	return dtoSessions, nil
+}
+
+// RevokeSession ends one of the signed in user's sessions. Its access tokens
+// are rejected by AuthGuard from the next request on.
+func (s *Service) RevokeSession(ctx context.Context, claims *utils.JWTClaims, sessionID string) error {
+	userID, err := parseUserID(claims.Sub)
+	if err != nil {
+		return err
+	}
+
+	owned, err := s.tokens.OwnsSession(ctx, userID, sessionID)
+	if err != nil {
+		return err
+	}
+	if !owned {
+		return ErrSessionNotFound
+	}
+	return s.tokens.RevokeFamily(ctx, sessionID)
+}
+
+// RevokeOtherSessions ends every session of the signed in user but the current one
+func (s *Service) RevokeOtherSessions(ctx context.Context, claims *utils.JWTClaims) error {
+	userID, err := parseUserID(claims.Sub)
+	if err != nil {
+		return err
+	}
+	return s.tokens.RevokeUserFamilies(ctx, userID, claims.SessionID)
+}
+
+// completeLogin resets the failure counter, records the attempt and starts a session
+func (s *Service) completeLogin(ctx context.Context, account *models.User, meta RequestMeta) (*TokenResponse, error) {
+	if err := s.throttle.RegisterSuccess(ctx, account.Email); err != nil {
+		log.Printf("⚠️ Failed to reset login failures: %v", err)
+	}
+	s.recordAttempt(ctx, account.Email, &account.ID, meta, models.LoginSucceeded)
+	return s.startSession(ctx, account, meta)
+}
+
+// recordAttempt persists the login history. A failed write is logged but
+// never blocks the login itself.
+func (s *Service) recordAttempt(ctx context.Context, email string, userID *uint, meta RequestMeta, result models.LoginResult) {
+	err := s.attempts.Create(ctx, &models.LoginAttempt{
+		Email:     normalize(email),
+		UserID:    userID,
+		IP:        meta.IP,
+		UserAgent: meta.UserAgent,
+		Result:    result,
+	})
+	if err != nil {
+		log.Printf("⚠️ Failed to record login attempt: %v", err)
+	}
+}
+
+func (s *Service) startSession(ctx context.Context, account *models.User, meta RequestMeta) (*TokenResponse, error) {
+	familyID, err := s.tokens.NewFamily()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.tokens.CreateSession(ctx, familyID, account.ID, meta); err != nil {
+		return nil, err
 	}
+	return s.issueTokens(ctx, account, familyID)
 }
 
-// Login checks the credentials and returns a token
-func (s *Service) Login(email, password string) (string, error) {
-	// Hardcoded check for now
-	if email != os.Getenv("ADMIN_EMAIL") {
-		return "", errors.New("Unauthorized")
+func (s *Service) issueTokens(ctx context.Context, account *models.User, familyID string) (*TokenResponse, error) {
+	accessToken, err := s.jwt.GenerateToken(formatUserID(account.ID), string(account.Role), familyID)
+	if err != nil {
+		return nil, err
 	}
 
-	match, err := argon2id.ComparePasswordAndHash(password, os.Getenv("ADMIN_PASSWORD_HASH"))
-	if err != nil || !match {
-		return "", errors.New("Unauthorized")
+	refreshToken, err := s.tokens.IssueRefreshToken(ctx, account.ID, familyID)
+	if err != nil {
+		return nil, err
 	}
 
-	return s.jwt.GenerateToken(os.Getenv("ADMIN_ID"))
+	return &TokenResponse{
+		Token:            accessToken,
+		RefreshToken:     refreshToken,
+		ExpiresIn:        int(s.jwt.TTL().Seconds()),
+		RefreshExpiresIn: int(s.tokens.RefreshTTL().Seconds()),
+	}, nil
+}
+
+func formatUserID(id uint) string {
+	return strconv.FormatUint(uint64(id), 10)
+}
 
+func parseUserID(sub string) (uint, error) {
+	id, err := strconv.ParseUint(sub, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	return uint(id), nil
 }
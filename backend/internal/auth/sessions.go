@@ -0,0 +1,198 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/othersidedrl/portfolio/backend/internal/utils"
+	"github.com/redis/go-redis/v9"
+)
+
+var ErrSessionNotFound = errors.New("session not found")
+
+// lastSeenResolution limits last-seen writes to one per session per minute
+const lastSeenResolution = time.Minute
+
+// Session is the metadata kept for every refresh token family.
+// Times are unix seconds because redis hashes only hold strings.
+type Session struct {
+	ID         string `redis:"id"`
+	UserID     uint   `redis:"user_id"`
+	Device     string `redis:"device"`
+	IP         string `redis:"ip"`
+	UserAgent  string `redis:"user_agent"`
+	CreatedAt  int64  `redis:"created_at"`
+	LastSeenAt int64  `redis:"last_seen_at"`
+}
+
+func sessionKey(familyID string) string     { return "auth:session:" + familyID }
+func sessionSeenKey(familyID string) string { return "auth:session_seen:" + familyID }
+
+// CreateSession stores the metadata of a new session
+func (s *TokenStore) CreateSession(ctx context.Context, familyID string, userID uint, meta RequestMeta) error {
+	now := time.Now().Unix()
+	session := Session{
+		ID:         familyID,
+		UserID:     userID,
+		Device:     describeDevice(meta.UserAgent),
+		IP:         meta.IP,
+		UserAgent:  meta.UserAgent,
+		CreatedAt:  now,
+		LastSeenAt: now,
+	}
+
+	pipe := s.client.TxPipeline()
+	pipe.HSet(ctx, sessionKey(familyID), session)
+	pipe.Expire(ctx, sessionKey(familyID), s.refreshTTL)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// RefreshSession marks the session as used and extends it with the new refresh token
+func (s *TokenStore) RefreshSession(ctx context.Context, familyID, ip string) error {
+	exists, err := s.client.Exists(ctx, sessionKey(familyID)).Result()
+	if err != nil || exists == 0 {
+		return err
+	}
+
+	pipe := s.client.TxPipeline()
+	pipe.HSet(ctx, sessionKey(familyID), "last_seen_at", time.Now().Unix(), "ip", ip)
+	pipe.Expire(ctx, sessionKey(familyID), s.refreshTTL)
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+// TouchSession updates the last-seen time of the token's session, at most
+// once a minute. Errors are ignored because this runs on every request.
+func (s *TokenStore) TouchSession(ctx context.Context, claims *utils.JWTClaims, ip string) {
+	if claims.SessionID == "" {
+		return
+	}
+
+	first, err := s.client.SetNX(ctx, sessionSeenKey(claims.SessionID), 1, lastSeenResolution).Result()
+	if err != nil || !first {
+		return
+	}
+
+	// HSET on a missing key would resurrect an expired session
+	exists, err := s.client.Exists(ctx, sessionKey(claims.SessionID)).Result()
+	if err != nil || exists == 0 {
+		return
+	}
+	s.client.HSet(ctx, sessionKey(claims.SessionID), "last_seen_at", time.Now().Unix(), "ip", ip)
+}
+
+// FindSession returns the metadata of a single session
+func (s *TokenStore) FindSession(ctx context.Context, familyID string) (*Session, error) {
+	var session Session
+	cmd := s.client.HGetAll(ctx, sessionKey(familyID))
+	if err := cmd.Err(); err != nil {
+		return nil, err
+	}
+	if len(cmd.Val()) == 0 {
+		return nil, ErrSessionNotFound
+	}
+	if err := cmd.Scan(&session); err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// ListSessions returns the user's sessions, most recently used first.
+// Sessions that expired on their own are dropped from the user's index.
+func (s *TokenStore) ListSessions(ctx context.Context, userID uint) ([]Session, error) {
+	familyIDs, err := s.client.SMembers(ctx, userFamiliesKey(userID)).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make([]Session, 0, len(familyIDs))
+	for _, familyID := range familyIDs {
+		session, err := s.FindSession(ctx, familyID)
+		if err != nil {
+			if errors.Is(err, ErrSessionNotFound) {
+				s.dropExpiredFamily(ctx, userID, familyID)
+				continue
+			}
+			return nil, err
+		}
+		sessions = append(sessions, *session)
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].LastSeenAt > sessions[j].LastSeenAt
+	})
+	return sessions, nil
+}
+
+// dropExpiredFamily removes a family from the user's index once its refresh
+// tokens are gone too. Families from before session tracking keep their entry.
+func (s *TokenStore) dropExpiredFamily(ctx context.Context, userID uint, familyID string) {
+	exists, err := s.client.Exists(ctx, familyKey(familyID)).Result()
+	if err == nil && exists == 0 {
+		s.client.SRem(ctx, userFamiliesKey(userID), familyID)
+	}
+}
+
+// deleteSession is queued on pipe when a family is revoked
+func (s *TokenStore) deleteSession(ctx context.Context, pipe redis.Pipeliner, familyID string) error {
+	userID, err := s.client.HGet(ctx, sessionKey(familyID), "user_id").Uint64()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return err
+	}
+
+	pipe.Del(ctx, sessionKey(familyID), sessionSeenKey(familyID))
+	if userID != 0 {
+		pipe.SRem(ctx, userFamiliesKey(uint(userID)), familyID)
+	}
+	return nil
+}
+
+// describeDevice turns a user agent into something like "Firefox on Linux".
+// It only needs to be good enough for a person to recognise their device.
+func describeDevice(userAgent string) string {
+	if userAgent == "" {
+		return "Unknown device"
+	}
+	ua := strings.ToLower(userAgent)
+
+	browser := "Unknown browser"
+	for _, candidate := range []struct{ token, name string }{
+		{"edg/", "Edge"},
+		{"opr/", "Opera"},
+		{"firefox/", "Firefox"},
+		{"chrome/", "Chrome"},
+		{"safari/", "Safari"},
+		{"curl/", "curl"},
+		{"postman", "Postman"},
+	} {
+		if strings.Contains(ua, candidate.token) {
+			browser = candidate.name
+			break
+		}
+	}
+
+	os := ""
+	for _, candidate := range []struct{ token, name string }{
+		{"iphone", "iOS"},
+		{"ipad", "iPadOS"},
+		{"android", "Android"},
+		{"windows", "Windows"},
+		{"mac os x", "macOS"},
+		{"cros", "ChromeOS"},
+		{"linux", "Linux"},
+	} {
+		if strings.Contains(ua, candidate.token) {
+			os = candidate.name
+			break
+		}
+	}
+
+	if os == "" {
+		return browser
+	}
+	return browser + " on " + os
+}
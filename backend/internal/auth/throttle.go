@@ -0,0 +1,122 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Failures allowed before back-off kicks in. IPs get more room because
+// several people can share one address.
+const (
+	accountFailureThreshold = 5
+	ipFailureThreshold      = 20
+	failureWindow           = 24 * time.Hour
+	baseLockout             = time.Minute
+	maxLockout              = time.Hour
+)
+
+// LockedError is returned while an account or IP is backing off
+type LockedError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LockedError) Error() string {
+	return fmt.Sprintf("too many failed login attempts, retry in %s", e.RetryAfter.Round(time.Second))
+}
+
+// LoginThrottle counts failed logins per account and per IP in Redis and
+// locks them out with exponential back-off
+type LoginThrottle struct {
+	client *redis.Client
+}
+
+func NewLoginThrottle(client *redis.Client) *LoginThrottle {
+	return &LoginThrottle{client: client}
+}
+
+func failureKey(kind, id string) string { return "auth:login_fail:" + kind + ":" + id }
+func lockKey(kind, id string) string    { return "auth:login_lock:" + kind + ":" + id }
+
+// Check returns a *LockedError when either the account or the IP is locked
+func (t *LoginThrottle) Check(ctx context.Context, email, ip string) error {
+	var retryAfter time.Duration
+	for _, key := range []string{lockKey("account", normalize(email)), lockKey("ip", ip)} {
+		ttl, err := t.client.PTTL(ctx, key).Result()
+		if err != nil {
+			return err
+		}
+		if ttl > retryAfter {
+			retryAfter = ttl
+		}
+	}
+
+	if retryAfter > 0 {
+		return &LockedError{RetryAfter: retryAfter}
+	}
+	return nil
+}
+
+// RegisterFailure bumps both counters and locks whichever crossed its threshold
+func (t *LoginThrottle) RegisterFailure(ctx context.Context, email, ip string) error {
+	if err := t.fail(ctx, "account", normalize(email), accountFailureThreshold); err != nil {
+		return err
+	}
+	return t.fail(ctx, "ip", ip, ipFailureThreshold)
+}
+
+// RegisterSuccess resets the account counter. The IP counter is left alone so
+// one valid login can't be used to keep guessing other accounts.
+func (t *LoginThrottle) RegisterSuccess(ctx context.Context, email string) error {
+	email = normalize(email)
+	return t.client.Del(ctx, failureKey("account", email), lockKey("account", email)).Err()
+}
+
+func (t *LoginThrottle) fail(ctx context.Context, kind, id string, threshold int64) error {
+	if id == "" {
+		return nil
+	}
+
+	pipe := t.client.TxPipeline()
+	incr := pipe.Incr(ctx, failureKey(kind, id))
+	pipe.Expire(ctx, failureKey(kind, id), failureWindow)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return err
+	}
+
+	failures := incr.Val()
+	if failures < threshold {
+		return nil
+	}
+
+	return t.client.Set(ctx, lockKey(kind, id), 1, lockoutFor(failures-threshold)).Err()
+}
+
+// lockoutFor doubles the lockout for every failure past the threshold
+func lockoutFor(excess int64) time.Duration {
+	lockout := baseLockout
+	for i := int64(0); i < excess && lockout < maxLockout; i++ {
+		lockout *= 2
+	}
+	if lockout > maxLockout {
+		lockout = maxLockout
+	}
+	return lockout
+}
+
+func normalize(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// IsLocked reports whether err is a lockout
+func IsLocked(err error) (*LockedError, bool) {
+	var locked *LockedError
+	if errors.As(err, &locked) {
+		return locked, true
+	}
+	return nil, false
+}
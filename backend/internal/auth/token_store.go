@@ -0,0 +1,292 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/othersidedrl/portfolio/backend/internal/utils"
+	"github.com/redis/go-redis/v9"
+)
+
+var (
+	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+	ErrRefreshTokenReused  = errors.New("refresh token reuse detected")
+	ErrInvalidResetToken   = errors.New("invalid or expired password reset token")
+)
+
+const (
+	defaultRefreshTokenTTL  = 7 * 24 * time.Hour
+	defaultPasswordResetTTL = 30 * time.Minute
+
+	// maxChallengeFailures is how many wrong codes a login challenge takes
+	// before it is burned and the user has to sign in again
+	maxChallengeFailures = 5
+)
+
+// RefreshRecord is what we keep in Redis for every issued refresh token.
+// The token itself is never stored, only its SHA-256 hash.
+type RefreshRecord struct {
+	UserID    uint      `json:"user_id"`
+	FamilyID  string    `json:"family_id"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// TokenStore keeps refresh tokens and revocations in Redis
+type TokenStore struct {
+	client     *redis.Client
+	refreshTTL time.Duration
+	accessTTL  time.Duration
+	resetTTL   time.Duration
+}
+
+func NewTokenStore(client *redis.Client, accessTTL time.Duration) *TokenStore {
+	return &TokenStore{
+		client:     client,
+		refreshTTL: utils.DurationFromEnv("REFRESH_TOKEN_TTL", defaultRefreshTokenTTL),
+		accessTTL:  accessTTL,
+		resetTTL:   utils.DurationFromEnv("PASSWORD_RESET_TTL", defaultPasswordResetTTL),
+	}
+}
+
+func refreshKey(hash string) string     { return "auth:refresh:" + hash }
+func refreshUsedKey(hash string) string { return "auth:refresh_used:" + hash }
+func familyKey(familyID string) string  { return "auth:family:" + familyID }
+func revokedFamilyKey(id string) string { return "auth:revoked_family:" + id }
+func revokedTokenKey(jti string) string { return "auth:revoked_jti:" + jti }
+func challengeFailuresKey(jti string) string {
+	return "auth:challenge_failures:" + jti
+}
+func userFamiliesKey(id uint) string {
+	return "auth:user_families:" + strconv.FormatUint(uint64(id), 10)
+}
+func resetTokenKey(hash string) string { return "auth:password_reset:" + hash }
+func userResetKey(id uint) string {
+	return "auth:password_reset_user:" + strconv.FormatUint(uint64(id), 10)
+}
+
+// RefreshTTL returns how long issued refresh tokens stay valid
+func (s *TokenStore) RefreshTTL() time.Duration {
+	return s.refreshTTL
+}
+
+// NewFamily starts a new refresh token family (one per login)
+func (s *TokenStore) NewFamily() (string, error) {
+	return utils.RandomToken(16)
+}
+
+// IssueRefreshToken creates a refresh token belonging to the given family
+func (s *TokenStore) IssueRefreshToken(ctx context.Context, userID uint, familyID string) (string, error) {
+	token, err := utils.RandomToken(32)
+	if err != nil {
+		return "", err
+	}
+	hash := utils.HashToken(token)
+
+	record, err := json.Marshal(RefreshRecord{
+		UserID:    userID,
+		FamilyID:  familyID,
+		ExpiresAt: time.Now().Add(s.refreshTTL),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	pipe := s.client.TxPipeline()
+	pipe.Set(ctx, refreshKey(hash), record, s.refreshTTL)
+	pipe.SAdd(ctx, familyKey(familyID), hash)
+	pipe.Expire(ctx, familyKey(familyID), s.refreshTTL)
+	pipe.SAdd(ctx, userFamiliesKey(userID), familyID)
+	pipe.Expire(ctx, userFamiliesKey(userID), s.refreshTTL)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+// ConsumeRefreshToken marks a refresh token as used and returns its record.
+// Presenting an already used token revokes its whole family.
+func (s *TokenStore) ConsumeRefreshToken(ctx context.Context, token string) (*RefreshRecord, error) {
+	hash := utils.HashToken(token)
+
+	raw, err := s.client.Get(ctx, refreshKey(hash)).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, ErrInvalidRefreshToken
+		}
+		return nil, err
+	}
+
+	var record RefreshRecord
+	if err := json.Unmarshal([]byte(raw), &record); err != nil {
+		return nil, err
+	}
+
+	remaining := time.Until(record.ExpiresAt)
+	if remaining <= 0 {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	// SETNX makes rotation atomic: only the first caller gets to use the token
+	first, err := s.client.SetNX(ctx, refreshUsedKey(hash), 1, remaining).Result()
+	if err != nil {
+		return nil, err
+	}
+	if !first {
+		if err := s.RevokeFamily(ctx, record.FamilyID); err != nil {
+			return nil, err
+		}
+		return nil, ErrRefreshTokenReused
+	}
+
+	return &record, nil
+}
+
+// RevokeFamily deletes every refresh token of a family and blocks the
+// access tokens that were issued for it
+func (s *TokenStore) RevokeFamily(ctx context.Context, familyID string) error {
+	hashes, err := s.client.SMembers(ctx, familyKey(familyID)).Result()
+	if err != nil {
+		return err
+	}
+
+	pipe := s.client.TxPipeline()
+	for _, hash := range hashes {
+		pipe.Del(ctx, refreshKey(hash))
+	}
+	pipe.Del(ctx, familyKey(familyID))
+	pipe.Set(ctx, revokedFamilyKey(familyID), 1, s.refreshTTL+s.accessTTL)
+	if err := s.deleteSession(ctx, pipe, familyID); err != nil {
+		return err
+	}
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+// RevokeUserFamilies revokes every session of a user except keepFamilyID
+// (pass "" to revoke all of them)
+func (s *TokenStore) RevokeUserFamilies(ctx context.Context, userID uint, keepFamilyID string) error {
+	familyIDs, err := s.client.SMembers(ctx, userFamiliesKey(userID)).Result()
+	if err != nil {
+		return err
+	}
+
+	for _, familyID := range familyIDs {
+		if familyID == keepFamilyID {
+			continue
+		}
+		if err := s.RevokeFamily(ctx, familyID); err != nil {
+			return err
+		}
+		if err := s.client.SRem(ctx, userFamiliesKey(userID), familyID).Err(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// OwnsSession reports whether the session belongs to the user
+func (s *TokenStore) OwnsSession(ctx context.Context, userID uint, familyID string) (bool, error) {
+	return s.client.SIsMember(ctx, userFamiliesKey(userID), familyID).Result()
+}
+
+// IssueResetToken creates a password reset token for the user. Only the
+// newest token stays valid.
+func (s *TokenStore) IssueResetToken(ctx context.Context, userID uint) (string, error) {
+	token, err := utils.RandomToken(32)
+	if err != nil {
+		return "", err
+	}
+	hash := utils.HashToken(token)
+
+	previous, err := s.client.GetSet(ctx, userResetKey(userID), hash).Result()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return "", err
+	}
+
+	pipe := s.client.TxPipeline()
+	if previous != "" {
+		pipe.Del(ctx, resetTokenKey(previous))
+	}
+	pipe.Expire(ctx, userResetKey(userID), s.resetTTL)
+	pipe.Set(ctx, resetTokenKey(hash), userID, s.resetTTL)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+// ConsumeResetToken returns the user a reset token was issued for.
+// GETDEL makes every token single use.
+func (s *TokenStore) ConsumeResetToken(ctx context.Context, token string) (uint, error) {
+	hash := utils.HashToken(token)
+
+	userID, err := s.client.GetDel(ctx, resetTokenKey(hash)).Uint64()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return 0, ErrInvalidResetToken
+		}
+		return 0, err
+	}
+
+	s.client.Del(ctx, userResetKey(uint(userID)))
+	return uint(userID), nil
+}
+
+// ResetTTL returns how long password reset tokens stay valid
+func (s *TokenStore) ResetTTL() time.Duration {
+	return s.resetTTL
+}
+
+// RevokeAccessToken blocks a single access token until it expires
+func (s *TokenStore) RevokeAccessToken(ctx context.Context, claims *utils.JWTClaims) error {
+	if claims.ID == "" || claims.ExpiresAt == nil {
+		return nil
+	}
+	remaining := time.Until(claims.ExpiresAt.Time)
+	if remaining <= 0 {
+		return nil
+	}
+	return s.client.Set(ctx, revokedTokenKey(claims.ID), 1, remaining).Err()
+}
+
+// RegisterChallengeFailure counts a wrong code against a login challenge and
+// revokes the challenge once it has had maxChallengeFailures
+func (s *TokenStore) RegisterChallengeFailure(ctx context.Context, claims *utils.JWTClaims) error {
+	if claims.ID == "" || claims.ExpiresAt == nil {
+		return nil
+	}
+	remaining := time.Until(claims.ExpiresAt.Time)
+	if remaining <= 0 {
+		return nil
+	}
+
+	pipe := s.client.TxPipeline()
+	failures := pipe.Incr(ctx, challengeFailuresKey(claims.ID))
+	pipe.Expire(ctx, challengeFailuresKey(claims.ID), remaining)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return err
+	}
+	if failures.Val() >= maxChallengeFailures {
+		return s.RevokeAccessToken(ctx, claims)
+	}
+	return nil
+}
+
+// IsRevoked reports whether the access token or its session has been revoked
+func (s *TokenStore) IsRevoked(ctx context.Context, claims *utils.JWTClaims) (bool, error) {
+	keys := []string{revokedTokenKey(claims.ID)}
+	if claims.SessionID != "" {
+		keys = append(keys, revokedFamilyKey(claims.SessionID))
+	}
+
+	count, err := s.client.Exists(ctx, keys...).Result()
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
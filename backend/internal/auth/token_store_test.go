@@ -0,0 +1,140 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/othersidedrl/portfolio/backend/internal/utils"
+	"github.com/redis/go-redis/v9"
+)
+
+// newTestTokenStore connects to the Redis at REDIS_TEST_ADDR. Every key the
+// tests write is random, so a shared development Redis is fine.
+func newTestTokenStore(t *testing.T) *TokenStore {
+	t.Helper()
+	addr := os.Getenv("REDIS_TEST_ADDR")
+	if addr == "" {
+		t.Skip("REDIS_TEST_ADDR not set")
+	}
+	client := redis.NewClient(&redis.Options{Addr: addr, Password: os.Getenv("REDIS_TEST_PASSWORD")})
+	t.Cleanup(func() { client.Close() })
+	if err := client.Ping(context.Background()).Err(); err != nil {
+		t.Fatalf("redis at %s: %v", addr, err)
+	}
+	return NewTokenStore(client, 15*time.Minute)
+}
+
+func TestRefreshTokenRotation(t *testing.T) {
+	store := newTestTokenStore(t)
+	ctx := context.Background()
+
+	family, err := store.NewFamily()
+	if err != nil {
+		t.Fatal(err)
+	}
+	first, err := store.IssueRefreshToken(ctx, 1, family)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	record, err := store.ConsumeRefreshToken(ctx, first)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if record.UserID != 1 || record.FamilyID != family {
+		t.Errorf("record = %+v", record)
+	}
+	second, err := store.IssueRefreshToken(ctx, 1, family)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Replaying the first token gives the family away as stolen
+	if _, err := store.ConsumeRefreshToken(ctx, first); !errors.Is(err, ErrRefreshTokenReused) {
+		t.Fatalf("replay: err = %v, want %v", err, ErrRefreshTokenReused)
+	}
+	if _, err := store.ConsumeRefreshToken(ctx, second); !errors.Is(err, ErrInvalidRefreshToken) {
+		t.Errorf("rotated token after reuse: err = %v, want %v", err, ErrInvalidRefreshToken)
+	}
+	revoked, err := store.IsRevoked(ctx, &utils.JWTClaims{SessionID: family, RegisteredClaims: jwt.RegisteredClaims{ID: "access"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !revoked {
+		t.Error("access tokens of the family are still valid")
+	}
+}
+
+func TestRefreshTokenConsumedOnce(t *testing.T) {
+	store := newTestTokenStore(t)
+	ctx := context.Background()
+
+	family, err := store.NewFamily()
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, err := store.IssueRefreshToken(ctx, 1, family)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	succeeded := 0
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := store.ConsumeRefreshToken(ctx, token); err == nil {
+				mu.Lock()
+				succeeded++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if succeeded != 1 {
+		t.Errorf("%d concurrent refreshes succeeded, want 1", succeeded)
+	}
+}
+
+func TestChallengeBurnedAfterFailures(t *testing.T) {
+	store := newTestTokenStore(t)
+	ctx := context.Background()
+
+	jti, err := utils.RandomToken(16)
+	if err != nil {
+		t.Fatal(err)
+	}
+	claims := &utils.JWTClaims{RegisteredClaims: jwt.RegisteredClaims{
+		ID:        jti,
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)),
+	}}
+
+	for i := 1; i <= maxChallengeFailures; i++ {
+		revoked, err := store.IsRevoked(ctx, claims)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if revoked {
+			t.Fatalf("challenge revoked after %d failures, want %d", i-1, maxChallengeFailures)
+		}
+		if err := store.RegisterChallengeFailure(ctx, claims); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	revoked, err := store.IsRevoked(ctx, claims)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !revoked {
+		t.Errorf("challenge still usable after %d failures", maxChallengeFailures)
+	}
+}
@@ -0,0 +1,119 @@
+package content
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/othersidedrl/portfolio/backend/internal/models"
+)
+
+type Handler struct {
+	service *Service
+}
+
+func NewHandler(service *Service) *Handler {
+	return &Handler{service}
+}
+
+// GetRevisions handles GET /admin/revisions?entity_type=&entity_id=.
+// entity_id can be left out for single-row pages. Supports ?page=&limit=.
+func (h *Handler) GetRevisions(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	entityType := models.EntityType(q.Get("entity_type"))
+	if entityType == "" {
+		http.Error(w, "entity_type is required", http.StatusBadRequest)
+		return
+	}
+	var entityID uint
+	if raw := q.Get("entity_id"); raw != "" {
+		id, err := strconv.Atoi(raw)
+		if err != nil || id < 0 {
+			http.Error(w, "Invalid entity ID", http.StatusBadRequest)
+			return
+		}
+		entityID = uint(id)
+	}
+
+	page, limit := 1, 50
+	if value, err := strconv.Atoi(q.Get("page")); err == nil && value > 0 {
+		page = value
+	}
+	if value, err := strconv.Atoi(q.Get("limit")); err == nil && value > 0 && value <= 200 {
+		limit = value
+	}
+
+	revisions, total, err := h.service.GetRevisions(r.Context(), entityType, entityID, page, limit)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response := map[string]interface{}{
+		"length": len(revisions),
+		"data":   revisions,
+		"page":   page,
+		"limit":  limit,
+		"total":  total,
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+func (h *Handler) GetRevision(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid revision ID", http.StatusBadRequest)
+		return
+	}
+
+	revision, err := h.service.GetRevision(r.Context(), uint(id))
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(revision)
+}
+
+// DiffRevisions handles GET /admin/revisions/diff?from=&to=
+func (h *Handler) DiffRevisions(w http.ResponseWriter, r *http.Request) {
+	from, err := strconv.Atoi(r.URL.Query().Get("from"))
+	if err != nil {
+		http.Error(w, "Invalid from revision ID", http.StatusBadRequest)
+		return
+	}
+	to, err := strconv.Atoi(r.URL.Query().Get("to"))
+	if err != nil {
+		http.Error(w, "Invalid to revision ID", http.StatusBadRequest)
+		return
+	}
+
+	diff, err := h.service.DiffRevisions(r.Context(), uint(from), uint(to))
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(diff)
+}
+
+// WriteError maps draft and revision errors to HTTP status codes. The domain
+// handlers fall back to it for errors they do not know themselves.
+func WriteError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, ErrRevisionNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, ErrRevisionMismatch), errors.Is(err, ErrInvalidSchedule):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, ErrNoDraft), errors.Is(err, ErrNothingToPublish):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
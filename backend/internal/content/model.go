@@ -0,0 +1,28 @@
+package content
+
+import (
+	"time"
+
+	"github.com/othersidedrl/portfolio/backend/internal/models"
+	"github.com/othersidedrl/portfolio/backend/internal/utils"
+)
+
+type RevisionDto struct {
+	ID         uint              `json:"id"`
+	EntityType models.EntityType `json:"entity_type"`
+	EntityID   uint              `json:"entity_id"`
+	AuthorID   string            `json:"author_id"`
+	Data       models.JSONB      `json:"data,omitempty"`
+	CreatedAt  string            `json:"created_at"`
+}
+
+type RevisionDiffDto struct {
+	From    uint                         `json:"from"`
+	To      uint                         `json:"to"`
+	Changes map[string]utils.FieldChange `json:"changes"`
+}
+
+type ScheduleDto struct {
+	PublishAt   *time.Time `json:"publish_at"`
+	UnpublishAt *time.Time `json:"unpublish_at"`
+}
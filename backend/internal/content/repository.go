@@ -0,0 +1,106 @@
+package content
+
+import (
+	"context"
+
+	"github.com/othersidedrl/portfolio/backend/internal/database"
+	"github.com/othersidedrl/portfolio/backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type DraftRepository interface {
+	FindDraft(ctx context.Context, entityType models.EntityType, entityID uint) (*models.ContentDraft, error)
+	SaveDraft(ctx context.Context, draft *models.ContentDraft) error
+	DeleteDraft(ctx context.Context, entityType models.EntityType, entityID uint) (bool, error)
+}
+
+type GormDraftRepository struct {
+	db *gorm.DB
+}
+
+func NewGormDraftRepository(db *gorm.DB) *GormDraftRepository {
+	return &GormDraftRepository{db: db}
+}
+
+func (r *GormDraftRepository) FindDraft(ctx context.Context, entityType models.EntityType, entityID uint) (*models.ContentDraft, error) {
+	var draft models.ContentDraft
+	err := database.Conn(ctx, r.db).
+		Where("entity_type = ? AND entity_id = ?", entityType, entityID).
+		First(&draft).Error
+	if err != nil {
+		return nil, err
+	}
+	return &draft, nil
+}
+
+// SaveDraft inserts the draft or replaces the existing one for the same entity
+func (r *GormDraftRepository) SaveDraft(ctx context.Context, draft *models.ContentDraft) error {
+	return database.Conn(ctx, r.db).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "entity_type"}, {Name: "entity_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"data", "updated_by_id", "updated_at"}),
+	}).Create(draft).Error
+}
+
+func (r *GormDraftRepository) DeleteDraft(ctx context.Context, entityType models.EntityType, entityID uint) (bool, error) {
+	result := database.Conn(ctx, r.db).
+		Where("entity_type = ? AND entity_id = ?", entityType, entityID).
+		Delete(&models.ContentDraft{})
+	return result.RowsAffected > 0, result.Error
+}
+
+type RevisionRepository interface {
+	CreateRevision(ctx context.Context, revision *models.ContentRevision) error
+	FindRevision(ctx context.Context, id uint) (*models.ContentRevision, error)
+	FindRevisions(ctx context.Context, entityType models.EntityType, entityID uint, page, limit int) ([]models.ContentRevision, int64, error)
+	CountRevisions(ctx context.Context, entityType models.EntityType, entityID uint) (int64, error)
+}
+
+type GormRevisionRepository struct {
+	db *gorm.DB
+}
+
+func NewGormRevisionRepository(db *gorm.DB) *GormRevisionRepository {
+	return &GormRevisionRepository{db: db}
+}
+
+func (r *GormRevisionRepository) CreateRevision(ctx context.Context, revision *models.ContentRevision) error {
+	return database.Conn(ctx, r.db).Create(revision).Error
+}
+
+func (r *GormRevisionRepository) FindRevision(ctx context.Context, id uint) (*models.ContentRevision, error) {
+	var revision models.ContentRevision
+	if err := database.Conn(ctx, r.db).First(&revision, id).Error; err != nil {
+		return nil, err
+	}
+	return &revision, nil
+}
+
+// FindRevisions returns a page of the entity's revisions, newest first, and the total count
+func (r *GormRevisionRepository) FindRevisions(ctx context.Context, entityType models.EntityType, entityID uint, page, limit int) ([]models.ContentRevision, int64, error) {
+	tx := database.Conn(ctx, r.db).Model(&models.ContentRevision{}).
+		Where("entity_type = ? AND entity_id = ?", entityType, entityID)
+
+	var total int64
+	if err := tx.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var revisions []models.ContentRevision
+	err := tx.Order("id DESC").
+		Offset((page - 1) * limit).
+		Limit(limit).
+		Find(&revisions).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return revisions, total, nil
+}
+
+func (r *GormRevisionRepository) CountRevisions(ctx context.Context, entityType models.EntityType, entityID uint) (int64, error) {
+	var count int64
+	err := database.Conn(ctx, r.db).Model(&models.ContentRevision{}).
+		Where("entity_type = ? AND entity_id = ?", entityType, entityID).
+		Count(&count).Error
+	return count, err
+}
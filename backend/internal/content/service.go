@@ -0,0 +1,241 @@
+package content
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"time"
+
+	"github.com/othersidedrl/portfolio/backend/internal/audit"
+	"github.com/othersidedrl/portfolio/backend/internal/middleware"
+	"github.com/othersidedrl/portfolio/backend/internal/models"
+	"github.com/othersidedrl/portfolio/backend/internal/utils"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrNoDraft          = errors.New("there is no draft to publish")
+	ErrNothingToPublish = errors.New("already published and there is no draft")
+	ErrRevisionNotFound = errors.New("revision not found")
+	ErrRevisionMismatch = errors.New("revisions belong to different entities")
+	ErrInvalidSchedule  = errors.New("unpublish_at must be after publish_at")
+)
+
+// Service keeps the unpublished drafts and the published revisions of pages
+// and projects. The domain services decide what publishing a draft or
+// restoring a revision means for their tables.
+type Service struct {
+	drafts    DraftRepository
+	revisions RevisionRepository
+	recorder  audit.Recorder
+}
+
+func NewService(drafts DraftRepository, revisions RevisionRepository, recorder audit.Recorder) *Service {
+	return &Service{drafts, revisions, recorder}
+}
+
+// SaveDraft stores data as the draft of the entity, replacing any previous draft
+func (s *Service) SaveDraft(ctx context.Context, entityType models.EntityType, entityID uint, data interface{}) error {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	var before interface{}
+	if previous, err := s.drafts.FindDraft(ctx, entityType, entityID); err == nil {
+		before = previous.Data
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	draft := &models.ContentDraft{
+		EntityType: entityType,
+		EntityID:   entityID,
+		Data:       raw,
+	}
+	if claims := middleware.GetUserFromContext(ctx); claims != nil {
+		draft.UpdatedByID = claims.Sub
+	}
+	if err := s.drafts.SaveDraft(ctx, draft); err != nil {
+		return err
+	}
+
+	s.recorder.Record(ctx, models.AuditSaveDraft, entityType, recordID(entityID), before, draft.Data)
+	return nil
+}
+
+// LoadDraft decodes the entity's draft into dst and reports whether there was one
+func (s *Service) LoadDraft(ctx context.Context, entityType models.EntityType, entityID uint, dst interface{}) (bool, error) {
+	draft, err := s.drafts.FindDraft(ctx, entityType, entityID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	if err := json.Unmarshal(draft.Data, dst); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// DeleteDraft removes the draft after it has been published
+func (s *Service) DeleteDraft(ctx context.Context, entityType models.EntityType, entityID uint) error {
+	_, err := s.drafts.DeleteDraft(ctx, entityType, entityID)
+	return err
+}
+
+// DiscardDraft throws the draft away without publishing it
+func (s *Service) DiscardDraft(ctx context.Context, entityType models.EntityType, entityID uint) error {
+	draft, err := s.drafts.FindDraft(ctx, entityType, entityID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrNoDraft
+		}
+		return err
+	}
+	if _, err := s.drafts.DeleteDraft(ctx, entityType, entityID); err != nil {
+		return err
+	}
+
+	s.recorder.Record(ctx, models.AuditDiscardDraft, entityType, recordID(entityID), draft.Data, nil)
+	return nil
+}
+
+// SaveRevision stores the live state of an entity after a write. The first
+// revision of an entity that existed before history was kept also stores
+// the state it replaced, so that edit can be rolled back too.
+func (s *Service) SaveRevision(ctx context.Context, entityType models.EntityType, entityID uint, before, after interface{}) error {
+	if v := reflect.ValueOf(before); before != nil && !(v.Kind() == reflect.Ptr && v.IsNil()) {
+		count, err := s.revisions.CountRevisions(ctx, entityType, entityID)
+		if err != nil {
+			return err
+		}
+		if count == 0 {
+			if err := s.createRevision(ctx, entityType, entityID, before); err != nil {
+				return err
+			}
+		}
+	}
+	return s.createRevision(ctx, entityType, entityID, after)
+}
+
+func (s *Service) createRevision(ctx context.Context, entityType models.EntityType, entityID uint, data interface{}) error {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	revision := &models.ContentRevision{
+		EntityType: entityType,
+		EntityID:   entityID,
+		Data:       raw,
+	}
+	if claims := middleware.GetUserFromContext(ctx); claims != nil {
+		revision.AuthorID = claims.Sub
+	}
+	return s.revisions.CreateRevision(ctx, revision)
+}
+
+// GetRevisions lists the revisions of an entity, newest first, without their data
+func (s *Service) GetRevisions(ctx context.Context, entityType models.EntityType, entityID uint, page, limit int) ([]RevisionDto, int64, error) {
+	revisions, total, err := s.revisions.FindRevisions(ctx, entityType, entityID, page, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	dtos := make([]RevisionDto, 0, len(revisions))
+	for _, revision := range revisions {
+		dto := toRevisionDto(revision)
+		dto.Data = nil
+		dtos = append(dtos, dto)
+	}
+	return dtos, total, nil
+}
+
+// GetRevision returns a single revision with its data
+func (s *Service) GetRevision(ctx context.Context, id uint) (*RevisionDto, error) {
+	revision, err := s.findRevision(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	dto := toRevisionDto(*revision)
+	return &dto, nil
+}
+
+// DiffRevisions compares two revisions of the same entity field by field
+func (s *Service) DiffRevisions(ctx context.Context, fromID, toID uint) (*RevisionDiffDto, error) {
+	from, err := s.findRevision(ctx, fromID)
+	if err != nil {
+		return nil, err
+	}
+	to, err := s.findRevision(ctx, toID)
+	if err != nil {
+		return nil, err
+	}
+	if from.EntityType != to.EntityType || from.EntityID != to.EntityID {
+		return nil, ErrRevisionMismatch
+	}
+
+	changes, err := utils.DiffJSON(from.Data, to.Data)
+	if err != nil {
+		return nil, err
+	}
+	return &RevisionDiffDto{From: from.ID, To: to.ID, Changes: changes}, nil
+}
+
+// LoadRevision decodes a revision of the given entity into dst
+func (s *Service) LoadRevision(ctx context.Context, entityType models.EntityType, entityID, revisionID uint, dst interface{}) error {
+	revision, err := s.findRevision(ctx, revisionID)
+	if err != nil {
+		return err
+	}
+	if revision.EntityType != entityType || revision.EntityID != entityID {
+		return ErrRevisionNotFound
+	}
+	return json.Unmarshal(revision.Data, dst)
+}
+
+func (s *Service) findRevision(ctx context.Context, id uint) (*models.ContentRevision, error) {
+	revision, err := s.revisions.FindRevision(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrRevisionNotFound
+		}
+		return nil, err
+	}
+	return revision, nil
+}
+
+func toRevisionDto(revision models.ContentRevision) RevisionDto {
+	return RevisionDto{
+		ID:         revision.ID,
+		EntityType: revision.EntityType,
+		EntityID:   revision.EntityID,
+		AuthorID:   revision.AuthorID,
+		Data:       revision.Data,
+		CreatedAt:  revision.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+// ToSchedule validates a schedule sent by the admin and converts it to the model
+func ToSchedule(data ScheduleDto) (models.Schedule, error) {
+	if data.PublishAt != nil && data.UnpublishAt != nil && !data.UnpublishAt.After(*data.PublishAt) {
+		return models.Schedule{}, ErrInvalidSchedule
+	}
+	return models.Schedule{PublishAt: data.PublishAt, UnpublishAt: data.UnpublishAt}, nil
+}
+
+// ToScheduleDto converts a stored schedule for the admin
+func ToScheduleDto(schedule models.Schedule) *ScheduleDto {
+	return &ScheduleDto{PublishAt: schedule.PublishAt, UnpublishAt: schedule.UnpublishAt}
+}
+
+// recordID leaves the audit entity id empty for single-row pages
+func recordID(entityID uint) interface{} {
+	if entityID == 0 {
+		return nil
+	}
+	return entityID
+}
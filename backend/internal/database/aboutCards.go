@@ -0,0 +1,73 @@
+package database
+
+import (
+	"errors"
+
+	"github.com/othersidedrl/portfolio/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// About cards used to be recreated on every save, so their translations
+// were keyed by the card's position on the page. Cards now keep their ID and
+// store their position: existing cards are numbered in their old order and
+// their translations are moved from the position to the card ID.
+
+// hasLegacyAboutCards runs before AutoMigrate and reports whether the cards
+// still lack their position
+func hasLegacyAboutCards(db *gorm.DB) bool {
+	migrator := db.Migrator()
+	return migrator.HasTable(&models.AboutCard{}) && !migrator.HasColumn(&models.AboutCard{}, "position")
+}
+
+// migrateLegacyAboutCards runs after AutoMigrate. Translations of positions
+// that have no card are dropped.
+func migrateLegacyAboutCards(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		var cards []models.AboutCard
+		if err := tx.Order("about_page_id, id").Find(&cards).Error; err != nil {
+			return err
+		}
+		// The site shows the first about page, so its positions are the ones translated
+		var page models.AboutPage
+		err := tx.First(&page).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+
+		cardAt := make(map[uint]uint)
+		positions := make(map[uint]int)
+		for _, card := range cards {
+			position := positions[card.AboutPageID]
+			positions[card.AboutPageID]++
+			if err := tx.Model(&models.AboutCard{}).Where("id = ?", card.ID).Update("position", position).Error; err != nil {
+				return err
+			}
+			if card.AboutPageID == page.ID {
+				cardAt[uint(position)] = card.ID
+			}
+		}
+
+		var translations []models.Translation
+		if err := tx.Where("entity_type = ?", models.EntityAboutCard).Find(&translations).Error; err != nil {
+			return err
+		}
+		if len(translations) == 0 {
+			return nil
+		}
+		// Deleted first so moving one onto the key of another cannot collide
+		if err := tx.Where("entity_type = ?", models.EntityAboutCard).Delete(&models.Translation{}).Error; err != nil {
+			return err
+		}
+		moved := make([]models.Translation, 0, len(translations))
+		for _, translation := range translations {
+			if id, ok := cardAt[translation.EntityID]; ok {
+				translation.ID, translation.EntityID = 0, id
+				moved = append(moved, translation)
+			}
+		}
+		if len(moved) == 0 {
+			return nil
+		}
+		return tx.Create(&moved).Error
+	})
+}
@@ -0,0 +1,103 @@
+package database
+
+import (
+	"log"
+	"strings"
+	"time"
+
+	"github.com/othersidedrl/portfolio/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// Career entries used to keep their dates as free-form text. Before
+// AutoMigrate the text columns are renamed out of the way, and afterwards
+// they are parsed into the new date columns and dropped.
+const (
+	legacyStartedAt = "legacy_started_at"
+	legacyEndedAt   = "legacy_ended_at"
+)
+
+// renameLegacyCareerDates runs before AutoMigrate
+func renameLegacyCareerDates(db *gorm.DB) error {
+	var dataType string
+	err := db.Raw(
+		"SELECT data_type FROM information_schema.columns WHERE table_schema = current_schema() AND table_name = ? AND column_name = ?",
+		"career_journeys", "started_at",
+	).Scan(&dataType).Error
+	if err != nil || dataType != "text" {
+		return err
+	}
+
+	migrator := db.Migrator()
+	if err := migrator.RenameColumn(&models.CareerJourney{}, "started_at", legacyStartedAt); err != nil {
+		return err
+	}
+	return migrator.RenameColumn(&models.CareerJourney{}, "ended_at", legacyEndedAt)
+}
+
+// migrateLegacyCareerDates runs after AutoMigrate. Dates it cannot read are
+// logged and left empty for an admin to fill in.
+func migrateLegacyCareerDates(db *gorm.DB) error {
+	migrator := db.Migrator()
+	if !migrator.HasColumn(&models.CareerJourney{}, legacyStartedAt) {
+		return nil
+	}
+
+	var rows []struct {
+		ID        uint
+		StartedAt string
+		EndedAt   string
+	}
+	err := db.Table("career_journeys").
+		Select("id, COALESCE(" + legacyStartedAt + ", '') AS started_at, COALESCE(" + legacyEndedAt + ", '') AS ended_at").
+		Scan(&rows).Error
+	if err != nil {
+		return err
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		for _, row := range rows {
+			start, _ := parseLegacyMonth(row.StartedAt)
+			if start == nil {
+				log.Printf("Career entry %d: could not read start date %q", row.ID, row.StartedAt)
+			}
+			end, ongoing := parseLegacyMonth(row.EndedAt)
+			if end == nil && !ongoing {
+				log.Printf("Career entry %d: could not read end date %q", row.ID, row.EndedAt)
+			}
+
+			err := tx.Table("career_journeys").Where("id = ?", row.ID).Updates(map[string]interface{}{
+				"started_at": start,
+				"ended_at":   end,
+				"ongoing":    ongoing,
+			}).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Migrator().DropColumn(&models.CareerJourney{}, legacyStartedAt); err != nil {
+			return err
+		}
+		return tx.Migrator().DropColumn(&models.CareerJourney{}, legacyEndedAt)
+	})
+}
+
+// parseLegacyMonth reads the dates the CMS used to send ("2021-03-15"), and
+// a few hand-written forms, down to the month. "Present" and blanks mean the
+// entry is ongoing.
+func parseLegacyMonth(value string) (*time.Time, bool) {
+	value = strings.TrimSpace(value)
+	switch strings.ToLower(value) {
+	case "", "present", "now", "current", "ongoing":
+		return nil, true
+	}
+
+	for _, layout := range []string{time.DateOnly, "2006-01", "January 2006", "Jan 2006", "01/2006", "2006"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			month := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+			return &month, false
+		}
+	}
+	return nil, false
+}
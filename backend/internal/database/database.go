@@ -42,8 +42,14 @@ func ConnectDB() *gorm.DB {
 		log.Fatal("Failed to connect to DB:", err)
 	}
 
+	if err := renameLegacyCareerDates(db); err != nil {
+		log.Fatal("Career date migration failed:", err)
+	}
+	legacyAboutCards := hasLegacyAboutCards(db)
+
 	// Auto-migrate tables
 	err = db.AutoMigrate(
+		&models.Technology{},
 		&models.HeroPage{},
 		&models.AboutPage{},
 		&models.AboutCard{},
@@ -53,13 +59,30 @@ func ConnectDB() *gorm.DB {
 		&models.Testimony{},
 		&models.ProjectPage{},
 		&models.Project{},
-		// &models.User{},
+		&models.ProjectSlug{},
+		&models.User{},
+		&models.RecoveryCode{},
+		&models.LoginAttempt{},
+		&models.APIKey{},
+		&models.AuditLog{},
+		&models.ContentDraft{},
+		&models.ContentRevision{},
+		&models.Translation{},
 		// You can add more models here
 	)
 	if err != nil {
 		log.Fatal("Auto migration failed:", err)
 	}
 
+	if err := migrateLegacyCareerDates(db); err != nil {
+		log.Fatal("Career date migration failed:", err)
+	}
+	if legacyAboutCards {
+		if err := migrateLegacyAboutCards(db); err != nil {
+			log.Fatal("About card migration failed:", err)
+		}
+	}
+
 	log.Println("✅ Connected and migrated DB successfully!")
 	return db
 }
@@ -2,9 +2,14 @@ package hero
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
+	"strconv"
 
+	"github.com/go-chi/chi/v5"
+	"github.com/othersidedrl/portfolio/backend/internal/content"
 	"github.com/othersidedrl/portfolio/backend/internal/utils"
+	"gorm.io/gorm"
 )
 
 type Handler struct {
@@ -26,6 +31,45 @@ func (h *Handler) GetHeroPage(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(hero)
 }
 
+func (h *Handler) GetPublicHeroPage(w http.ResponseWriter, r *http.Request) {
+	hero, err := h.service.FindPublic(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(hero)
+}
+
+func (h *Handler) GetHeroPageSchedule(w http.ResponseWriter, r *http.Request) {
+	schedule, err := h.service.GetSchedule(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(schedule)
+}
+
+func (h *Handler) UpdateHeroPageSchedule(w http.ResponseWriter, r *http.Request) {
+	var body content.ScheduleDto
+	if err := utils.DecodeBody(r, &body); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.SetSchedule(r.Context(), body); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"message": "Hero page schedule updated"})
+}
+
 func (h *Handler) UpdateHeroPage(w http.ResponseWriter, r *http.Request) {
 	var body HeroPageDto
 
@@ -35,12 +79,68 @@ func (h *Handler) UpdateHeroPage(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.service.Update(r.Context(), body); err != nil {
+	if err := h.service.SaveDraft(r.Context(), body); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"message": "Hero page draft saved"})
+}
+
+func (h *Handler) PreviewHeroPage(w http.ResponseWriter, r *http.Request) {
+	hero, err := h.service.Preview(r.Context())
+	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(hero)
+}
+
+func (h *Handler) PublishHeroPage(w http.ResponseWriter, r *http.Request) {
+	if err := h.service.Publish(r.Context()); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"message": "Hero page published"})
+}
+
+func (h *Handler) DiscardHeroPageDraft(w http.ResponseWriter, r *http.Request) {
+	if err := h.service.DiscardDraft(r.Context()); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) RestoreHeroPage(w http.ResponseWriter, r *http.Request) {
+	revisionID, err := strconv.Atoi(chi.URLParam(r, "revisionID"))
+	if err != nil {
+		http.Error(w, "Invalid revision ID", http.StatusBadRequest)
+		return
+	}
+	if err := h.service.Restore(r.Context(), uint(revisionID)); err != nil {
+		writeError(w, err)
+		return
+	}
 	w.WriteHeader(http.StatusOK)
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]string{"message": "Hero page updated"})
+	json.NewEncoder(w).Encode(map[string]string{"message": "Hero page restored"})
+}
+
+// writeError maps service errors to HTTP status codes
+func writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	default:
+		content.WriteError(w, err)
+	}
 }
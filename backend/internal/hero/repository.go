@@ -3,7 +3,9 @@ package hero
 import (
 	"context"
 	"errors"
+	"time"
 
+	"github.com/othersidedrl/portfolio/backend/internal/database"
 	"github.com/othersidedrl/portfolio/backend/internal/models"
 	"gorm.io/gorm"
 )
@@ -11,6 +13,9 @@ import (
 // HeroRepository defines the interface for data access
 type HeroRepository interface {
 	Find(ctx context.Context) (*HeroPageDto, error)
+	FindPublic(ctx context.Context) (*HeroPageDto, error)
+	GetSchedule(ctx context.Context) (*models.Schedule, error)
+	SetSchedule(ctx context.Context, schedule models.Schedule) error
 	Update(ctx context.Context, data *HeroPageDto) error
 }
 
@@ -26,8 +31,17 @@ func NewGormHeroRepository(db *gorm.DB) *GormHeroRepository {
 
 // Find retrieves the hero page from the database (assumes single row)
 func (r *GormHeroRepository) Find(ctx context.Context) (*HeroPageDto, error) {
+	return r.findHeroPage(ctx)
+}
+
+// FindPublic retrieves the hero page unless its unpublish time has passed
+func (r *GormHeroRepository) FindPublic(ctx context.Context) (*HeroPageDto, error) {
+	return r.findHeroPage(ctx, models.NotUnpublished(time.Now()))
+}
+
+func (r *GormHeroRepository) findHeroPage(ctx context.Context, scopes ...func(*gorm.DB) *gorm.DB) (*HeroPageDto, error) {
 	var hero models.HeroPage
-	if err := r.db.WithContext(ctx).First(&hero).Error; err != nil {
+	if err := database.Conn(ctx, r.db).Scopes(scopes...).First(&hero).Error; err != nil {
 		return nil, err
 	}
 
@@ -45,10 +59,28 @@ func (r *GormHeroRepository) Find(ctx context.Context) (*HeroPageDto, error) {
 	return &dto, nil
 }
 
+func (r *GormHeroRepository) GetSchedule(ctx context.Context) (*models.Schedule, error) {
+	var page models.HeroPage
+	if err := database.Conn(ctx, r.db).First(&page).Error; err != nil {
+		return nil, err
+	}
+	return &page.Schedule, nil
+}
+
+func (r *GormHeroRepository) SetSchedule(ctx context.Context, schedule models.Schedule) error {
+	var page models.HeroPage
+	if err := database.Conn(ctx, r.db).First(&page).Error; err != nil {
+		return err
+	}
+	return database.Conn(ctx, r.db).Model(&page).
+		Select("publish_at", "unpublish_at").
+		Updates(&models.HeroPage{Schedule: schedule}).Error
+}
+
 // Update modifies the hero page
 func (r *GormHeroRepository) Update(ctx context.Context, data *HeroPageDto) error {
 	var existing models.HeroPage
-	err := r.db.WithContext(ctx).First(&existing).Error
+	err := database.Conn(ctx, r.db).First(&existing).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			newHero := &models.HeroPage{
@@ -61,7 +93,7 @@ func (r *GormHeroRepository) Update(ctx context.Context, data *HeroPageDto) erro
 				ImageURLs:   data.ImageUrls,
 				Hobbies:     data.Hobbies,
 			}
-			return r.db.WithContext(ctx).Create(newHero).Error
+			return database.Conn(ctx, r.db).Create(newHero).Error
 		}
 		return err
 	}
@@ -75,5 +107,5 @@ func (r *GormHeroRepository) Update(ctx context.Context, data *HeroPageDto) erro
 	existing.ImageURLs = data.ImageUrls
 	existing.Hobbies = data.Hobbies
 
-	return r.db.WithContext(ctx).Save(&existing).Error
+	return database.Conn(ctx, r.db).Save(&existing).Error
 }
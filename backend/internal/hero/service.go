@@ -2,15 +2,29 @@ package hero
 
 import (
 	"context"
+	"errors"
+	"time"
+
+	"github.com/othersidedrl/portfolio/backend/internal/audit"
+	"github.com/othersidedrl/portfolio/backend/internal/content"
+	"github.com/othersidedrl/portfolio/backend/internal/models"
+	"github.com/othersidedrl/portfolio/backend/internal/translation"
+	"gorm.io/gorm"
 )
 
 type Service struct {
-	repo HeroRepository
+	repo         HeroRepository
+	versions     *content.Service
+	translations *translation.Service
+	recorder     audit.Recorder
 }
 
-func NewService(repo HeroRepository) *Service {
+func NewService(repo HeroRepository, versions *content.Service, translations *translation.Service, recorder audit.Recorder) *Service {
 	return &Service{
-		repo: repo,
+		repo:         repo,
+		versions:     versions,
+		translations: translations,
+		recorder:     recorder,
 	}
 }
 
@@ -19,6 +33,159 @@ func (s *Service) Find(ctx context.Context) (*HeroPageDto, error) {
 	return s.repo.Find(ctx)
 }
 
-func (s *Service) Update(ctx context.Context, data HeroPageDto) error {
-	return s.repo.Update(ctx, &data)
+// FindPublic returns the hero page for the public site
+func (s *Service) FindPublic(ctx context.Context) (*HeroPageDto, error) {
+	hero, err := s.repo.FindPublic(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.translations.Translate(ctx, models.EntityHeroPage, 0, heroFields(hero)); err != nil {
+		return nil, err
+	}
+	return hero, nil
+}
+
+// TranslatableFields lists the hero page text that can be translated
+func (s *Service) TranslatableFields(ctx context.Context) ([]translation.SourceField, error) {
+	hero, err := s.repo.Find(ctx)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return translation.SourceFields(models.EntityHeroPage, 0, heroFields(hero)), nil
+}
+
+func heroFields(hero *HeroPageDto) translation.Fields {
+	return translation.Fields{"title": &hero.Title, "subtitle": &hero.Subtitle}
+}
+
+func (s *Service) GetSchedule(ctx context.Context) (*content.ScheduleDto, error) {
+	schedule, err := s.repo.GetSchedule(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return content.ToScheduleDto(*schedule), nil
+}
+
+// SetSchedule sets when the hero page draft goes live and when the page goes offline
+func (s *Service) SetSchedule(ctx context.Context, data content.ScheduleDto) error {
+	schedule, err := content.ToSchedule(data)
+	if err != nil {
+		return err
+	}
+
+	before, err := s.GetSchedule(ctx)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.SetSchedule(ctx, schedule); err != nil {
+		return err
+	}
+
+	s.recorder.Record(ctx, models.AuditSchedule, models.EntityHeroPage, nil, before, data)
+	return nil
+}
+
+// RunSchedule publishes the hero page draft once its publish time has come. It
+// reports whether the public page changed since the previous run.
+func (s *Service) RunSchedule(ctx context.Context, since, now time.Time) (bool, error) {
+	schedule, err := s.repo.GetSchedule(ctx)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	changed := schedule.UnpublishedBetween(since, now)
+	if schedule.PublishDue(now) {
+		if err := s.Publish(ctx); err != nil && !errors.Is(err, content.ErrNoDraft) {
+			return false, err
+		}
+		schedule.PublishAt = nil
+		if err := s.repo.SetSchedule(ctx, *schedule); err != nil {
+			return false, err
+		}
+		changed = true
+	}
+	return changed, nil
+}
+
+// SaveDraft stores an edit of the hero page without changing the live page
+func (s *Service) SaveDraft(ctx context.Context, data HeroPageDto) error {
+	return s.versions.SaveDraft(ctx, models.EntityHeroPage, 0, data)
+}
+
+// Preview returns the draft of the hero page, or the live page when there is no draft
+func (s *Service) Preview(ctx context.Context) (*HeroPageDto, error) {
+	var draft HeroPageDto
+	found, err := s.versions.LoadDraft(ctx, models.EntityHeroPage, 0, &draft)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return s.repo.Find(ctx)
+	}
+	return &draft, nil
+}
+
+// Publish makes the draft the live hero page
+func (s *Service) Publish(ctx context.Context) error {
+	var draft HeroPageDto
+	found, err := s.versions.LoadDraft(ctx, models.EntityHeroPage, 0, &draft)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return content.ErrNoDraft
+	}
+
+	if err := s.apply(ctx, &draft, models.AuditPublish); err != nil {
+		return err
+	}
+	return s.versions.DeleteDraft(ctx, models.EntityHeroPage, 0)
+}
+
+// Restore rolls the live hero page back to one of its revisions
+func (s *Service) Restore(ctx context.Context, revisionID uint) error {
+	var revision HeroPageDto
+	if err := s.versions.LoadRevision(ctx, models.EntityHeroPage, 0, revisionID, &revision); err != nil {
+		return err
+	}
+	return s.apply(ctx, &revision, models.AuditRestore)
+}
+
+// Import writes imported content straight to the live hero page. A pending
+// draft is kept and still replaces the page when it is published.
+func (s *Service) Import(ctx context.Context, data HeroPageDto) error {
+	return s.apply(ctx, &data, models.AuditImport)
+}
+
+// apply writes data to the live hero page and keeps a revision of the result
+func (s *Service) apply(ctx context.Context, data *HeroPageDto, action models.AuditAction) error {
+	before, err := s.repo.Find(ctx)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	if err := s.repo.Update(ctx, data); err != nil {
+		return err
+	}
+
+	after, err := s.repo.Find(ctx)
+	if err != nil {
+		return err
+	}
+	if err := s.versions.SaveRevision(ctx, models.EntityHeroPage, 0, before, after); err != nil {
+		return err
+	}
+	s.recorder.Record(ctx, action, models.EntityHeroPage, nil, before, after)
+	return nil
+}
+
+// DiscardDraft throws away the unpublished changes to the hero page
+func (s *Service) DiscardDraft(ctx context.Context) error {
+	return s.versions.DiscardDraft(ctx, models.EntityHeroPage, 0)
 }
@@ -0,0 +1,27 @@
+package mailer
+
+import (
+	"errors"
+	"fmt"
+	"os"
+)
+
+// ErrLogMailerInProduction stops the API from printing password reset links
+// to the production logs for lack of a mail server
+var ErrLogMailerInProduction = errors.New("MAILER=smtp is required when ENV=production")
+
+// New picks the transport from MAILER ("smtp" or "log", default "log").
+// The log mailer is refused when ENV=production.
+func New() (Mailer, error) {
+	switch os.Getenv("MAILER") {
+	case "", "log":
+		if os.Getenv("ENV") == "production" {
+			return nil, ErrLogMailerInProduction
+		}
+		return NewLogMailer(), nil
+	case "smtp":
+		return NewSMTPMailer()
+	default:
+		return nil, fmt.Errorf("unknown MAILER %q", os.Getenv("MAILER"))
+	}
+}
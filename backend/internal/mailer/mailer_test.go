@@ -0,0 +1,41 @@
+package mailer
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     string
+		mailer  string
+		wantErr error
+	}{
+		{"log in development", "", "", nil},
+		{"explicit log in development", "development", "log", nil},
+		{"default in production", "production", "", ErrLogMailerInProduction},
+		{"explicit log in production", "production", "log", ErrLogMailerInProduction},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("ENV", tt.env)
+			t.Setenv("MAILER", tt.mailer)
+			if _, err := New(); !errors.Is(err, tt.wantErr) {
+				t.Errorf("err = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewSMTPInProduction(t *testing.T) {
+	t.Setenv("ENV", "production")
+	t.Setenv("MAILER", "smtp")
+	t.Setenv("SMTP_HOST", "mail.example.com")
+	t.Setenv("MAIL_FROM", "cms@example.com")
+
+	if _, err := New(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,105 @@
+package markdown
+
+import (
+	"bytes"
+	"regexp"
+
+	"github.com/alecthomas/chroma/v2/formatters/html"
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	highlighting "github.com/yuin/goldmark-highlighting/v2"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/parser"
+	goldmarkhtml "github.com/yuin/goldmark/renderer/html"
+	"github.com/yuin/goldmark/text"
+)
+
+// codeStyle is the chroma style used for fenced code blocks. Colours are
+// inlined so the public site needs no extra stylesheet.
+const codeStyle = "github"
+
+// embedSources are the only iframes allowed through, for videos and code playgrounds
+var embedSources = regexp.MustCompile(`^https://(www\.youtube(-nocookie)?\.com/embed/|player\.vimeo\.com/video/|codepen\.io/|codesandbox\.io/embed/|stackblitz\.com/)`)
+
+var (
+	md = goldmark.New(
+		goldmark.WithExtensions(
+			extension.GFM,
+			highlighting.NewHighlighting(
+				highlighting.WithStyle(codeStyle),
+				highlighting.WithFormatOptions(html.WithClasses(false)),
+			),
+		),
+		goldmark.WithParserOptions(parser.WithAutoHeadingID()),
+		// Raw HTML is let through so embeds work; the policy below cleans it
+		goldmark.WithRendererOptions(goldmarkhtml.WithUnsafe()),
+	)
+	policy = newPolicy()
+)
+
+// Heading is an entry in a document's table of contents
+type Heading struct {
+	Level int    `json:"level"`
+	ID    string `json:"id"`
+	Text  string `json:"text"`
+}
+
+// Document is Markdown rendered to sanitized HTML
+type Document struct {
+	HTML string    `json:"html"`
+	TOC  []Heading `json:"toc"`
+}
+
+// Render turns Markdown into sanitized HTML with highlighted code blocks
+// and lists its headings, which get anchor IDs, as a table of contents
+func Render(source string) (*Document, error) {
+	src := []byte(source)
+	doc := md.Parser().Parse(text.NewReader(src))
+
+	var buf bytes.Buffer
+	if err := md.Renderer().Render(&buf, src, doc); err != nil {
+		return nil, err
+	}
+
+	return &Document{
+		HTML: policy.Sanitize(buf.String()),
+		TOC:  tableOfContents(doc, src),
+	}, nil
+}
+
+func tableOfContents(doc ast.Node, src []byte) []Heading {
+	toc := []Heading{}
+	ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		heading, ok := n.(*ast.Heading)
+		if !ok || !entering {
+			return ast.WalkContinue, nil
+		}
+
+		id, _ := heading.AttributeString("id")
+		idBytes, _ := id.([]byte)
+		toc = append(toc, Heading{
+			Level: heading.Level,
+			ID:    string(idBytes),
+			Text:  string(heading.Text(src)),
+		})
+		return ast.WalkSkipChildren, nil
+	})
+	return toc
+}
+
+func newPolicy() *bluemonday.Policy {
+	p := bluemonday.UGCPolicy()
+
+	// Highlighted code comes with inline colours
+	p.AllowStyles("color", "background-color", "font-weight", "font-style", "text-decoration").
+		OnElements("pre", "span")
+
+	// Embeds from known providers
+	p.AllowElements("iframe")
+	p.AllowAttrs("src").Matching(embedSources).OnElements("iframe")
+	p.AllowAttrs("width", "height").Matching(bluemonday.NumberOrPercent).OnElements("iframe")
+	p.AllowAttrs("title").OnElements("iframe")
+	p.AllowAttrs("allowfullscreen").Matching(regexp.MustCompile(`^(|allowfullscreen|true)$`)).OnElements("iframe")
+	return p
+}
@@ -0,0 +1,96 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderSanitizes(t *testing.T) {
+	tests := []struct {
+		name    string
+		source  string
+		absent  []string
+		present []string
+	}{
+		{
+			name:    "script tag",
+			source:  "Hello <script>alert(1)</script> world",
+			absent:  []string{"<script", "alert(1)"},
+			present: []string{"Hello", "world"},
+		},
+		{
+			name:    "javascript link",
+			source:  "[click](javascript:alert(1))",
+			absent:  []string{"javascript:"},
+			present: []string{"click"},
+		},
+		{
+			name:   "javascript link in raw HTML",
+			source: `<a href="javascript:alert(1)">click</a>`,
+			absent: []string{"javascript:"},
+		},
+		{
+			name:   "event handler",
+			source: `<img src="https://example.com/a.png" onerror="alert(1)">`,
+			absent: []string{"onerror"},
+		},
+		{
+			name:   "iframe on a host that is not allowed",
+			source: `<iframe src="https://evil.example.com/embed"></iframe>`,
+			absent: []string{"<iframe", "evil.example.com"},
+		},
+		{
+			name:    "iframe on an allowed host",
+			source:  `<iframe src="https://www.youtube-nocookie.com/embed/abc" width="560" height="315" allowfullscreen></iframe>`,
+			present: []string{`src="https://www.youtube-nocookie.com/embed/abc"`, `width="560"`},
+		},
+		{
+			name:    "highlighted code keeps its colours",
+			source:  "```go\nfunc main() {}\n```",
+			present: []string{"<pre", "style="},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := Render(tt.source)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, s := range tt.absent {
+				if strings.Contains(doc.HTML, s) {
+					t.Errorf("HTML contains %q:\n%s", s, doc.HTML)
+				}
+			}
+			for _, s := range tt.present {
+				if !strings.Contains(doc.HTML, s) {
+					t.Errorf("HTML is missing %q:\n%s", s, doc.HTML)
+				}
+			}
+		})
+	}
+}
+
+func TestRenderTableOfContents(t *testing.T) {
+	doc, err := Render("# Overview\n\ntext\n\n## The Stack\n\n### Go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Heading{
+		{Level: 1, ID: "overview", Text: "Overview"},
+		{Level: 2, ID: "the-stack", Text: "The Stack"},
+		{Level: 3, ID: "go", Text: "Go"},
+	}
+	if len(doc.TOC) != len(want) {
+		t.Fatalf("toc = %+v, want %+v", doc.TOC, want)
+	}
+	for i := range want {
+		if doc.TOC[i] != want[i] {
+			t.Errorf("toc[%d] = %+v, want %+v", i, doc.TOC[i], want[i])
+		}
+	}
+	if !strings.Contains(doc.HTML, `id="the-stack"`) {
+		t.Errorf("heading anchor missing:\n%s", doc.HTML)
+	}
+}
@@ -12,23 +12,65 @@ type contextKey string
 
 const userContextKey = contextKey("user")
 
-// AuthGuard checks for a valid JWT in the Authorization header
-func AuthGuard(jwt *utils.JWTService) func(http.Handler) http.Handler {
+// RevocationChecker reports whether a verified token has been revoked and
+// keeps the last-seen time of its session current
+type RevocationChecker interface {
+	IsRevoked(ctx context.Context, claims *utils.JWTClaims) (bool, error)
+	TouchSession(ctx context.Context, claims *utils.JWTClaims, ip string)
+}
+
+// APIKeyAuthenticator resolves an X-API-Key header into scoped claims
+type APIKeyAuthenticator interface {
+	Authenticate(ctx context.Context, key string) (*utils.JWTClaims, error)
+}
+
+// AuthGuard accepts a valid, non-revoked JWT from the Authorization header or
+// the session cookie, or an API key in the X-API-Key header
+func AuthGuard(jwt *utils.JWTService, revocations RevocationChecker, apiKeys APIKeyAuthenticator) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			authHeader := r.Header.Get("Authorization")
-			if authHeader == "" || !strings.HasPrefix(authHeader, "Bearer ") {
+			if key := r.Header.Get("X-API-Key"); key != "" {
+				claims, err := apiKeys.Authenticate(r.Context(), key)
+				if err != nil {
+					http.Error(w, "Unauthorized: invalid API key", http.StatusUnauthorized)
+					return
+				}
+
+				ctx := context.WithValue(r.Context(), userContextKey, claims)
+				next.ServeHTTP(w, r.WithContext(ctx))
+				return
+			}
+
+			token, fromCookie := bearerToken(r)
+			if token == "" {
 				http.Error(w, "Unauthorized: missing or malformed token", http.StatusUnauthorized)
 				return
 			}
 
-			token := strings.TrimPrefix(authHeader, "Bearer ")
+			// Cookies are sent automatically by the browser, so mutating
+			// requests must prove they can read the CSRF cookie
+			if fromCookie && !isSafeMethod(r.Method) && !ValidCSRF(r) {
+				http.Error(w, "Forbidden: invalid CSRF token", http.StatusForbidden)
+				return
+			}
+
 			claims, err := jwt.VerifyToken(token)
 			if err != nil {
 				http.Error(w, "Unauthorized: invalid token", http.StatusUnauthorized)
 				return
 			}
 
+			revoked, err := revocations.IsRevoked(r.Context(), claims)
+			if err != nil {
+				http.Error(w, "Failed to validate token", http.StatusInternalServerError)
+				return
+			}
+			if revoked {
+				http.Error(w, "Unauthorized: token revoked", http.StatusUnauthorized)
+				return
+			}
+			revocations.TouchSession(r.Context(), claims, GetClientIP(r))
+
 			// Store claims in context so handlers can access it
 			ctx := context.WithValue(r.Context(), userContextKey, claims)
 			next.ServeHTTP(w, r.WithContext(ctx))
@@ -36,6 +78,21 @@ func AuthGuard(jwt *utils.JWTService) func(http.Handler) http.Handler {
 	}
 }
 
+// bearerToken returns the access token and whether it came from the session cookie
+func bearerToken(r *http.Request) (string, bool) {
+	if authHeader := r.Header.Get("Authorization"); authHeader != "" {
+		if !strings.HasPrefix(authHeader, "Bearer ") {
+			return "", false
+		}
+		return strings.TrimPrefix(authHeader, "Bearer "), false
+	}
+
+	if cookie, err := r.Cookie(SessionCookieName); err == nil {
+		return cookie.Value, true
+	}
+	return "", false
+}
+
 // GetUserFromContext retrieves the JWT claims from the request context
 func GetUserFromContext(ctx context.Context) *utils.JWTClaims {
 	claims, ok := ctx.Value(userContextKey).(*utils.JWTClaims)
@@ -3,14 +3,61 @@ package middleware
 import (
 	"fmt"
 	"net/http"
+	"net/url"
 	"time"
 
+	"github.com/go-chi/chi/v5"
+	"github.com/othersidedrl/portfolio/backend/internal/utils"
 	"github.com/redis/go-redis/v9"
 )
 
+// RedisCache serves the response from Redis when it is cached. Responses
+// are cached per locale on the routes that negotiate one.
 func RedisCache(client *redis.Client, key string, ttl time.Duration, handler http.HandlerFunc) http.HandlerFunc {
+	return redisCache(client, func(*http.Request) string { return key }, ttl, handler)
+}
+
+// RedisCacheParam caches a response per value of a URL parameter, under
+// "key:value". Dropping key drops every value.
+func RedisCacheParam(client *redis.Client, key, param string, ttl time.Duration, handler http.HandlerFunc) http.HandlerFunc {
+	return redisCache(client, func(r *http.Request) string {
+		return key + ":" + chi.URLParam(r, param)
+	}, ttl, handler)
+}
+
+// RedisCacheQuery caches a response per combination of the given query
+// parameters, under "key:<hash>". Other parameters do not split the cache,
+// and dropping key drops every combination.
+func RedisCacheQuery(client *redis.Client, key string, params []string, ttl time.Duration, handler http.HandlerFunc) http.HandlerFunc {
+	return RedisCacheNormalizedQuery(client, key, params, func(r *http.Request) url.Values {
+		return r.URL.Query()
+	}, ttl, handler)
+}
+
+// RedisCacheNormalizedQuery is RedisCacheQuery on the query normalize
+// returns, so requests asking for the same thing in different words share
+// an entry
+func RedisCacheNormalizedQuery(client *redis.Client, key string, params []string, normalize func(*http.Request) url.Values, ttl time.Duration, handler http.HandlerFunc) http.HandlerFunc {
+	return redisCache(client, func(r *http.Request) string {
+		query := normalize(r)
+		values := url.Values{}
+		for _, param := range params {
+			if v, ok := query[param]; ok {
+				values[param] = v
+			}
+		}
+		if len(values) == 0 {
+			return key
+		}
+		// Encode sorts by parameter name, so equal queries share an entry
+		return key + ":" + utils.HashToken(values.Encode())[:16]
+	}, ttl, handler)
+}
+
+func redisCache(client *redis.Client, keyFor func(*http.Request) string, ttl time.Duration, handler http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
+		key := utils.LocaleCacheKey(keyFor(r), GetLocaleFromContext(ctx))
 
 		// Try to get cached response
 		cached, err := client.Get(ctx, key).Result()
@@ -33,6 +80,11 @@ func RedisCache(client *redis.Client, key string, ttl time.Duration, handler htt
 }
 
 func RemoveCache(client *redis.Client, key string, handler http.HandlerFunc) http.HandlerFunc {
+	return RemoveCaches(client, []string{key}, handler)
+}
+
+// RemoveCaches drops several cache keys, in every locale, after a successful write
+func RemoveCaches(client *redis.Client, keys []string, handler http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		rec := NewResponseRecorder(w)
 		handler(rec, r)
@@ -40,12 +92,25 @@ func RemoveCache(client *redis.Client, key string, handler http.HandlerFunc) htt
 		// Only refresh if success
 		if rec.StatusCode >= 200 && rec.StatusCode < 300 {
 			// Refresh the cache using the captured body
-			err := client.Del(r.Context(), key).Err()
+			err := utils.DeleteCache(r.Context(), client, keys...)
 			if err != nil {
 				fmt.Println("⚠️ Failed to refresh cache:", err)
 			} else {
-				fmt.Println("✅ Refreshed cache key:", key)
+				fmt.Println("✅ Refreshed cache keys:", keys)
 			}
 		}
 	}
 }
+
+// RemoveCachesUnless is RemoveCaches for handlers that only sometimes
+// write, such as dry runs. Requests matching skip leave the cache alone.
+func RemoveCachesUnless(client *redis.Client, keys []string, skip func(r *http.Request) bool, handler http.HandlerFunc) http.HandlerFunc {
+	remove := RemoveCaches(client, keys, handler)
+	return func(w http.ResponseWriter, r *http.Request) {
+		if skip(r) {
+			handler(w, r)
+			return
+		}
+		remove(w, r)
+	}
+}
@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+)
+
+// Cookie names used by the cookie-based CMS session
+const (
+	SessionCookieName = "portfolio_session"
+	RefreshCookieName = "portfolio_refresh"
+	CSRFCookieName    = "portfolio_csrf"
+	CSRFHeaderName    = "X-CSRF-Token"
+)
+
+// isSafeMethod reports whether the method can't change state
+func isSafeMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	return false
+}
+
+// ValidCSRF implements the double-submit check: the X-CSRF-Token header must
+// match the CSRF cookie, which only our own origin can read
+func ValidCSRF(r *http.Request) bool {
+	cookie, err := r.Cookie(CSRFCookieName)
+	if err != nil || cookie.Value == "" {
+		return false
+	}
+	header := r.Header.Get(CSRFHeaderName)
+	if header == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(cookie.Value), []byte(header)) == 1
+}
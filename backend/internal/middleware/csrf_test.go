@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/othersidedrl/portfolio/backend/internal/utils"
+)
+
+type nopRevocations struct{}
+
+func (nopRevocations) IsRevoked(ctx context.Context, claims *utils.JWTClaims) (bool, error) {
+	return false, nil
+}
+
+func (nopRevocations) TouchSession(ctx context.Context, claims *utils.JWTClaims, ip string) {}
+
+func TestAuthGuardCSRF(t *testing.T) {
+	t.Setenv("JWT_KEYS_DIR", "")
+	t.Setenv("JWT_SECRET", "test-secret")
+	jwt, err := utils.NewJWTService()
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, err := jwt.GenerateToken("1", "owner", "session")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		method string
+		bearer bool
+		cookie string
+		header string
+		want   int
+	}{
+		{"cookie read needs no token", http.MethodGet, false, "", "", http.StatusOK},
+		{"cookie write with matching token", http.MethodPost, false, "csrf", "csrf", http.StatusOK},
+		{"cookie write without header", http.MethodPost, false, "csrf", "", http.StatusForbidden},
+		{"cookie write without cookie", http.MethodDelete, false, "", "csrf", http.StatusForbidden},
+		{"cookie write with wrong token", http.MethodPatch, false, "csrf", "other", http.StatusForbidden},
+		{"bearer write needs no token", http.MethodPost, true, "", "", http.StatusOK},
+	}
+
+	guard := AuthGuard(jwt, nopRevocations{}, nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(tt.method, "/api/v1/admin/hero", nil)
+			if tt.bearer {
+				r.Header.Set("Authorization", "Bearer "+token)
+			} else {
+				r.AddCookie(&http.Cookie{Name: SessionCookieName, Value: token})
+			}
+			if tt.cookie != "" {
+				r.AddCookie(&http.Cookie{Name: CSRFCookieName, Value: tt.cookie})
+			}
+			if tt.header != "" {
+				r.Header.Set(CSRFHeaderName, tt.header)
+			}
+
+			w := httptest.NewRecorder()
+			guard.ServeHTTP(w, r)
+			if w.Code != tt.want {
+				t.Errorf("status = %d, want %d", w.Code, tt.want)
+			}
+		})
+	}
+}
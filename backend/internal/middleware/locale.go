@@ -0,0 +1,139 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const localeContextKey = contextKey("locale")
+
+// LocaleConfig lists the locales the public site is served in. Default is
+// the language the content is written in and the fallback for missing
+// translations.
+type LocaleConfig struct {
+	Default   string
+	Supported []string
+}
+
+// LocaleConfigFromEnv reads DEFAULT_LOCALE (default "en") and the
+// comma-separated SUPPORTED_LOCALES, which always include the default
+func LocaleConfigFromEnv() LocaleConfig {
+	config := LocaleConfig{Default: normalizeLocale(os.Getenv("DEFAULT_LOCALE"))}
+	if config.Default == "" {
+		config.Default = "en"
+	}
+
+	config.Supported = []string{config.Default}
+	for _, locale := range strings.Split(os.Getenv("SUPPORTED_LOCALES"), ",") {
+		locale = normalizeLocale(locale)
+		if locale != "" && !config.IsSupported(locale) {
+			config.Supported = append(config.Supported, locale)
+		}
+	}
+	return config
+}
+
+// IsSupported reports whether the locale is one of the supported locales
+func (c LocaleConfig) IsSupported(locale string) bool {
+	for _, supported := range c.Supported {
+		if supported == locale {
+			return true
+		}
+	}
+	return false
+}
+
+// Match returns the supported locale for a requested one, trying the base
+// language when the region is not supported ("pt-br" matches "pt")
+func (c LocaleConfig) Match(requested string) (string, bool) {
+	requested = normalizeLocale(requested)
+	if c.IsSupported(requested) {
+		return requested, true
+	}
+	if base, _, found := strings.Cut(requested, "-"); found && c.IsSupported(base) {
+		return base, true
+	}
+	return "", false
+}
+
+// Locale picks the response locale from ?lang= or else the Accept-Language
+// header, falling back to the default locale
+func Locale(config LocaleConfig) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			locale := negotiateLocale(config, r)
+
+			w.Header().Set("Content-Language", locale)
+			w.Header().Add("Vary", "Accept-Language")
+
+			ctx := context.WithValue(r.Context(), localeContextKey, locale)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// GetLocaleFromContext returns the negotiated locale, or "" outside the public routes
+func GetLocaleFromContext(ctx context.Context) string {
+	locale, _ := ctx.Value(localeContextKey).(string)
+	return locale
+}
+
+func negotiateLocale(config LocaleConfig, r *http.Request) string {
+	if lang := r.URL.Query().Get("lang"); lang != "" {
+		if locale, ok := config.Match(lang); ok {
+			return locale
+		}
+	}
+
+	for _, lang := range parseAcceptLanguage(r.Header.Get("Accept-Language")) {
+		if locale, ok := config.Match(lang); ok {
+			return locale
+		}
+	}
+	return config.Default
+}
+
+// parseAcceptLanguage returns the languages of an Accept-Language header,
+// highest quality first
+func parseAcceptLanguage(header string) []string {
+	type weighted struct {
+		lang    string
+		quality float64
+	}
+
+	var langs []weighted
+	for _, part := range strings.Split(header, ",") {
+		lang, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if lang == "" || lang == "*" {
+			continue
+		}
+
+		quality := 1.0
+		if q, found := strings.CutPrefix(strings.TrimSpace(params), "q="); found {
+			value, err := strconv.ParseFloat(q, 64)
+			if err != nil || value <= 0 {
+				continue
+			}
+			quality = value
+		}
+		langs = append(langs, weighted{lang, quality})
+	}
+
+	sort.SliceStable(langs, func(i, j int) bool {
+		return langs[i].quality > langs[j].quality
+	})
+
+	result := make([]string, len(langs))
+	for i, l := range langs {
+		result[i] = l.lang
+	}
+	return result
+}
+
+func normalizeLocale(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+}
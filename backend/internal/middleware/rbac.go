@@ -0,0 +1,73 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/othersidedrl/portfolio/backend/internal/models"
+)
+
+// RequirePermission only lets through users whose role grants the permission,
+// or API keys that were given it as a scope. It must run after AuthGuard.
+func RequirePermission(perm models.Permission) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			claims := GetUserFromContext(r.Context())
+			if claims == nil {
+				http.Error(w, "Unauthorized", http.StatusUnauthorized)
+				return
+			}
+
+			allowed := false
+			if claims.IsAPIKey() {
+				for _, scope := range claims.Scopes {
+					if models.Permission(scope) == perm {
+						allowed = true
+						break
+					}
+				}
+			} else {
+				allowed = models.UserRole(claims.Role).Can(perm)
+			}
+
+			if !allowed {
+				http.Error(w, "Forbidden: missing permission "+string(perm), http.StatusForbidden)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RequirePermissionToRead applies RequirePermission to GET and HEAD requests
+// only, for route groups whose writes check their own permissions
+func RequirePermissionToRead(perm models.Permission) func(http.Handler) http.Handler {
+	require := RequirePermission(perm)
+	return func(next http.Handler) http.Handler {
+		guarded := require(next)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if isSafeMethod(r.Method) {
+				guarded.ServeHTTP(w, r)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RequireUser rejects API keys on routes that act on a user account.
+// It must run after AuthGuard.
+func RequireUser(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		claims := GetUserFromContext(r.Context())
+		if claims == nil {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		if claims.IsAPIKey() {
+			http.Error(w, "Forbidden: this endpoint requires a user login", http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/othersidedrl/portfolio/backend/internal/models"
+	"github.com/othersidedrl/portfolio/backend/internal/utils"
+)
+
+func TestRequirePermissionToRead(t *testing.T) {
+	apiKey := func(scopes ...string) *utils.JWTClaims {
+		return &utils.JWTClaims{Sub: utils.APIKeySubjectPrefix + "1", Scopes: scopes}
+	}
+	user := func(role models.UserRole) *utils.JWTClaims {
+		return &utils.JWTClaims{Sub: "1", Role: string(role)}
+	}
+
+	tests := []struct {
+		name   string
+		method string
+		claims *utils.JWTClaims
+		want   int
+	}{
+		{"editor reads", http.MethodGet, user(models.Editor), http.StatusOK},
+		{"moderator reads", http.MethodGet, user(models.Moderator), http.StatusOK},
+		{"scoped key reads", http.MethodGet, apiKey(string(models.PermContentRead)), http.StatusOK},
+		{"write-only key cannot read", http.MethodGet, apiKey(string(models.PermHeroWrite)), http.StatusForbidden},
+		{"writes are left to their route", http.MethodPatch, apiKey(string(models.PermHeroWrite)), http.StatusOK},
+		{"anonymous read", http.MethodGet, nil, http.StatusUnauthorized},
+	}
+
+	handler := RequirePermissionToRead(models.PermContentRead)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(tt.method, "/api/v1/admin/hero", nil)
+			if tt.claims != nil {
+				r = r.WithContext(context.WithValue(r.Context(), userContextKey, tt.claims))
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+			if w.Code != tt.want {
+				t.Errorf("status = %d, want %d", w.Code, tt.want)
+			}
+		})
+	}
+}
@@ -1,7 +1,12 @@
 package middleware
 
 import (
+	"context"
+	"log"
+	"net"
 	"net/http"
+	"net/netip"
+	"os"
 	"strings"
 	"sync"
 	"time"
@@ -57,10 +62,11 @@ func RequestSizeLimit(maxSize int64) func(http.Handler) http.Handler {
 	}
 }
 
-// ValidateContentType ensures proper content type for POST/PUT requests
+// ValidateContentType ensures proper content type for POST/PUT requests that carry a body
 func ValidateContentType(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if r.Method == "POST" || r.Method == "PUT" || r.Method == "PATCH" {
+		hasBody := r.ContentLength != 0
+		if hasBody && (r.Method == "POST" || r.Method == "PUT" || r.Method == "PATCH") {
 			contentType := r.Header.Get("Content-Type")
 			if contentType == "" {
 				http.Error(w, "Content-Type header required", http.StatusBadRequest)
@@ -121,7 +127,7 @@ func (rl *RateLimiter) getLimiter(ip string) *rate.Limiter {
 
 func (rl *RateLimiter) Handler(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		ip := getClientIP(r)
+		ip := GetClientIP(r)
 		limiter := rl.getLimiter(ip)
 
 		if !limiter.Allow() {
@@ -133,26 +139,108 @@ func (rl *RateLimiter) Handler(next http.Handler) http.Handler {
 	})
 }
 
-// getClientIP extracts the client IP from the request
-func getClientIP(r *http.Request) string {
-	// Check X-Forwarded-For header (proxy/load balancer)
-	if xff := r.Header.Get("X-Forwarded-For"); xff != "" {
-		ips := strings.Split(xff, ",")
-		if len(ips) > 0 {
-			return strings.TrimSpace(ips[0])
+// trustedProxies are the proxies allowed to report the client IP, read once
+// from the comma-separated TRUSTED_PROXIES (IPs or CIDRs). With none set the
+// forwarding headers are ignored.
+var trustedProxies = sync.OnceValue(func() []netip.Prefix {
+	return parseTrustedProxies(os.Getenv("TRUSTED_PROXIES"))
+})
+
+func parseTrustedProxies(value string) []netip.Prefix {
+	var prefixes []netip.Prefix
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
 		}
+		if !strings.Contains(entry, "/") {
+			addr, err := netip.ParseAddr(entry)
+			if err != nil {
+				log.Printf("⚠️ Ignoring invalid TRUSTED_PROXIES entry %q", entry)
+				continue
+			}
+			prefixes = append(prefixes, netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()))
+			continue
+		}
+		prefix, err := netip.ParsePrefix(entry)
+		if err != nil {
+			log.Printf("⚠️ Ignoring invalid TRUSTED_PROXIES entry %q", entry)
+			continue
+		}
+		prefixes = append(prefixes, prefix.Masked())
+	}
+	return prefixes
+}
+
+// GetClientIP returns the IP the request came from. X-Forwarded-For and
+// X-Real-IP are only honoured when the connection comes from a trusted
+// proxy, and the client is the right-most X-Forwarded-For hop that is not
+// one of our proxies, since anything left of it can be forged.
+func GetClientIP(r *http.Request) string {
+	return clientIP(r, trustedProxies())
+}
+
+func clientIP(r *http.Request, trusted []netip.Prefix) string {
+	remote := r.RemoteAddr
+	if host, _, err := net.SplitHostPort(remote); err == nil {
+		remote = host
+	}
+	if !isTrustedProxy(remote, trusted) {
+		return remote
+	}
+
+	if xff := r.Header.Values("X-Forwarded-For"); len(xff) > 0 {
+		hops := strings.Split(strings.Join(xff, ","), ",")
+		client := remote
+		for i := len(hops) - 1; i >= 0; i-- {
+			hop := strings.TrimSpace(hops[i])
+			addr, err := netip.ParseAddr(hop)
+			if err != nil {
+				// A malformed hop ends the chain we can vouch for
+				break
+			}
+			client = addr.Unmap().String()
+			if !isTrustedProxy(client, trusted) {
+				break
+			}
+		}
+		return client
 	}
 
-	// Check X-Real-IP header (proxy)
-	if xri := r.Header.Get("X-Real-IP"); xri != "" {
-		return xri
+	if addr, err := netip.ParseAddr(strings.TrimSpace(r.Header.Get("X-Real-IP"))); err == nil {
+		return addr.Unmap().String()
 	}
+	return remote
+}
 
-	// Fallback to RemoteAddr
-	ip := r.RemoteAddr
-	if colon := strings.LastIndex(ip, ":"); colon != -1 {
-		ip = ip[:colon]
+func isTrustedProxy(ip string, trusted []netip.Prefix) bool {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
 	}
+	addr = addr.Unmap()
+	for _, prefix := range trusted {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+const clientIPContextKey = contextKey("client_ip")
+
+// ClientIPContext stores the client IP in the request context so services
+// (e.g. the audit log) can read it without access to the request
+func ClientIPContext(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), clientIPContextKey, GetClientIP(r))
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// GetClientIPFromContext returns the IP stored by ClientIPContext
+func GetClientIPFromContext(ctx context.Context) string {
+	ip, _ := ctx.Value(clientIPContextKey).(string)
 	return ip
 }
 
@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClientIP(t *testing.T) {
+	trusted := parseTrustedProxies("10.0.0.0/8, 192.168.1.5, not-an-ip")
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		xff        string
+		realIP     string
+		want       string
+	}{
+		{"direct connection", "203.0.113.7:5000", "", "", "203.0.113.7"},
+		{"untrusted peer cannot forge XFF", "203.0.113.7:5000", "1.2.3.4", "", "203.0.113.7"},
+		{"untrusted peer cannot forge X-Real-IP", "203.0.113.7:5000", "", "1.2.3.4", "203.0.113.7"},
+		{"trusted proxy", "10.0.0.2:5000", "198.51.100.9", "", "198.51.100.9"},
+		{"right-most untrusted hop", "10.0.0.2:5000", "1.2.3.4, 198.51.100.9, 10.0.0.3", "", "198.51.100.9"},
+		{"trusted single address", "192.168.1.5:5000", "198.51.100.9", "", "198.51.100.9"},
+		{"all hops trusted", "10.0.0.2:5000", "10.0.0.4, 10.0.0.3", "", "10.0.0.4"},
+		{"malformed hop", "10.0.0.2:5000", "198.51.100.9, garbage", "", "10.0.0.2"},
+		{"X-Real-IP from trusted proxy", "10.0.0.2:5000", "", "198.51.100.9", "198.51.100.9"},
+		{"IPv6 peer", "[2001:db8::1]:5000", "1.2.3.4", "", "2001:db8::1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/", nil)
+			r.RemoteAddr = tt.remoteAddr
+			if tt.xff != "" {
+				r.Header.Set("X-Forwarded-For", tt.xff)
+			}
+			if tt.realIP != "" {
+				r.Header.Set("X-Real-IP", tt.realIP)
+			}
+			if got := clientIP(r, trusted); got != tt.want {
+				t.Errorf("clientIP = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClientIPWithoutTrustedProxies(t *testing.T) {
+	r := httptest.NewRequest("GET", "/", nil)
+	r.RemoteAddr = "10.0.0.2:5000"
+	r.Header.Set("X-Forwarded-For", "1.2.3.4")
+
+	if got := clientIP(r, nil); got != "10.0.0.2" {
+		t.Errorf("clientIP = %q, want the peer address", got)
+	}
+}
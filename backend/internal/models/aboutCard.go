@@ -12,6 +12,7 @@ type AboutCard struct {
 	Title       string    `json:"title"`
 	Description string    `json:"description"`
 	AboutPageID uint      `json:"about_page_id"`
+	Position    int       `json:"position" gorm:"not null;default:0"`
 	UpdatedAt   time.Time `json:"updated_at"`
 	CreatedAt   time.Time `json:"created_at"`
 }
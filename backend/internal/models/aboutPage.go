@@ -8,6 +8,7 @@ import (
 
 type AboutPage struct {
 	gorm.Model
+	Schedule
 	ID           uint        `json:"id" gorm:"primaryKey"`
 	Description  string      `json:"description"`
 	Cards        []AboutCard `json:"cards" gorm:"foreignKey:AboutPageID"`
@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+)
+
+// APIKey is a long-lived credential for automation. Only the SHA-256 of the
+// key is stored; the plain key is shown once when it is created.
+type APIKey struct {
+	gorm.Model
+	ID          uint           `json:"id" gorm:"primaryKey"`
+	Name        string         `json:"name" gorm:"not null"`
+	Prefix      string         `json:"prefix" gorm:"not null"`
+	KeyHash     string         `json:"-" gorm:"uniqueIndex;not null"`
+	Scopes      pq.StringArray `json:"scopes" gorm:"type:text[]"`
+	ExpiresAt   *time.Time     `json:"expires_at"`
+	LastUsedAt  *time.Time     `json:"last_used_at"`
+	CreatedByID uint           `json:"created_by_id"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	CreatedAt   time.Time      `json:"created_at"`
+}
@@ -0,0 +1,38 @@
+package models
+
+import "time"
+
+type AuditAction string
+
+const (
+	AuditCreate    AuditAction = "create"
+	AuditUpdate    AuditAction = "update"
+	AuditDelete    AuditAction = "delete"
+	AuditApprove   AuditAction = "approve"
+	AuditUnapprove AuditAction = "unapprove"
+
+	AuditSaveDraft    AuditAction = "save_draft"
+	AuditDiscardDraft AuditAction = "discard_draft"
+	AuditPublish      AuditAction = "publish"
+	AuditUnpublish    AuditAction = "unpublish"
+	AuditRestore      AuditAction = "restore"
+	AuditSchedule     AuditAction = "schedule"
+	AuditUndelete     AuditAction = "undelete"
+	AuditReorder      AuditAction = "reorder"
+	AuditImport       AuditAction = "import"
+)
+
+// AuditLog is an append-only record of a single admin write.
+// Changes only holds the fields that differ between Before and After.
+type AuditLog struct {
+	ID         uint        `json:"id" gorm:"primaryKey"`
+	ActorID    string      `json:"actor_id" gorm:"index"`
+	Action     AuditAction `json:"action" gorm:"type:varchar(32);index"`
+	EntityType EntityType  `json:"entity_type" gorm:"type:varchar(32);index:idx_audit_entity"`
+	EntityID   string      `json:"entity_id" gorm:"index:idx_audit_entity"`
+	Before     JSONB       `json:"before" gorm:"type:jsonb"`
+	After      JSONB       `json:"after" gorm:"type:jsonb"`
+	Changes    JSONB       `json:"changes" gorm:"type:jsonb"`
+	IP         string      `json:"ip"`
+	CreatedAt  time.Time   `json:"created_at" gorm:"index"`
+}
@@ -10,6 +10,11 @@ import (
 
 type CareerType string
 
+// Valid reports whether the type is Education or Job
+func (ct CareerType) Valid() bool {
+	return ct == Education || ct == Job
+}
+
 const (
 	Education CareerType = "Education"
 	Job       CareerType = "Job"
@@ -30,16 +35,20 @@ func (ct CareerType) Value() (driver.Value, error) {
 	return string(ct), nil
 }
 
+// CareerJourney is an education or job entry. StartedAt and EndedAt are
+// the first day of their month; EndedAt is nil while the entry is ongoing.
 type CareerJourney struct {
 	gorm.Model
 	ID          uint       `json:"id" gorm:"primaryKey"`
-	StartedAt   string     `json:"startedAt"`
-	EndedAt     string     `json:"endedAt"`
+	StartedAt   *time.Time `json:"started_at" gorm:"type:date"`
+	EndedAt     *time.Time `json:"ended_at" gorm:"type:date"`
+	Ongoing     bool       `json:"ongoing" gorm:"not null;default:false"`
 	Title       string     `json:"title"`
 	Affiliation string     `json:"affiliation"`
 	Description string     `json:"description"`
 	Location    string     `json:"location"`
 	Type        CareerType `json:"type" gorm:"type:career_type"`
+	Search      string     `json:"-" gorm:"->:false;<-:false;type:tsvector;index:,type:gin"`
 	UpdatedAt   time.Time  `json:"updated_at"`
 	CreatedAt   time.Time  `json:"created_at"`
 }
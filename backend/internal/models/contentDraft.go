@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// ContentDraft holds an unpublished version of a page or project as JSON.
+// Single-row pages use EntityID 0.
+type ContentDraft struct {
+	ID          uint       `json:"id" gorm:"primaryKey"`
+	EntityType  EntityType `json:"entity_type" gorm:"type:varchar(32);uniqueIndex:idx_draft_entity"`
+	EntityID    uint       `json:"entity_id" gorm:"uniqueIndex:idx_draft_entity"`
+	Data        JSONB      `json:"data" gorm:"type:jsonb;not null"`
+	UpdatedByID string     `json:"updated_by_id"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
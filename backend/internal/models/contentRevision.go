@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// ContentRevision is a snapshot of a page or project as it was published.
+// Single-row pages use EntityID 0.
+type ContentRevision struct {
+	ID         uint       `json:"id" gorm:"primaryKey"`
+	EntityType EntityType `json:"entity_type" gorm:"type:varchar(32);index:idx_revision_entity"`
+	EntityID   uint       `json:"entity_id" gorm:"index:idx_revision_entity"`
+	Data       JSONB      `json:"data" gorm:"type:jsonb;not null"`
+	AuthorID   string     `json:"author_id"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
@@ -0,0 +1,21 @@
+package models
+
+// EntityType names a kind of content in the audit log, drafts, revisions
+// and translations
+type EntityType string
+
+const (
+	EntityHeroPage       EntityType = "hero_page"
+	EntityAboutPage      EntityType = "about_page"
+	EntityAboutCard      EntityType = "about_card"
+	EntityTechnicalSkill EntityType = "technical_skill"
+	EntityCareer         EntityType = "career"
+	EntityTestimonyPage  EntityType = "testimony_page"
+	EntityTestimony      EntityType = "testimony"
+	EntityProjectPage    EntityType = "project_page"
+	EntityProject        EntityType = "project"
+	EntityUser           EntityType = "user"
+	EntityAPIKey         EntityType = "api_key"
+	EntityTranslation    EntityType = "translation"
+	EntityTechnology     EntityType = "technology"
+)
@@ -9,6 +9,7 @@ import (
 
 type HeroPage struct {
 	gorm.Model
+	Schedule
 	ID          uint           `json:"id" gorm:"primaryKey"`
 	Name        string         `json:"name"`
 	Rank        string         `json:"rank"`
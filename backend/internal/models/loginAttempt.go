@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+type LoginResult string
+
+const (
+	LoginSucceeded          LoginResult = "success"
+	LoginInvalidCredentials LoginResult = "invalid_credentials"
+	LoginLocked             LoginResult = "locked"
+	LoginTwoFactorRequired  LoginResult = "2fa_required"
+	LoginTwoFactorFailed    LoginResult = "2fa_failed"
+	LoginOIDCRejected       LoginResult = "oidc_rejected"
+)
+
+// LoginAttempt is an append-only record of every login try
+type LoginAttempt struct {
+	ID        uint        `json:"id" gorm:"primaryKey"`
+	Email     string      `json:"email" gorm:"index"`
+	UserID    *uint       `json:"user_id" gorm:"index"`
+	IP        string      `json:"ip" gorm:"index"`
+	UserAgent string      `json:"user_agent"`
+	Result    LoginResult `json:"result" gorm:"type:varchar(32);index"`
+	CreatedAt time.Time   `json:"created_at" gorm:"index"`
+}
@@ -13,6 +13,9 @@ type ProjectType string
 
 type ContributionType string
 
+// PublishStatus tells whether a project is shown on the public site
+type PublishStatus string
+
 const (
 	Web             ProjectType = "Web"
 	Mobile          ProjectType = "Mobile"
@@ -20,6 +23,9 @@ const (
 
 	Personal ContributionType = "Personal"
 	Team     ContributionType = "Team"
+
+	Published   PublishStatus = "published"
+	Unpublished PublishStatus = "unpublished"
 )
 
 // Scanner and Valuer for ProjectType
@@ -50,17 +56,47 @@ func (ct ContributionType) Value() (driver.Value, error) {
 	return string(ct), nil
 }
 
+// Scanner and Valuer for PublishStatus
+func (ps *PublishStatus) Scan(value interface{}) error {
+	str, ok := value.(string)
+	if !ok {
+		return fmt.Errorf("cannot scan PublishStatus from %T", value)
+	}
+	*ps = PublishStatus(str)
+	return nil
+}
+
+func (ps PublishStatus) Value() (driver.Value, error) {
+	return string(ps), nil
+}
+
+// PublicProjects limits a query to the projects shown on the public site
+func PublicProjects(now time.Time) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("(projects.status = ? OR projects.publish_at <= ?)", Published, now).
+			Where("(projects.unpublish_at IS NULL OR projects.unpublish_at > ?)", now)
+	}
+}
+
 type Project struct {
 	gorm.Model
+	Schedule
 	ID           uint             `json:"id" gorm:"primaryKey"`
+	Slug         string           `json:"slug" gorm:"type:varchar(100);not null;default:'';uniqueIndex:idx_project_slug,where:slug <> ''"`
 	Name         string           `json:"name"`
 	ImageUrls    pq.StringArray   `json:"imageUrls" gorm:"type:text[]"`
 	Description  string           `json:"description"`
+	Body         string           `json:"body" gorm:"type:text"`
 	TechStack    pq.StringArray   `json:"techStack" gorm:"type:text[]"`
 	GithubLink   string           `json:"githubLink"`
 	Type         ProjectType      `json:"type" gorm:"type:project_type"`
 	Contribution ContributionType `json:"contribution" gorm:"type:contribution_type"`
 	ProjectLink  string           `json:"projectLink"`
+	Status       PublishStatus    `json:"status" gorm:"type:varchar(16);not null;default:'published'"`
+	Position     int              `json:"position" gorm:"not null;default:0;index"`
+	Featured     bool             `json:"featured" gorm:"not null;default:false"`
+	Technologies []Technology     `json:"technologies,omitempty" gorm:"many2many:project_technologies"`
+	Search       string           `json:"-" gorm:"->:false;<-:false;type:tsvector;index:,type:gin"`
 	UpdatedAt    time.Time        `json:"updated_at"`
 	CreatedAt    time.Time        `json:"created_at"`
 }
@@ -8,6 +8,7 @@ import (
 
 type ProjectPage struct {
 	gorm.Model
+	Schedule
 	ID          uint      `json:"id" gorm:"primaryKey"`
 	Title       string    `json:"title"`
 	Description string    `json:"description"`
@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+// ProjectSlug is a slug a project used before it was renamed. Old links
+// redirect to the project's current slug.
+type ProjectSlug struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	ProjectID uint      `json:"project_id" gorm:"index;not null"`
+	Slug      string    `json:"slug" gorm:"type:varchar(100);uniqueIndex;not null"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Schedule is embedded by content that can go live or offline at a set time.
+// On pages PublishAt publishes the pending draft; projects are also kept off
+// the public site until then. Past UnpublishAt hides the content.
+type Schedule struct {
+	PublishAt   *time.Time `json:"publish_at" gorm:"index"`
+	UnpublishAt *time.Time `json:"unpublish_at" gorm:"index"`
+}
+
+// PublishDue reports whether the publish time has come
+func (s Schedule) PublishDue(now time.Time) bool {
+	return s.PublishAt != nil && !s.PublishAt.After(now)
+}
+
+// UnpublishDue reports whether the unpublish time has come
+func (s Schedule) UnpublishDue(now time.Time) bool {
+	return s.UnpublishAt != nil && !s.UnpublishAt.After(now)
+}
+
+// UnpublishedBetween reports whether the unpublish time fell in (since, now]
+func (s Schedule) UnpublishedBetween(since, now time.Time) bool {
+	return s.UnpublishDue(now) && s.UnpublishAt.After(since)
+}
+
+// NotUnpublished limits a query to rows whose unpublish time has not passed
+func NotUnpublished(now time.Time) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("(unpublish_at IS NULL OR unpublish_at > ?)", now)
+	}
+}
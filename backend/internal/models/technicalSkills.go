@@ -53,12 +53,16 @@ func (sl Cateogry) Value() (driver.Value, error) {
 
 type TechnicalSkills struct {
 	gorm.Model
-	ID           uint           `json:"id" gorm:"primaryKey"`
-	Name         string         `json:"name"`
-	Description  string         `json:"description"`
-	Specialities pq.StringArray `json:"specialities" gorm:"type:text[]"`
-	Level        SkillLevel     `json:"level" gorm:"type:skill_level"`
-	Category     Cateogry       `json:"category" gorm:"type:category"`
-	UpdatedAt    time.Time      `json:"updated_at"`
-	CreatedAt    time.Time      `json:"created_at"`
+	ID           uint            `json:"id" gorm:"primaryKey"`
+	Name         string          `json:"name"`
+	Description  string          `json:"description"`
+	Specialities pq.StringArray  `json:"specialities" gorm:"type:text[]"`
+	Level        SkillLevel      `json:"level" gorm:"type:skill_level"`
+	Category     Cateogry        `json:"category" gorm:"type:category"`
+	Technologies []Technology    `json:"technologies,omitempty" gorm:"many2many:skill_technologies"`
+	Projects     []Project       `json:"projects,omitempty" gorm:"many2many:skill_projects"`
+	Careers      []CareerJourney `json:"careers,omitempty" gorm:"many2many:skill_careers"`
+	Search       string          `json:"-" gorm:"->:false;<-:false;type:tsvector;index:,type:gin"`
+	UpdatedAt    time.Time       `json:"updated_at"`
+	CreatedAt    time.Time       `json:"created_at"`
 }
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// TechnologyCategory groups technologies on the public site
+type TechnologyCategory string
+
+const (
+	TechLanguage  TechnologyCategory = "language"
+	TechFramework TechnologyCategory = "framework"
+	TechLibrary   TechnologyCategory = "library"
+	TechDatabase  TechnologyCategory = "database"
+	TechPlatform  TechnologyCategory = "platform"
+	TechTool      TechnologyCategory = "tool"
+	TechOther     TechnologyCategory = "other"
+)
+
+// Technology is the canonical entry for a language, framework or tool.
+// Project tech stacks and skill specialities are resolved against its name
+// and aliases, ignoring case, so "golang" and "GoLang" both become "Go".
+// Aliases are stored lowercase.
+type Technology struct {
+	ID        uint               `json:"id" gorm:"primaryKey"`
+	Name      string             `json:"name" gorm:"type:varchar(64);uniqueIndex;not null"`
+	Aliases   pq.StringArray     `json:"aliases" gorm:"type:text[]"`
+	Icon      string             `json:"icon"`
+	Category  TechnologyCategory `json:"category" gorm:"type:varchar(16);not null;default:'other'"`
+	CreatedAt time.Time          `json:"created_at"`
+	UpdatedAt time.Time          `json:"updated_at"`
+}
@@ -16,6 +16,7 @@ type Testimony struct {
 	Description string    `json:"description"`
 	AISummary   string    `json:"ai_summary"`
 	Approved    bool      `json:"approved"`
+	Search      string    `json:"-" gorm:"->:false;<-:false;type:tsvector;index:,type:gin"`
 	UpdatedAt   time.Time `json:"updated_at"`
 	CreatedAt   time.Time `json:"created_at"`
 }
@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// Translation is the text of one field of a page, card, career entry or
+// project in a locale other than the default. Single-row pages use EntityID 0.
+type Translation struct {
+	ID         uint       `json:"id" gorm:"primaryKey"`
+	EntityType EntityType `json:"entity_type" gorm:"type:varchar(32);uniqueIndex:idx_translation_key"`
+	EntityID   uint       `json:"entity_id" gorm:"uniqueIndex:idx_translation_key"`
+	Field      string     `json:"field" gorm:"type:varchar(64);uniqueIndex:idx_translation_key"`
+	Locale     string     `json:"locale" gorm:"type:varchar(16);uniqueIndex:idx_translation_key;index"`
+	Value      string     `json:"value" gorm:"type:text;not null"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
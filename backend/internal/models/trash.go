@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Trashed limits a query to soft-deleted rows
+func Trashed(db *gorm.DB) *gorm.DB {
+	return db.Unscoped().Where("deleted_at IS NOT NULL")
+}
+
+// TrashedBefore limits a query to rows soft-deleted before the given time
+func TrashedBefore(before time.Time) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Unscoped().Where("deleted_at IS NOT NULL AND deleted_at < ?", before)
+	}
+}
+
+// PurgeEntityContent deletes the drafts, revisions and translations of the
+// entities of one type whose ids the ids query selects. Purges run it before
+// deleting the rows themselves.
+func PurgeEntityContent(tx *gorm.DB, entityType EntityType, ids *gorm.DB) error {
+	for _, model := range []interface{}{&ContentDraft{}, &ContentRevision{}, &Translation{}} {
+		if err := tx.Where("entity_type = ? AND entity_id IN (?)", entityType, ids).Delete(model).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package models
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type UserRole string
+
+const (
+	Owner     UserRole = "owner"
+	Editor    UserRole = "editor"
+	Moderator UserRole = "moderator"
+)
+
+// Permission is a single capability checked by the admin routes
+type Permission string
+
+const (
+	PermHeroWrite           Permission = "hero:write"
+	PermAboutWrite          Permission = "about:write"
+	PermProjectsWrite       Permission = "projects:write"
+	PermTestimoniesWrite    Permission = "testimonies:write"
+	PermTestimoniesModerate Permission = "testimonies:moderate"
+	PermImagesWrite         Permission = "images:write"
+	PermUsersManage         Permission = "users:manage"
+	PermSecurityRead        Permission = "security:read"
+	PermAPIKeysManage       Permission = "apikeys:manage"
+	PermAuditRead           Permission = "audit:read"
+	PermTranslationsWrite   Permission = "translations:write"
+	PermContentRead         Permission = "content:read"
+)
+
+// APIKeyScopes are the permissions that can be granted to an API key.
+// Account and key management always need an interactive login.
+var APIKeyScopes = []Permission{
+	PermContentRead,
+	PermHeroWrite,
+	PermAboutWrite,
+	PermProjectsWrite,
+	PermTestimoniesWrite,
+	PermTestimoniesModerate,
+	PermImagesWrite,
+	PermTranslationsWrite,
+}
+
+// IsAPIKeyScope reports whether the permission may be granted to an API key
+func (p Permission) IsAPIKeyScope() bool {
+	for _, scope := range APIKeyScopes {
+		if scope == p {
+			return true
+		}
+	}
+	return false
+}
+
+// rolePermissions maps every role to the permissions it grants.
+// Owners are allowed everything and are not listed here.
+var rolePermissions = map[UserRole][]Permission{
+	Editor: {
+		PermContentRead,
+		PermHeroWrite,
+		PermAboutWrite,
+		PermProjectsWrite,
+		PermTestimoniesWrite,
+		PermTestimoniesModerate,
+		PermImagesWrite,
+		PermTranslationsWrite,
+	},
+	Moderator: {
+		PermContentRead,
+		PermTestimoniesModerate,
+	},
+}
+
+// IsValid reports whether the role is one of the known roles
+func (r UserRole) IsValid() bool {
+	switch r {
+	case Owner, Editor, Moderator:
+		return true
+	}
+	return false
+}
+
+// Can reports whether the role grants the given permission
+func (r UserRole) Can(p Permission) bool {
+	if r == Owner {
+		return true
+	}
+	for _, granted := range rolePermissions[r] {
+		if granted == p {
+			return true
+		}
+	}
+	return false
+}
+
+// Implement the Scanner interface
+func (r *UserRole) Scan(value interface{}) error {
+	str, ok := value.(string)
+	if !ok {
+		return fmt.Errorf("cannot scan UserRole from %T", value)
+	}
+	*r = UserRole(str)
+	return nil
+}
+
+// Implement the Valuer interface
+func (r UserRole) Value() (driver.Value, error) {
+	return string(r), nil
+}
+
+type User struct {
+	gorm.Model
+	ID           uint      `json:"id" gorm:"primaryKey"`
+	Email        string    `json:"email" gorm:"uniqueIndex;not null"`
+	Name         string    `json:"name"`
+	PasswordHash string    `json:"-" gorm:"not null"`
+	Role         UserRole  `json:"role" gorm:"type:varchar(20);not null"`
+	TOTPSecret   string    `json:"-"`
+	TOTPEnabled  bool      `json:"totp_enabled"`
+	TOTPLastStep int64     `json:"-"`
+	UpdatedAt    time.Time `json:"updated_at"`
+	CreatedAt    time.Time `json:"created_at"`
+}
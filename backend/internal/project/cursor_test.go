@@ -0,0 +1,108 @@
+package project
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/othersidedrl/portfolio/backend/internal/models"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	cursor := &ProjectCursor{Value: "2025-03-01T10:00:00.123456789Z", ID: 42}
+	encoded, err := encodeCursor(cursor)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := decodeCursor(encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *decoded != *cursor {
+		t.Errorf("decoded = %+v, want %+v", decoded, cursor)
+	}
+}
+
+func TestDecodeCursorRejects(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+	}{
+		{"not base64", "%%%"},
+		{"not JSON", "bm90IGpzb24"},
+		{"no ID", "eyJ2IjoiMSJ9"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := decodeCursor(tt.value); !errors.Is(err, ErrInvalidCursor) {
+				t.Errorf("err = %v, want %v", err, ErrInvalidCursor)
+			}
+		})
+	}
+}
+
+// Every sort has to read back the value it put in a cursor, or the next
+// page would start in the wrong place
+func TestProjectSortCursorValues(t *testing.T) {
+	project := models.Project{
+		Name:     "Portfolio, v2",
+		Position: 7,
+	}
+	project.CreatedAt = time.Date(2025, 3, 1, 10, 0, 0, 123456789, time.UTC)
+
+	tests := []struct {
+		sort ProjectSort
+		want interface{}
+	}{
+		{SortPosition, 7},
+		{SortNewest, project.CreatedAt},
+		{SortOldest, project.CreatedAt},
+		{SortName, "Portfolio, v2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.sort), func(t *testing.T) {
+			sort := projectSorts[tt.sort]
+			got, err := sort.parseValue(sort.cursorValue(project))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if when, ok := got.(time.Time); ok {
+				if !when.Equal(tt.want.(time.Time)) {
+					t.Errorf("value = %v, want %v", when, tt.want)
+				}
+				return
+			}
+			if got != tt.want {
+				t.Errorf("value = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetProjectsRejectsBadQueries(t *testing.T) {
+	handler := NewHandler(NewService(nil, nil, nil, nil, nopRecorder{}))
+
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{"unknown type", "?type=Desktop"},
+		{"unknown contribution", "?contribution=Solo"},
+		{"unknown sort", "?sort=stars"},
+		{"bad cursor", "?cursor=%25%25"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			handler.GetProjects(w, httptest.NewRequest("GET", "/api/v1/project/items"+tt.query, nil))
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+			}
+		})
+	}
+}
@@ -2,11 +2,19 @@ package project
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
+	"net/url"
+	"path"
+	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/othersidedrl/portfolio/backend/internal/content"
+	"github.com/othersidedrl/portfolio/backend/internal/models"
 	"github.com/othersidedrl/portfolio/backend/internal/utils"
+	"gorm.io/gorm"
 )
 
 type Handler struct {
@@ -27,23 +35,229 @@ func (h *Handler) GetProjectPage(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(page)
 }
 
+func (h *Handler) GetPublicProjectPage(w http.ResponseWriter, r *http.Request) {
+	page, err := h.service.GetPublicProjectPage(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(page)
+}
+
+func (h *Handler) GetProjectPageSchedule(w http.ResponseWriter, r *http.Request) {
+	schedule, err := h.service.GetProjectPageSchedule(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(schedule)
+}
+
+func (h *Handler) UpdateProjectPageSchedule(w http.ResponseWriter, r *http.Request) {
+	var body content.ScheduleDto
+	if err := utils.DecodeBody(r, &body); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.SetProjectPageSchedule(r.Context(), body); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"message": "Project page schedule updated"})
+}
+
 func (h *Handler) UpdateProjectPage(w http.ResponseWriter, r *http.Request) {
 	var body ProjectPageDto
 	if err := utils.DecodeBody(r, &body); err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
-	if err := h.service.UpdateProjectPage(r.Context(), &body); err != nil {
+	if err := h.service.SaveProjectPageDraft(r.Context(), &body); err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 	w.WriteHeader(http.StatusOK)
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]string{"message": "Project page updated"})
+	json.NewEncoder(w).Encode(map[string]string{"message": "Project page draft saved"})
 }
 
+func (h *Handler) PreviewProjectPage(w http.ResponseWriter, r *http.Request) {
+	page, err := h.service.PreviewProjectPage(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(page)
+}
+
+func (h *Handler) PublishProjectPage(w http.ResponseWriter, r *http.Request) {
+	if err := h.service.PublishProjectPage(r.Context()); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"message": "Project page published"})
+}
+
+func (h *Handler) DiscardProjectPageDraft(w http.ResponseWriter, r *http.Request) {
+	if err := h.service.DiscardProjectPageDraft(r.Context()); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) RestoreProjectPage(w http.ResponseWriter, r *http.Request) {
+	revisionID, err := strconv.Atoi(chi.URLParam(r, "revisionID"))
+	if err != nil {
+		http.Error(w, "Invalid revision ID", http.StatusBadRequest)
+		return
+	}
+	if err := h.service.RestoreProjectPage(r.Context(), uint(revisionID)); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"message": "Project page restored"})
+}
+
+// CacheQuery is the GET /project/items query with the technologies in
+// their canonical names and sorted, so "golang,react" and "React,Go" are
+// cached as one. The names are kept as sent if the lookup fails.
+func (h *Handler) CacheQuery(r *http.Request) url.Values {
+	q := r.URL.Query()
+	techs := techFilter(q)
+	if len(techs) == 0 {
+		return q
+	}
+	canonical, err := h.service.CanonicalTech(r.Context(), techs)
+	if err != nil {
+		return q
+	}
+	sort.Strings(canonical)
+	q["tech"] = canonical
+	return q
+}
+
+// techFilter reads ?tech=, repeatable or comma separated
+func techFilter(q url.Values) []string {
+	var techs []string
+	for _, value := range q["tech"] {
+		for _, tech := range strings.Split(value, ",") {
+			if tech = strings.TrimSpace(tech); tech != "" {
+				techs = append(techs, tech)
+			}
+		}
+	}
+	return techs
+}
+
+// GetProjects handles GET /project/items.
+// Supports ?type=, ?contribution=, ?tech= (repeatable or comma separated,
+// projects must use all of them), ?sort=position|newest|oldest|name
+// (position, the manual order, by default), ?limit= and
+// ?cursor= taken from the previous page's pagination.next_cursor.
 func (h *Handler) GetProjects(w http.ResponseWriter, r *http.Request) {
-	projects, err := h.service.GetProjects(r.Context())
+	q := r.URL.Query()
+	query := ProjectQuery{
+		Type:         models.ProjectType(q.Get("type")),
+		Contribution: models.ContributionType(q.Get("contribution")),
+		Sort:         SortPosition,
+		Limit:        20,
+	}
+	switch query.Type {
+	case "", models.Web, models.Mobile, models.MachineLearning:
+	default:
+		http.Error(w, "Invalid type, expected Web, Mobile or Machine Learning", http.StatusBadRequest)
+		return
+	}
+	switch query.Contribution {
+	case "", models.Personal, models.Team:
+	default:
+		http.Error(w, "Invalid contribution, expected Personal or Team", http.StatusBadRequest)
+		return
+	}
+	query.Tech = techFilter(q)
+	if sort := ProjectSort(q.Get("sort")); sort != "" {
+		if _, ok := projectSorts[sort]; !ok {
+			http.Error(w, "Invalid sort, expected position, newest, oldest or name", http.StatusBadRequest)
+			return
+		}
+		query.Sort = sort
+	}
+	if limit, err := strconv.Atoi(q.Get("limit")); err == nil && limit > 0 && limit <= 100 {
+		query.Limit = limit
+	}
+	if cursor := q.Get("cursor"); cursor != "" {
+		after, err := decodeCursor(cursor)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		query.After = after
+	}
+
+	projects, err := h.service.GetProjects(r.Context(), query)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	response := map[string]interface{}{
+		"length":     len(projects.Projects),
+		"data":       projects.Projects,
+		"pagination": projects.Pagination,
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+func (h *Handler) GetFeaturedProjects(w http.ResponseWriter, r *http.Request) {
+	projects, err := h.service.GetFeaturedProjects(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	response := map[string]interface{}{
+		"length": len(projects.Projects),
+		"data":   projects.Projects,
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// GetProjectBySlug serves a public project. Old slugs of renamed projects
+// redirect permanently to the current one.
+func (h *Handler) GetProjectBySlug(w http.ResponseWriter, r *http.Request) {
+	project, current, err := h.service.GetProjectBySlug(r.Context(), chi.URLParam(r, "slug"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if current != "" {
+		target := *r.URL
+		target.Path = path.Join(path.Dir(r.URL.Path), current)
+		http.Redirect(w, r, target.String(), http.StatusMovedPermanently)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(project)
+}
+
+func (h *Handler) GetAllProjects(w http.ResponseWriter, r *http.Request) {
+	projects, err := h.service.GetAllProjects(r.Context())
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -64,12 +278,12 @@ func (h *Handler) CreateProject(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if err := h.service.CreateProject(r.Context(), &body); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, err)
 		return
 	}
 	w.WriteHeader(http.StatusCreated)
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]string{"message": "Successfully created a project"})
+	json.NewEncoder(w).Encode(map[string]interface{}{"message": "Successfully created a project", "id": body.ID, "slug": body.Slug})
 }
 
 func (h *Handler) UpdateProject(w http.ResponseWriter, r *http.Request) {
@@ -83,13 +297,163 @@ func (h *Handler) UpdateProject(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
-	if err := h.service.UpdateProject(r.Context(), &body, uint(id)); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	if err := h.service.SaveProjectDraft(r.Context(), &body, uint(id)); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"message": "Project draft saved"})
+}
+
+// ReorderProjects handles PUT /admin/project/items/order with every project
+// ID in the new order
+func (h *Handler) ReorderProjects(w http.ResponseWriter, r *http.Request) {
+	var body ProjectOrderDto
+	if err := utils.DecodeBody(r, &body); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := h.service.ReorderProjects(r.Context(), body); err != nil {
+		writeError(w, err)
 		return
 	}
 	w.WriteHeader(http.StatusOK)
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]string{"message": "Project updated"})
+	json.NewEncoder(w).Encode(map[string]string{"message": "Projects reordered"})
+}
+
+func (h *Handler) SetProjectFeatured(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid project ID", http.StatusBadRequest)
+		return
+	}
+	var body FeaturedDto
+	if err := utils.DecodeBody(r, &body); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := h.service.SetProjectFeatured(r.Context(), uint(id), body.Featured); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"message": "Project featured status updated"})
+}
+
+func (h *Handler) PreviewProject(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid project ID", http.StatusBadRequest)
+		return
+	}
+	project, err := h.service.PreviewProject(r.Context(), uint(id))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(project)
+}
+
+func (h *Handler) PublishProject(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid project ID", http.StatusBadRequest)
+		return
+	}
+	if err := h.service.PublishProject(r.Context(), uint(id)); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"message": "Project published"})
+}
+
+func (h *Handler) UnpublishProject(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid project ID", http.StatusBadRequest)
+		return
+	}
+	if err := h.service.UnpublishProject(r.Context(), uint(id)); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"message": "Project unpublished"})
+}
+
+func (h *Handler) DiscardProjectDraft(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid project ID", http.StatusBadRequest)
+		return
+	}
+	if err := h.service.DiscardProjectDraft(r.Context(), uint(id)); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) RestoreProject(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid project ID", http.StatusBadRequest)
+		return
+	}
+	revisionID, err := strconv.Atoi(chi.URLParam(r, "revisionID"))
+	if err != nil {
+		http.Error(w, "Invalid revision ID", http.StatusBadRequest)
+		return
+	}
+	if err := h.service.RestoreProject(r.Context(), uint(id), uint(revisionID)); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"message": "Project restored"})
+}
+
+func (h *Handler) GetProjectSchedule(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid project ID", http.StatusBadRequest)
+		return
+	}
+	schedule, err := h.service.GetProjectSchedule(r.Context(), uint(id))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(schedule)
+}
+
+func (h *Handler) UpdateProjectSchedule(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid project ID", http.StatusBadRequest)
+		return
+	}
+	var body content.ScheduleDto
+	if err := utils.DecodeBody(r, &body); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := h.service.SetProjectSchedule(r.Context(), uint(id), body); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"message": "Project schedule updated"})
 }
 
 func (h *Handler) DeleteProject(w http.ResponseWriter, r *http.Request) {
@@ -99,9 +463,55 @@ func (h *Handler) DeleteProject(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if err := h.service.DeleteProject(r.Context(), uint(id)); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, err)
 		return
 	}
 	w.WriteHeader(http.StatusNoContent)
 	w.Header().Set("Content-Type", "application/json")
 }
+
+func (h *Handler) GetTrashedProjects(w http.ResponseWriter, r *http.Request) {
+	projects, err := h.service.GetTrashedProjects(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	response := map[string]interface{}{
+		"length": len(projects),
+		"data":   projects,
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+func (h *Handler) RestoreTrashedProject(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid project ID", http.StatusBadRequest)
+		return
+	}
+	if err := h.service.RestoreTrashedProject(r.Context(), uint(id)); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"message": "Project restored"})
+}
+
+// writeError maps service errors to HTTP status codes
+func writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, ErrInvalidSlug):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, ErrSlugTaken):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, ErrInvalidCursor), errors.Is(err, ErrInvalidOrder):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		content.WriteError(w, err)
+	}
+}
@@ -0,0 +1,54 @@
+package project
+
+import (
+	"context"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/othersidedrl/portfolio/backend/internal/models"
+	"github.com/othersidedrl/portfolio/backend/internal/technology"
+)
+
+// fakeTechnologyRepository knows Go (alias golang) and React
+type fakeTechnologyRepository struct {
+	technology.TechnologyRepository
+}
+
+func (fakeTechnologyRepository) FindByKeys(ctx context.Context, keys []string) ([]models.Technology, error) {
+	return []models.Technology{
+		{Name: "Go", Aliases: []string{"golang"}},
+		{Name: "React"},
+	}, nil
+}
+
+type nopRecorder struct{}
+
+func (nopRecorder) Record(ctx context.Context, action models.AuditAction, entityType models.EntityType, entityID interface{}, before, after interface{}) {
+}
+
+func TestCacheQueryCanonicalTech(t *testing.T) {
+	techs := technology.NewService(fakeTechnologyRepository{}, nopRecorder{})
+	handler := NewHandler(NewService(nil, nil, nil, techs, nopRecorder{}))
+
+	tests := []struct {
+		name  string
+		query string
+		want  []string
+	}{
+		{"canonical names", "?tech=Go,React", []string{"Go", "React"}},
+		{"aliases and case", "?tech=golang,react", []string{"Go", "React"}},
+		{"repeated and reordered", "?tech=REACT&tech=golang", []string{"Go", "React"}},
+		{"unknown kept as written", "?tech=Elm", []string{"Elm"}},
+		{"no filter", "?type=Web", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/api/v1/project/items"+tt.query, nil)
+			if got := handler.CacheQuery(r)["tech"]; !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("tech = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,6 +1,11 @@
 package project
 
-import "github.com/othersidedrl/portfolio/backend/internal/models"
+import (
+	"time"
+
+	"github.com/othersidedrl/portfolio/backend/internal/markdown"
+	"github.com/othersidedrl/portfolio/backend/internal/models"
+)
 
 type ProjectPageDto struct {
 	Title       string `json:"title"`
@@ -9,16 +14,89 @@ type ProjectPageDto struct {
 
 type ProjectItemDto struct {
 	ID           int                     `json:"id"`
+	Slug         string                  `json:"slug"`
 	Name         string                  `json:"name"`
 	ImageUrls    []string                `json:"imageUrls"`
 	Description  string                  `json:"description"`
+	Body         string                  `json:"body,omitempty"`
 	TechStack    []string                `json:"techStack"`
 	GithubLink   string                  `json:"githubLink"`
 	Type         models.ProjectType      `json:"type"`
 	Contribution models.ContributionType `json:"contribution"`
 	ProjectLink  string                  `json:"projectLink"`
+	Position     int                     `json:"position"`
+	Featured     bool                    `json:"featured"`
+	Status       models.PublishStatus    `json:"status,omitempty"`
+	PublishAt    *time.Time              `json:"publish_at,omitempty"`
+	UnpublishAt  *time.Time              `json:"unpublish_at,omitempty"`
+}
+
+// ProjectDetailDto is a single project with its Markdown body rendered.
+// The raw body is only filled in for the admin.
+type ProjectDetailDto struct {
+	ProjectItemDto
+	BodyHTML   string             `json:"body_html"`
+	TOC        []markdown.Heading `json:"toc"`
+	SkillsUsed []SkillRefDto      `json:"skills_used"`
+}
+
+// SkillRefDto is a technical skill the project shows in use
+type SkillRefDto struct {
+	ID       uint   `json:"id"`
+	Name     string `json:"name"`
+	Level    string `json:"level"`
+	Category string `json:"category"`
+}
+
+// ProjectOrderDto lists every project ID in the order they should appear
+type ProjectOrderDto struct {
+	IDs []uint `json:"ids"`
+}
+
+type FeaturedDto struct {
+	Featured bool `json:"featured"`
+}
+
+type TrashedProjectDto struct {
+	ProjectItemDto
+	DeletedAt time.Time `json:"deleted_at"`
 }
 
 type ProjectDto struct {
-	Projects []ProjectItemDto `json:"projects"`
+	Projects   []ProjectItemDto `json:"projects"`
+	Pagination *PaginationDto   `json:"pagination,omitempty"`
+}
+
+// ProjectSort is an order of the public project list
+type ProjectSort string
+
+const (
+	SortPosition ProjectSort = "position"
+	SortNewest   ProjectSort = "newest"
+	SortOldest   ProjectSort = "oldest"
+	SortName     ProjectSort = "name"
+)
+
+// ProjectQuery filters, orders and pages the public project list.
+// Tech lists technologies a project must all use.
+type ProjectQuery struct {
+	Type         models.ProjectType
+	Contribution models.ContributionType
+	Tech         []string
+	Sort         ProjectSort
+	After        *ProjectCursor
+	Limit        int
+}
+
+// ProjectCursor points at the last project of a page: its value for the
+// sort column and its ID, which breaks ties
+type ProjectCursor struct {
+	Value string `json:"v"`
+	ID    uint   `json:"id"`
+}
+
+type PaginationDto struct {
+	Limit      int    `json:"limit"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	HasMore    bool   `json:"has_more"`
 }
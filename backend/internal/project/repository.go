@@ -2,18 +2,51 @@ package project
 
 import (
 	"context"
+	"fmt"
+	"strconv"
+	"time"
 
+	"github.com/lib/pq"
+	"github.com/othersidedrl/portfolio/backend/internal/database"
 	"github.com/othersidedrl/portfolio/backend/internal/models"
+	"github.com/othersidedrl/portfolio/backend/internal/search"
+	"github.com/othersidedrl/portfolio/backend/internal/technology"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type ProjectRepository interface {
 	GetProjectPage(ctx context.Context) (*ProjectPageDto, error)
+	GetPublicProjectPage(ctx context.Context) (*ProjectPageDto, error)
+	GetProjectPageSchedule(ctx context.Context) (*models.Schedule, error)
+	SetProjectPageSchedule(ctx context.Context, schedule models.Schedule) error
 	UpdateProjectPage(ctx context.Context, data *ProjectPageDto) error
-	GetProjects(ctx context.Context) (*ProjectDto, error)
+	GetProjects(ctx context.Context, query ProjectQuery) (*ProjectDto, *ProjectCursor, error)
+	GetAllProjects(ctx context.Context) (*ProjectDto, error)
+	GetPublicProjects(ctx context.Context) (*ProjectDto, error)
+	GetFeaturedProjects(ctx context.Context) (*ProjectDto, error)
+	GetProjectOrder(ctx context.Context) ([]uint, error)
+	ReorderProjects(ctx context.Context, ids []uint) error
+	SetProjectFeatured(ctx context.Context, id uint, featured bool) error
+	GetProject(ctx context.Context, id uint) (*ProjectItemDto, error)
+	GetPublicProjectBySlug(ctx context.Context, slug string) (*ProjectItemDto, error)
+	FindSlugRedirect(ctx context.Context, slug string) (string, error)
+	SlugTaken(ctx context.Context, slug string, exceptID uint) (bool, error)
+	GetProjectsWithoutSlug(ctx context.Context) ([]ProjectItemDto, error)
+	SetProjectSlug(ctx context.Context, id uint, slug string) error
 	CreateProject(ctx context.Context, data *ProjectItemDto) error
 	UpdateProject(ctx context.Context, data *ProjectItemDto, id uint) error
+	SetProjectStatus(ctx context.Context, id uint, status models.PublishStatus) error
+	GetProjectSchedule(ctx context.Context, id uint) (*models.Schedule, error)
+	SetProjectSchedule(ctx context.Context, id uint, schedule models.Schedule) error
+	GetDueProjects(ctx context.Context, now time.Time) (publish []uint, unpublish []uint, err error)
 	DeleteProject(ctx context.Context, id uint) error
+	GetTrashedProjects(ctx context.Context) ([]TrashedProjectDto, error)
+	RestoreTrashedProject(ctx context.Context, id uint) error
+	PurgeProjects(ctx context.Context, before time.Time) (int64, error)
+	GetProjectsWithoutTechnologies(ctx context.Context) ([]ProjectItemDto, error)
+	GetProjectSkills(ctx context.Context, id uint) ([]SkillRefDto, error)
+	SetProjectTechnologies(ctx context.Context, id uint, names []string) error
 }
 
 type GormProjectRepository struct {
@@ -25,8 +58,17 @@ func NewGormProjectRepository(db *gorm.DB) *GormProjectRepository {
 }
 
 func (r *GormProjectRepository) GetProjectPage(ctx context.Context) (*ProjectPageDto, error) {
+	return r.findProjectPage(ctx)
+}
+
+// GetPublicProjectPage retrieves the project page unless its unpublish time has passed
+func (r *GormProjectRepository) GetPublicProjectPage(ctx context.Context) (*ProjectPageDto, error) {
+	return r.findProjectPage(ctx, models.NotUnpublished(time.Now()))
+}
+
+func (r *GormProjectRepository) findProjectPage(ctx context.Context, scopes ...func(*gorm.DB) *gorm.DB) (*ProjectPageDto, error) {
 	var page models.ProjectPage
-	if err := r.db.WithContext(ctx).First(&page).Error; err != nil {
+	if err := database.Conn(ctx, r.db).Scopes(scopes...).First(&page).Error; err != nil {
 		return nil, err
 	}
 	return &ProjectPageDto{
@@ -35,68 +77,583 @@ func (r *GormProjectRepository) GetProjectPage(ctx context.Context) (*ProjectPag
 	}, nil
 }
 
+func (r *GormProjectRepository) GetProjectPageSchedule(ctx context.Context) (*models.Schedule, error) {
+	var page models.ProjectPage
+	if err := database.Conn(ctx, r.db).First(&page).Error; err != nil {
+		return nil, err
+	}
+	return &page.Schedule, nil
+}
+
+func (r *GormProjectRepository) SetProjectPageSchedule(ctx context.Context, schedule models.Schedule) error {
+	var page models.ProjectPage
+	if err := database.Conn(ctx, r.db).First(&page).Error; err != nil {
+		return err
+	}
+	return database.Conn(ctx, r.db).Model(&page).
+		Select("publish_at", "unpublish_at").
+		Updates(&models.ProjectPage{Schedule: schedule}).Error
+}
+
 func (r *GormProjectRepository) UpdateProjectPage(ctx context.Context, data *ProjectPageDto) error {
 	var page models.ProjectPage
-	if err := r.db.WithContext(ctx).First(&page).Error; err != nil {
-		return r.db.WithContext(ctx).Create(&models.ProjectPage{
+	if err := database.Conn(ctx, r.db).First(&page).Error; err != nil {
+		return database.Conn(ctx, r.db).Create(&models.ProjectPage{
 			Title:       data.Title,
 			Description: data.Description,
 		}).Error
 	}
 	page.Title = data.Title
 	page.Description = data.Description
-	return r.db.WithContext(ctx).Save(&page).Error
+	return database.Conn(ctx, r.db).Save(&page).Error
+}
+
+// projectSort orders projects by a column, with the ID breaking ties so
+// cursors always point at a single row. cursorValue reads the column for a
+// cursor and parseValue turns it back into a query argument.
+type projectSort struct {
+	column      string
+	desc        bool
+	cursorValue func(p models.Project) string
+	parseValue  func(value string) (interface{}, error)
+}
+
+var (
+	byCreatedAt = projectSort{
+		column:      "created_at",
+		cursorValue: func(p models.Project) string { return p.CreatedAt.Format(time.RFC3339Nano) },
+		parseValue: func(value string) (interface{}, error) {
+			return time.Parse(time.RFC3339Nano, value)
+		},
+	}
+	byName = projectSort{
+		column:      "name",
+		cursorValue: func(p models.Project) string { return p.Name },
+		parseValue:  func(value string) (interface{}, error) { return value, nil },
+	}
+	byPosition = projectSort{
+		column:      "position",
+		cursorValue: func(p models.Project) string { return strconv.Itoa(p.Position) },
+		parseValue: func(value string) (interface{}, error) {
+			return strconv.Atoi(value)
+		},
+	}
+)
+
+func descending(sort projectSort) projectSort {
+	sort.desc = true
+	return sort
+}
+
+var projectSorts = map[ProjectSort]projectSort{
+	SortPosition: byPosition,
+	SortNewest:   descending(byCreatedAt),
+	SortOldest:   byCreatedAt,
+	SortName:     byName,
 }
 
-func (r *GormProjectRepository) GetProjects(ctx context.Context) (*ProjectDto, error) {
+// GetProjects returns one page of the projects that are public right now:
+// published or past their publish time, and not past their unpublish time.
+// The cursor of the next page is nil on the last page.
+func (r *GormProjectRepository) GetProjects(ctx context.Context, query ProjectQuery) (*ProjectDto, *ProjectCursor, error) {
+	sort, ok := projectSorts[query.Sort]
+	if !ok {
+		sort = projectSorts[SortPosition]
+	}
+	op, dir := ">", "ASC"
+	if sort.desc {
+		op, dir = "<", "DESC"
+	}
+
+	db := database.Conn(ctx, r.db).Scopes(models.PublicProjects(time.Now()))
+	if query.Type != "" {
+		db = db.Where("type = ?", query.Type)
+	}
+	if query.Contribution != "" {
+		db = db.Where("contribution = ?", query.Contribution)
+	}
+	if len(query.Tech) > 0 {
+		db = db.Where("tech_stack @> ?", pq.StringArray(query.Tech))
+	}
+	if query.After != nil {
+		value, err := sort.parseValue(query.After.Value)
+		if err != nil {
+			return nil, nil, ErrInvalidCursor
+		}
+		db = db.Where(fmt.Sprintf("(%[1]s %[2]s ? OR (%[1]s = ? AND id %[2]s ?))", sort.column, op), value, value, query.After.ID)
+	}
+
 	var projects []models.Project
-	if err := r.db.WithContext(ctx).Find(&projects).Error; err != nil {
+	err := db.Order(fmt.Sprintf("%s %s, id %s", sort.column, dir, dir)).
+		Limit(query.Limit + 1).
+		Find(&projects).Error
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var next *ProjectCursor
+	if len(projects) > query.Limit {
+		projects = projects[:query.Limit]
+		last := projects[len(projects)-1]
+		next = &ProjectCursor{ID: last.ID, Value: sort.cursorValue(last)}
+	}
+
+	dto := &ProjectDto{Projects: make([]ProjectItemDto, 0, len(projects))}
+	for _, p := range projects {
+		dto.Projects = append(dto.Projects, toProjectItemDto(p))
+	}
+	return dto, next, nil
+}
+
+// GetAllProjects returns every project, published or not, in their manual order
+func (r *GormProjectRepository) GetAllProjects(ctx context.Context) (*ProjectDto, error) {
+	return r.findProjects(database.Conn(ctx, r.db).Order("position, id"))
+}
+
+// GetPublicProjects returns every project that is public right now, in their manual order
+func (r *GormProjectRepository) GetPublicProjects(ctx context.Context) (*ProjectDto, error) {
+	return r.findProjects(database.Conn(ctx, r.db).
+		Scopes(models.PublicProjects(time.Now())).
+		Order("position, id"))
+}
+
+// GetFeaturedProjects returns the public projects pinned to the landing page
+func (r *GormProjectRepository) GetFeaturedProjects(ctx context.Context) (*ProjectDto, error) {
+	return r.findProjects(database.Conn(ctx, r.db).
+		Scopes(models.PublicProjects(time.Now())).
+		Where("featured = ?", true).
+		Order("position, id"))
+}
+
+// GetProjectOrder returns the IDs of every project in their manual order
+func (r *GormProjectRepository) GetProjectOrder(ctx context.Context) ([]uint, error) {
+	var ids []uint
+	err := database.Conn(ctx, r.db).Model(&models.Project{}).Order("position, id").Pluck("id", &ids).Error
+	return ids, err
+}
+
+// projectOrderLock is the advisory lock key held by every transaction that
+// changes which projects are listed or their positions
+const projectOrderLock = 7_100_001
+
+// lockProjectOrder takes the project order lock until the transaction ends.
+// Row locks cannot stop a concurrent insert, so creating, restoring and
+// reordering projects take this lock instead.
+func lockProjectOrder(tx *gorm.DB) error {
+	return tx.Exec("SELECT pg_advisory_xact_lock(?)", projectOrderLock).Error
+}
+
+// ReorderProjects sets every project's position to its index in ids. The
+// project order is locked while ids is checked against the projects, so a
+// project created or restored meanwhile cannot be left out.
+func (r *GormProjectRepository) ReorderProjects(ctx context.Context, ids []uint) error {
+	return database.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := lockProjectOrder(tx); err != nil {
+			return err
+		}
+
+		var current []uint
+		err := tx.Model(&models.Project{}).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Pluck("id", &current).Error
+		if err != nil {
+			return err
+		}
+
+		if len(ids) != len(current) {
+			return ErrInvalidOrder
+		}
+		remaining := make(map[uint]bool, len(current))
+		for _, id := range current {
+			remaining[id] = true
+		}
+		for _, id := range ids {
+			if !remaining[id] {
+				return ErrInvalidOrder
+			}
+			delete(remaining, id)
+		}
+
+		for position, id := range ids {
+			if err := tx.Model(&models.Project{}).Where("id = ?", id).Update("position", position).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *GormProjectRepository) SetProjectFeatured(ctx context.Context, id uint, featured bool) error {
+	result := database.Conn(ctx, r.db).Model(&models.Project{}).Where("id = ?", id).Update("featured", featured)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *GormProjectRepository) findProjects(query *gorm.DB) (*ProjectDto, error) {
+	var projects []models.Project
+	if err := query.Find(&projects).Error; err != nil {
 		return nil, err
 	}
 	var dtoProjects []ProjectItemDto
 	for _, p := range projects {
-		dtoProjects = append(dtoProjects, ProjectItemDto{
-			ID:           int(p.ID),
-			Name:         p.Name,
-			ImageUrls:    p.ImageUrls,
-			Description:  p.Description,
-			TechStack:    p.TechStack,
-			GithubLink:   p.GithubLink,
-			Type:         p.Type,
-			Contribution: p.Contribution,
-			ProjectLink:  p.ProjectLink,
-		})
+		dtoProjects = append(dtoProjects, toProjectItemDto(p))
 	}
 	return &ProjectDto{Projects: dtoProjects}, nil
 }
 
+func toProjectItemDto(p models.Project) ProjectItemDto {
+	return ProjectItemDto{
+		ID:           int(p.ID),
+		Slug:         p.Slug,
+		Name:         p.Name,
+		ImageUrls:    p.ImageUrls,
+		Description:  p.Description,
+		Body:         p.Body,
+		TechStack:    p.TechStack,
+		GithubLink:   p.GithubLink,
+		Type:         p.Type,
+		Contribution: p.Contribution,
+		ProjectLink:  p.ProjectLink,
+		Status:       p.Status,
+		Position:     p.Position,
+		Featured:     p.Featured,
+		PublishAt:    p.PublishAt,
+		UnpublishAt:  p.UnpublishAt,
+	}
+}
+
+func (r *GormProjectRepository) GetProject(ctx context.Context, id uint) (*ProjectItemDto, error) {
+	var p models.Project
+	if err := database.Conn(ctx, r.db).First(&p, id).Error; err != nil {
+		return nil, err
+	}
+	dto := toProjectItemDto(p)
+	return &dto, nil
+}
+
+// GetPublicProjectBySlug returns the project with the given slug if it is public right now
+func (r *GormProjectRepository) GetPublicProjectBySlug(ctx context.Context, slug string) (*ProjectItemDto, error) {
+	var p models.Project
+	err := database.Conn(ctx, r.db).Scopes(models.PublicProjects(time.Now())).
+		Where("slug = ?", slug).
+		First(&p).Error
+	if err != nil {
+		return nil, err
+	}
+	dto := toProjectItemDto(p)
+	return &dto, nil
+}
+
+// FindSlugRedirect returns the current slug of the project that used to
+// have the given one
+func (r *GormProjectRepository) FindSlugRedirect(ctx context.Context, slug string) (string, error) {
+	var current string
+	err := database.Conn(ctx, r.db).Model(&models.ProjectSlug{}).
+		Joins("JOIN projects ON projects.id = project_slugs.project_id AND projects.deleted_at IS NULL").
+		Where("project_slugs.slug = ? AND projects.slug <> ''", slug).
+		Pluck("projects.slug", &current).Error
+	if err != nil {
+		return "", err
+	}
+	if current == "" {
+		return "", gorm.ErrRecordNotFound
+	}
+	return current, nil
+}
+
+// SlugTaken reports whether another project, trashed ones included, has the slug
+func (r *GormProjectRepository) SlugTaken(ctx context.Context, slug string, exceptID uint) (bool, error) {
+	var count int64
+	err := database.Conn(ctx, r.db).Unscoped().Model(&models.Project{}).
+		Where("slug = ? AND id <> ?", slug, exceptID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// GetProjectsWithoutSlug returns the projects created before slugs existed
+func (r *GormProjectRepository) GetProjectsWithoutSlug(ctx context.Context) ([]ProjectItemDto, error) {
+	var projects []models.Project
+	if err := database.Conn(ctx, r.db).Unscoped().Where("slug = ''").Order("id").Find(&projects).Error; err != nil {
+		return nil, err
+	}
+	dtos := make([]ProjectItemDto, 0, len(projects))
+	for _, p := range projects {
+		dtos = append(dtos, toProjectItemDto(p))
+	}
+	return dtos, nil
+}
+
+// SetProjectSlug gives a project without a slug its first one
+func (r *GormProjectRepository) SetProjectSlug(ctx context.Context, id uint, slug string) error {
+	return database.Conn(ctx, r.db).Unscoped().Model(&models.Project{}).
+		Where("id = ? AND slug = ''", id).
+		Update("slug", slug).Error
+}
+
+// CreateProject inserts the project unpublished, after every other project,
+// links its tech stack and sets data.ID to the new ID. A slug that used to
+// redirect to another project now belongs to the new one.
 func (r *GormProjectRepository) CreateProject(ctx context.Context, data *ProjectItemDto) error {
+	return database.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		return createProject(tx, data)
+	})
+}
+
+func createProject(tx *gorm.DB, data *ProjectItemDto) error {
+	if err := lockProjectOrder(tx); err != nil {
+		return err
+	}
+
+	var last int
+	err := tx.Model(&models.Project{}).
+		Select("COALESCE(MAX(position), -1)").
+		Scan(&last).Error
+	if err != nil {
+		return err
+	}
+
 	project := models.Project{
+		Position:     last + 1,
+		Status:       models.Unpublished,
+		Slug:         data.Slug,
 		Name:         data.Name,
 		ImageUrls:    data.ImageUrls,
 		Description:  data.Description,
+		Body:         data.Body,
 		TechStack:    data.TechStack,
 		GithubLink:   data.GithubLink,
 		Type:         data.Type,
 		Contribution: data.Contribution,
 		ProjectLink:  data.ProjectLink,
 	}
-	return r.db.WithContext(ctx).Create(&project).Error
+	if err := tx.Where("slug = ?", data.Slug).Delete(&models.ProjectSlug{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Create(&project).Error; err != nil {
+		return err
+	}
+	if err := technology.ProjectLinks.Link(tx, project.ID, data.TechStack); err != nil {
+		return err
+	}
+	if err := search.Projects.Refresh(tx, project.ID); err != nil {
+		return err
+	}
+	data.ID = int(project.ID)
+	data.Status = project.Status
+	return nil
 }
 
+// UpdateProject overwrites the project's content, including fields set to
+// their zero value, so publishing a draft or restoring a revision can clear them.
+// A new slug is applied and the old one kept for redirects; an empty slug is left alone.
 func (r *GormProjectRepository) UpdateProject(ctx context.Context, data *ProjectItemDto, id uint) error {
-	return r.db.WithContext(ctx).Model(&models.Project{}).Where("id = ?", id).Updates(&models.Project{
-		Name:         data.Name,
-		ImageUrls:    data.ImageUrls,
-		Description:  data.Description,
-		TechStack:    data.TechStack,
-		GithubLink:   data.GithubLink,
-		Type:         data.Type,
-		Contribution: data.Contribution,
-		ProjectLink:  data.ProjectLink,
-	}).Error
+	return database.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		var current models.Project
+		if err := tx.Select("id", "slug").First(&current, id).Error; err != nil {
+			return err
+		}
+
+		if data.Slug != "" && data.Slug != current.Slug {
+			if err := changeProjectSlug(tx, id, current.Slug, data.Slug); err != nil {
+				return err
+			}
+		}
+
+		err := tx.Model(&models.Project{}).Where("id = ?", id).
+			Select("name", "image_urls", "description", "body", "tech_stack", "github_link", "type", "contribution", "project_link", "updated_at").
+			Updates(&models.Project{
+				Name:         data.Name,
+				ImageUrls:    data.ImageUrls,
+				Description:  data.Description,
+				Body:         data.Body,
+				TechStack:    data.TechStack,
+				GithubLink:   data.GithubLink,
+				Type:         data.Type,
+				Contribution: data.Contribution,
+				ProjectLink:  data.ProjectLink,
+			}).Error
+		if err != nil {
+			return err
+		}
+		if err := technology.ProjectLinks.Link(tx, id, data.TechStack); err != nil {
+			return err
+		}
+		return search.Projects.Refresh(tx, id)
+	})
 }
 
+// changeProjectSlug moves the project to a new slug. The new slug stops
+// redirecting anywhere else and the old one starts redirecting here.
+func changeProjectSlug(tx *gorm.DB, id uint, from, to string) error {
+	if err := tx.Where("slug = ?", to).Delete(&models.ProjectSlug{}).Error; err != nil {
+		return err
+	}
+	if from != "" {
+		if err := tx.Create(&models.ProjectSlug{ProjectID: id, Slug: from}).Error; err != nil {
+			return err
+		}
+	}
+	return tx.Model(&models.Project{}).Where("id = ?", id).Update("slug", to).Error
+}
+
+func (r *GormProjectRepository) SetProjectStatus(ctx context.Context, id uint, status models.PublishStatus) error {
+	return database.Conn(ctx, r.db).Model(&models.Project{}).Where("id = ?", id).Update("status", status).Error
+}
+
+func (r *GormProjectRepository) GetProjectSchedule(ctx context.Context, id uint) (*models.Schedule, error) {
+	var p models.Project
+	if err := database.Conn(ctx, r.db).First(&p, id).Error; err != nil {
+		return nil, err
+	}
+	return &p.Schedule, nil
+}
+
+func (r *GormProjectRepository) SetProjectSchedule(ctx context.Context, id uint, schedule models.Schedule) error {
+	result := database.Conn(ctx, r.db).Model(&models.Project{}).Where("id = ?", id).
+		Select("publish_at", "unpublish_at").
+		Updates(&models.Project{Schedule: schedule})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// GetDueProjects returns the IDs of the projects whose publish or unpublish
+// time has come. Trashed projects are left out, their schedule waits for a restore.
+func (r *GormProjectRepository) GetDueProjects(ctx context.Context, now time.Time) (publish []uint, unpublish []uint, err error) {
+	err = database.Conn(ctx, r.db).Model(&models.Project{}).
+		Where("publish_at <= ?", now).
+		Order("publish_at").
+		Pluck("id", &publish).Error
+	if err != nil {
+		return nil, nil, err
+	}
+	err = database.Conn(ctx, r.db).Model(&models.Project{}).
+		Where("unpublish_at <= ?", now).
+		Order("unpublish_at").
+		Pluck("id", &unpublish).Error
+	if err != nil {
+		return nil, nil, err
+	}
+	return publish, unpublish, nil
+}
+
+// DeleteProject moves the project to the trash. Its draft, revisions and
+// slugs stay until the trash is purged.
 func (r *GormProjectRepository) DeleteProject(ctx context.Context, id uint) error {
-	return r.db.WithContext(ctx).Where("id = ?", id).Unscoped().Delete(&models.Project{}).Error
+	return database.Conn(ctx, r.db).Where("id = ?", id).Delete(&models.Project{}).Error
+}
+
+func (r *GormProjectRepository) GetTrashedProjects(ctx context.Context) ([]TrashedProjectDto, error) {
+	var projects []models.Project
+	if err := database.Conn(ctx, r.db).Scopes(models.Trashed).Order("deleted_at DESC").Find(&projects).Error; err != nil {
+		return nil, err
+	}
+	trashed := make([]TrashedProjectDto, 0, len(projects))
+	for _, p := range projects {
+		trashed = append(trashed, TrashedProjectDto{
+			ProjectItemDto: toProjectItemDto(p),
+			DeletedAt:      p.DeletedAt.Time,
+		})
+	}
+	return trashed, nil
+}
+
+// RestoreTrashedProject takes the project out of the trash
+func (r *GormProjectRepository) RestoreTrashedProject(ctx context.Context, id uint) error {
+	return database.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := lockProjectOrder(tx); err != nil {
+			return err
+		}
+
+		result := tx.Model(&models.Project{}).
+			Scopes(models.Trashed).
+			Where("id = ?", id).
+			Update("deleted_at", nil)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+}
+
+// PurgeProjects permanently deletes the projects trashed before the given
+// time, along with their links, old slugs, drafts, revisions and translations
+func (r *GormProjectRepository) PurgeProjects(ctx context.Context, before time.Time) (int64, error) {
+	var purged int64
+	err := database.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		trashed := tx.Model(&models.Project{}).Scopes(models.TrashedBefore(before)).Select("id")
+		if err := technology.ProjectLinks.Unlink(tx, trashed); err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM skill_projects WHERE project_id IN (?)", trashed).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("project_id IN (?)", trashed).Delete(&models.ProjectSlug{}).Error; err != nil {
+			return err
+		}
+		if err := models.PurgeEntityContent(tx, models.EntityProject, trashed); err != nil {
+			return err
+		}
+		result := tx.Scopes(models.TrashedBefore(before)).Delete(&models.Project{})
+		purged = result.RowsAffected
+		return result.Error
+	})
+	return purged, err
+}
+
+// GetProjectSkills returns the live skills linked to the project, by name
+func (r *GormProjectRepository) GetProjectSkills(ctx context.Context, id uint) ([]SkillRefDto, error) {
+	skills := []SkillRefDto{}
+	err := database.Conn(ctx, r.db).Model(&models.TechnicalSkills{}).
+		Select("technical_skills.id, technical_skills.name, technical_skills.level, technical_skills.category").
+		Joins("JOIN skill_projects sp ON sp.technical_skills_id = technical_skills.id").
+		Where("sp.project_id = ?", id).
+		Order("technical_skills.name").
+		Scan(&skills).Error
+	return skills, err
+}
+
+// GetProjectsWithoutTechnologies returns the projects listing technologies
+// they are not linked to yet, from before technologies were tracked
+func (r *GormProjectRepository) GetProjectsWithoutTechnologies(ctx context.Context) ([]ProjectItemDto, error) {
+	var projects []models.Project
+	err := database.Conn(ctx, r.db).Unscoped().
+		Where("cardinality(tech_stack) > 0").
+		Where("NOT EXISTS (SELECT 1 FROM project_technologies pt WHERE pt.project_id = projects.id)").
+		Find(&projects).Error
+	if err != nil {
+		return nil, err
+	}
+	dtos := make([]ProjectItemDto, 0, len(projects))
+	for _, p := range projects {
+		dtos = append(dtos, toProjectItemDto(p))
+	}
+	return dtos, nil
+}
+
+// SetProjectTechnologies stores the canonical tech stack of a project and links it
+func (r *GormProjectRepository) SetProjectTechnologies(ctx context.Context, id uint, names []string) error {
+	return database.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		err := tx.Unscoped().Model(&models.Project{}).Where("id = ?", id).
+			UpdateColumn("tech_stack", pq.StringArray(names)).Error
+		if err != nil {
+			return err
+		}
+		if err := technology.ProjectLinks.Link(tx, id, names); err != nil {
+			return err
+		}
+		return search.Projects.Refresh(tx, id)
+	})
 }
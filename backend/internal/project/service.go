@@ -2,36 +2,761 @@ package project
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/othersidedrl/portfolio/backend/internal/audit"
+	"github.com/othersidedrl/portfolio/backend/internal/content"
+	"github.com/othersidedrl/portfolio/backend/internal/markdown"
+	"github.com/othersidedrl/portfolio/backend/internal/models"
+	"github.com/othersidedrl/portfolio/backend/internal/technology"
+	"github.com/othersidedrl/portfolio/backend/internal/translation"
+	"github.com/othersidedrl/portfolio/backend/internal/utils"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrInvalidSlug  = errors.New("slug may only contain lowercase letters, digits and single hyphens")
+	ErrSlugTaken    = errors.New("slug is already used by another project")
+	ErrInvalidOrder = errors.New("order must list every project exactly once")
 )
 
 type Service struct {
-	repo ProjectRepository
+	repo         ProjectRepository
+	versions     *content.Service
+	translations *translation.Service
+	technologies *technology.Service
+	recorder     audit.Recorder
 }
 
-func NewService(repo ProjectRepository) *Service {
-	return &Service{repo}
+func NewService(repo ProjectRepository, versions *content.Service, translations *translation.Service, technologies *technology.Service, recorder audit.Recorder) *Service {
+	return &Service{repo, versions, translations, technologies, recorder}
 }
 
 func (s *Service) GetProjectPage(ctx context.Context) (*ProjectPageDto, error) {
 	return s.repo.GetProjectPage(ctx)
 }
 
-func (s *Service) UpdateProjectPage(ctx context.Context, data *ProjectPageDto) error {
-	return s.repo.UpdateProjectPage(ctx, data)
+// GetPublicProjectPage returns the project page for the public site
+func (s *Service) GetPublicProjectPage(ctx context.Context) (*ProjectPageDto, error) {
+	page, err := s.repo.GetPublicProjectPage(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.translations.Translate(ctx, models.EntityProjectPage, 0, pageFields(page)); err != nil {
+		return nil, err
+	}
+	return page, nil
+}
+
+// TranslatableFields lists the project page and project text that can be translated
+func (s *Service) TranslatableFields(ctx context.Context) ([]translation.SourceField, error) {
+	var fields []translation.SourceField
+
+	page, err := s.repo.GetProjectPage(ctx)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	if page != nil {
+		fields = append(fields, translation.SourceFields(models.EntityProjectPage, 0, pageFields(page))...)
+	}
+
+	projects, err := s.repo.GetAllProjects(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for id, project := range projectFields(projects.Projects) {
+		fields = append(fields, translation.SourceFields(models.EntityProject, id, project)...)
+	}
+	return fields, nil
+}
+
+func pageFields(page *ProjectPageDto) translation.Fields {
+	return translation.Fields{"title": &page.Title, "description": &page.Description}
+}
+
+func projectFields(projects []ProjectItemDto) map[uint]translation.Fields {
+	fields := make(map[uint]translation.Fields, len(projects))
+	for i := range projects {
+		fields[uint(projects[i].ID)] = translation.Fields{
+			"description": &projects[i].Description,
+			"body":        &projects[i].Body,
+		}
+	}
+	return fields
+}
+
+func (s *Service) GetProjectPageSchedule(ctx context.Context) (*content.ScheduleDto, error) {
+	schedule, err := s.repo.GetProjectPageSchedule(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return content.ToScheduleDto(*schedule), nil
+}
+
+// SetProjectPageSchedule sets when the project page draft goes live and when the page goes offline
+func (s *Service) SetProjectPageSchedule(ctx context.Context, data content.ScheduleDto) error {
+	schedule, err := content.ToSchedule(data)
+	if err != nil {
+		return err
+	}
+
+	before, err := s.GetProjectPageSchedule(ctx)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.SetProjectPageSchedule(ctx, schedule); err != nil {
+		return err
+	}
+
+	s.recorder.Record(ctx, models.AuditSchedule, models.EntityProjectPage, nil, before, data)
+	return nil
+}
+
+// RunProjectPageSchedule publishes the project page draft once its publish time has come. It
+// reports whether the public page changed since the previous run.
+func (s *Service) RunProjectPageSchedule(ctx context.Context, since, now time.Time) (bool, error) {
+	schedule, err := s.repo.GetProjectPageSchedule(ctx)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	changed := schedule.UnpublishedBetween(since, now)
+	if schedule.PublishDue(now) {
+		if err := s.PublishProjectPage(ctx); err != nil && !errors.Is(err, content.ErrNoDraft) {
+			return false, err
+		}
+		schedule.PublishAt = nil
+		if err := s.repo.SetProjectPageSchedule(ctx, *schedule); err != nil {
+			return false, err
+		}
+		changed = true
+	}
+	return changed, nil
+}
+
+// SaveProjectPageDraft stores an edit of the project page without changing the live page
+func (s *Service) SaveProjectPageDraft(ctx context.Context, data *ProjectPageDto) error {
+	return s.versions.SaveDraft(ctx, models.EntityProjectPage, 0, data)
+}
+
+// PreviewProjectPage returns the draft of the project page, or the live page when there is no draft
+func (s *Service) PreviewProjectPage(ctx context.Context) (*ProjectPageDto, error) {
+	var draft ProjectPageDto
+	found, err := s.versions.LoadDraft(ctx, models.EntityProjectPage, 0, &draft)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return s.repo.GetProjectPage(ctx)
+	}
+	return &draft, nil
+}
+
+// PublishProjectPage makes the draft the live project page
+func (s *Service) PublishProjectPage(ctx context.Context) error {
+	var draft ProjectPageDto
+	found, err := s.versions.LoadDraft(ctx, models.EntityProjectPage, 0, &draft)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return content.ErrNoDraft
+	}
+
+	if err := s.applyProjectPage(ctx, &draft, models.AuditPublish); err != nil {
+		return err
+	}
+	return s.versions.DeleteDraft(ctx, models.EntityProjectPage, 0)
+}
+
+// RestoreProjectPage rolls the live project page back to one of its revisions
+func (s *Service) RestoreProjectPage(ctx context.Context, revisionID uint) error {
+	var revision ProjectPageDto
+	if err := s.versions.LoadRevision(ctx, models.EntityProjectPage, 0, revisionID, &revision); err != nil {
+		return err
+	}
+	return s.applyProjectPage(ctx, &revision, models.AuditRestore)
+}
+
+// applyProjectPage writes data to the live project page and keeps a revision of the result
+func (s *Service) applyProjectPage(ctx context.Context, data *ProjectPageDto, action models.AuditAction) error {
+	before, err := s.repo.GetProjectPage(ctx)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	if err := s.repo.UpdateProjectPage(ctx, data); err != nil {
+		return err
+	}
+
+	after, err := s.repo.GetProjectPage(ctx)
+	if err != nil {
+		return err
+	}
+	if err := s.versions.SaveRevision(ctx, models.EntityProjectPage, 0, before, after); err != nil {
+		return err
+	}
+	s.recorder.Record(ctx, action, models.EntityProjectPage, nil, before, after)
+	return nil
+}
+
+// DiscardProjectPageDraft throws away the unpublished changes to the project page
+func (s *Service) DiscardProjectPageDraft(ctx context.Context) error {
+	return s.versions.DiscardDraft(ctx, models.EntityProjectPage, 0)
+}
+
+// GetProjects returns one page of the public project list
+func (s *Service) GetProjects(ctx context.Context, query ProjectQuery) (*ProjectDto, error) {
+	// Tech stacks are stored by canonical name, so aliases match too
+	if len(query.Tech) > 0 {
+		techs, err := s.CanonicalTech(ctx, query.Tech)
+		if err != nil {
+			return nil, err
+		}
+		query.Tech = techs
+	}
+
+	projects, next, err := s.repo.GetProjects(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	projects.Pagination = &PaginationDto{Limit: query.Limit, HasMore: next != nil}
+	if next != nil {
+		if projects.Pagination.NextCursor, err = encodeCursor(next); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.forPublic(ctx, projects)
+}
+
+// CanonicalTech maps technology names and aliases to the names tech stacks
+// are stored with. Unknown names are kept as written.
+func (s *Service) CanonicalTech(ctx context.Context, names []string) ([]string, error) {
+	return s.technologies.Canonical(ctx, names)
+}
+
+// GetFeaturedProjects returns the public projects pinned to the landing page
+func (s *Service) GetFeaturedProjects(ctx context.Context) (*ProjectDto, error) {
+	projects, err := s.repo.GetFeaturedProjects(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.forPublic(ctx, projects)
+}
+
+// GetPublicProjects returns every public project, unpaged, in their manual order
+func (s *Service) GetPublicProjects(ctx context.Context) (*ProjectDto, error) {
+	projects, err := s.repo.GetPublicProjects(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.forPublic(ctx, projects)
+}
+
+// forPublic translates a public project list. The case study body is only
+// sent with the project detail.
+func (s *Service) forPublic(ctx context.Context, projects *ProjectDto) (*ProjectDto, error) {
+	for i := range projects.Projects {
+		projects.Projects[i].Body = ""
+	}
+	if err := s.translations.TranslateMany(ctx, models.EntityProject, projectFields(projects.Projects)); err != nil {
+		return nil, err
+	}
+	return projects, nil
+}
+
+// ReorderProjects puts the projects in the given order, which must list all of them
+func (s *Service) ReorderProjects(ctx context.Context, data ProjectOrderDto) error {
+	before, err := s.repo.GetProjectOrder(ctx)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.ReorderProjects(ctx, data.IDs); err != nil {
+		return err
+	}
+	s.recorder.Record(ctx, models.AuditReorder, models.EntityProject, nil, ProjectOrderDto{IDs: before}, data)
+	return nil
+}
+
+// SetProjectFeatured pins the project to the landing page or unpins it
+func (s *Service) SetProjectFeatured(ctx context.Context, id uint, featured bool) error {
+	before, err := s.repo.GetProject(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.SetProjectFeatured(ctx, id, featured); err != nil {
+		return err
+	}
+
+	after, err := s.repo.GetProject(ctx, id)
+	if err != nil {
+		return err
+	}
+	s.recorder.Record(ctx, models.AuditUpdate, models.EntityProject, id, before, after)
+	return nil
+}
+
+// GetProjectBySlug returns a public project. When the slug belonged to a
+// project that has since been renamed, it returns the current slug instead.
+func (s *Service) GetProjectBySlug(ctx context.Context, slug string) (*ProjectDetailDto, string, error) {
+	project, err := s.repo.GetPublicProjectBySlug(ctx, slug)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		current, redirectErr := s.repo.FindSlugRedirect(ctx, slug)
+		if redirectErr != nil {
+			return nil, "", redirectErr
+		}
+		return nil, current, nil
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	projects := []ProjectItemDto{*project}
+	if err := s.translations.TranslateMany(ctx, models.EntityProject, projectFields(projects)); err != nil {
+		return nil, "", err
+	}
+
+	detail, err := s.renderProject(ctx, &projects[0])
+	if err != nil {
+		return nil, "", err
+	}
+	detail.Body = ""
+	return detail, "", nil
+}
+
+// renderProject renders the project's Markdown body and adds the skills
+// it shows in use
+func (s *Service) renderProject(ctx context.Context, project *ProjectItemDto) (*ProjectDetailDto, error) {
+	doc, err := markdown.Render(project.Body)
+	if err != nil {
+		return nil, err
+	}
+	skills, err := s.repo.GetProjectSkills(ctx, uint(project.ID))
+	if err != nil {
+		return nil, err
+	}
+	return &ProjectDetailDto{
+		ProjectItemDto: *project,
+		BodyHTML:       doc.HTML,
+		TOC:            doc.TOC,
+		SkillsUsed:     skills,
+	}, nil
 }
 
-func (s *Service) GetProjects(ctx context.Context) (*ProjectDto, error) {
-	return s.repo.GetProjects(ctx)
+// GetAllProjects also returns the unpublished projects, for the admin
+func (s *Service) GetAllProjects(ctx context.Context) (*ProjectDto, error) {
+	return s.repo.GetAllProjects(ctx)
 }
 
 func (s *Service) CreateProject(ctx context.Context, data *ProjectItemDto) error {
-	return s.repo.CreateProject(ctx, data)
+	if err := s.resolve(ctx, data, 0, ""); err != nil {
+		return err
+	}
+	if err := s.repo.CreateProject(ctx, data); err != nil {
+		return err
+	}
+
+	after, err := s.repo.GetProject(ctx, uint(data.ID))
+	if err != nil {
+		return err
+	}
+	if err := s.versions.SaveRevision(ctx, models.EntityProject, uint(data.ID), nil, after); err != nil {
+		return err
+	}
+	s.recorder.Record(ctx, models.AuditCreate, models.EntityProject, data.ID, nil, after)
+	return nil
+}
+
+// SaveProjectDraft stores an edit of a project without changing the live project
+func (s *Service) SaveProjectDraft(ctx context.Context, data *ProjectItemDto, id uint) error {
+	live, err := s.repo.GetProject(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.resolve(ctx, data, id, live.Slug); err != nil {
+		return err
+	}
+	return s.versions.SaveDraft(ctx, models.EntityProject, id, data)
+}
+
+// resolve fills in the slug and swaps the tech stack for the canonical
+// technology names before a project is written
+func (s *Service) resolve(ctx context.Context, data *ProjectItemDto, id uint, currentSlug string) error {
+	if err := s.resolveSlug(ctx, data, id, currentSlug); err != nil {
+		return err
+	}
+	techStack, err := s.technologies.Resolve(ctx, data.TechStack)
+	if err != nil {
+		return err
+	}
+	data.TechStack = techStack
+	return nil
+}
+
+// resolveSlug fills in or checks data.Slug. An empty slug keeps the current
+// one, or is generated from the name when there is none yet.
+func (s *Service) resolveSlug(ctx context.Context, data *ProjectItemDto, id uint, current string) error {
+	if data.Slug == "" {
+		if current != "" {
+			data.Slug = current
+			return nil
+		}
+		slug, err := s.uniqueSlug(ctx, data.Name, id)
+		if err != nil {
+			return err
+		}
+		data.Slug = slug
+		return nil
+	}
+
+	if !utils.ValidSlug(data.Slug) {
+		return ErrInvalidSlug
+	}
+	taken, err := s.repo.SlugTaken(ctx, data.Slug, id)
+	if err != nil {
+		return err
+	}
+	if taken {
+		return ErrSlugTaken
+	}
+	return nil
+}
+
+// uniqueSlug derives a free slug from a project name, numbering it when
+// another project already has it
+func (s *Service) uniqueSlug(ctx context.Context, name string, id uint) (string, error) {
+	base := utils.Slugify(name)
+	if base == "" {
+		base = "project"
+	}
+
+	slug := base
+	for n := 2; ; n++ {
+		taken, err := s.repo.SlugTaken(ctx, slug, id)
+		if err != nil {
+			return "", err
+		}
+		if !taken {
+			return slug, nil
+		}
+		suffix := fmt.Sprintf("-%d", n)
+		if len(base)+len(suffix) > utils.MaxSlugLength {
+			base = strings.TrimSuffix(base[:utils.MaxSlugLength-len(suffix)], "-")
+		}
+		slug = base + suffix
+	}
+}
+
+// EnsureSlugs gives every project created before slugs existed one derived from its name
+func (s *Service) EnsureSlugs(ctx context.Context) error {
+	projects, err := s.repo.GetProjectsWithoutSlug(ctx)
+	if err != nil {
+		return err
+	}
+	for _, project := range projects {
+		slug, err := s.uniqueSlug(ctx, project.Name, uint(project.ID))
+		if err != nil {
+			return err
+		}
+		if err := s.repo.SetProjectSlug(ctx, uint(project.ID), slug); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
-func (s *Service) UpdateProject(ctx context.Context, data *ProjectItemDto, id uint) error {
-	return s.repo.UpdateProject(ctx, data, id)
+// EnsureTechnologies links every project created before technologies were
+// tracked to the technologies in its tech stack
+func (s *Service) EnsureTechnologies(ctx context.Context) error {
+	projects, err := s.repo.GetProjectsWithoutTechnologies(ctx)
+	if err != nil {
+		return err
+	}
+	for _, project := range projects {
+		techStack, err := s.technologies.Resolve(ctx, project.TechStack)
+		if err != nil {
+			return err
+		}
+		if err := s.repo.SetProjectTechnologies(ctx, uint(project.ID), techStack); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PreviewProject returns the project with its draft applied, with both the
+// raw Markdown body and how it renders
+func (s *Service) PreviewProject(ctx context.Context, id uint) (*ProjectDetailDto, error) {
+	live, err := s.repo.GetProject(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var draft ProjectItemDto
+	found, err := s.versions.LoadDraft(ctx, models.EntityProject, id, &draft)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return s.renderProject(ctx, live)
+	}
+	draft.ID = live.ID
+	draft.Status = live.Status
+	return s.renderProject(ctx, &draft)
+}
+
+// PublishProject applies the project's draft, if there is one, and makes
+// the project public
+func (s *Service) PublishProject(ctx context.Context, id uint) error {
+	before, err := s.repo.GetProject(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	var draft ProjectItemDto
+	found, err := s.versions.LoadDraft(ctx, models.EntityProject, id, &draft)
+	if err != nil {
+		return err
+	}
+	if !found && before.Status == models.Published {
+		return content.ErrNothingToPublish
+	}
+
+	if found {
+		// The slug may have been taken since the draft was saved
+		if err := s.resolve(ctx, &draft, id, before.Slug); err != nil {
+			return err
+		}
+		if err := s.repo.UpdateProject(ctx, &draft, id); err != nil {
+			return err
+		}
+	}
+	if err := s.repo.SetProjectStatus(ctx, id, models.Published); err != nil {
+		return err
+	}
+	if err := s.versions.DeleteDraft(ctx, models.EntityProject, id); err != nil {
+		return err
+	}
+
+	after, err := s.repo.GetProject(ctx, id)
+	if err != nil {
+		return err
+	}
+	if found {
+		if err := s.versions.SaveRevision(ctx, models.EntityProject, id, before, after); err != nil {
+			return err
+		}
+	}
+	s.recorder.Record(ctx, models.AuditPublish, models.EntityProject, id, before, after)
+	return nil
+}
+
+// RestoreProject rolls the project's content back to one of its revisions.
+// Whether the project is published does not change.
+func (s *Service) RestoreProject(ctx context.Context, id, revisionID uint) error {
+	before, err := s.repo.GetProject(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	var revision ProjectItemDto
+	if err := s.versions.LoadRevision(ctx, models.EntityProject, id, revisionID, &revision); err != nil {
+		return err
+	}
+	if err := s.resolve(ctx, &revision, id, before.Slug); err != nil {
+		return err
+	}
+	if err := s.repo.UpdateProject(ctx, &revision, id); err != nil {
+		return err
+	}
+
+	after, err := s.repo.GetProject(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.versions.SaveRevision(ctx, models.EntityProject, id, before, after); err != nil {
+		return err
+	}
+	s.recorder.Record(ctx, models.AuditRestore, models.EntityProject, id, before, after)
+	return nil
+}
+
+// ImportProject writes imported content straight to the project. Whether
+// the project is published does not change, and a pending draft is kept.
+func (s *Service) ImportProject(ctx context.Context, data *ProjectItemDto, id uint) error {
+	before, err := s.repo.GetProject(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.resolve(ctx, data, id, before.Slug); err != nil {
+		return err
+	}
+	if err := s.repo.UpdateProject(ctx, data, id); err != nil {
+		return err
+	}
+
+	after, err := s.repo.GetProject(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.versions.SaveRevision(ctx, models.EntityProject, id, before, after); err != nil {
+		return err
+	}
+	s.recorder.Record(ctx, models.AuditImport, models.EntityProject, id, before, after)
+	return nil
+}
+
+// UnpublishProject hides the project from the public site and keeps its draft
+func (s *Service) UnpublishProject(ctx context.Context, id uint) error {
+	before, err := s.repo.GetProject(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.SetProjectStatus(ctx, id, models.Unpublished); err != nil {
+		return err
+	}
+
+	after, err := s.repo.GetProject(ctx, id)
+	if err != nil {
+		return err
+	}
+	s.recorder.Record(ctx, models.AuditUnpublish, models.EntityProject, id, before, after)
+	return nil
+}
+
+func (s *Service) GetProjectSchedule(ctx context.Context, id uint) (*content.ScheduleDto, error) {
+	schedule, err := s.repo.GetProjectSchedule(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return content.ToScheduleDto(*schedule), nil
+}
+
+// SetProjectSchedule sets when the project and its draft go live and when
+// the project goes offline
+func (s *Service) SetProjectSchedule(ctx context.Context, id uint, data content.ScheduleDto) error {
+	schedule, err := content.ToSchedule(data)
+	if err != nil {
+		return err
+	}
+
+	before, err := s.GetProjectSchedule(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.SetProjectSchedule(ctx, id, schedule); err != nil {
+		return err
+	}
+
+	s.recorder.Record(ctx, models.AuditSchedule, models.EntityProject, id, before, data)
+	return nil
+}
+
+// RunProjectSchedule publishes and unpublishes the projects whose time has
+// come and reports whether any of them changed. Trashed projects are
+// skipped. A project that fails is logged and keeps its schedule for the
+// next run, without holding up the others; the failures are returned together.
+func (s *Service) RunProjectSchedule(ctx context.Context, since, now time.Time) (bool, error) {
+	publish, unpublish, err := s.repo.GetDueProjects(ctx, now)
+	if err != nil {
+		return false, err
+	}
+
+	changed := false
+	var errs []error
+	run := func(id uint, action string, apply func(ctx context.Context, id uint) error, publish bool) {
+		err := apply(ctx, id)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// Trashed since it was listed, the schedule waits for a restore
+			return
+		}
+		if err != nil && !errors.Is(err, content.ErrNothingToPublish) {
+			log.Printf("⚠️ Failed to %s project %d: %v", action, id, err)
+			errs = append(errs, fmt.Errorf("%s project %d: %w", action, id, err))
+			return
+		}
+		changed = true
+		if err := s.clearProjectSchedule(ctx, id, publish, !publish); err != nil {
+			log.Printf("⚠️ Failed to clear the %s time of project %d: %v", action, id, err)
+			errs = append(errs, fmt.Errorf("clear %s time of project %d: %w", action, id, err))
+		}
+	}
+	for _, id := range publish {
+		run(id, "publish", s.PublishProject, true)
+	}
+	for _, id := range unpublish {
+		run(id, "unpublish", s.UnpublishProject, false)
+	}
+	return changed, errors.Join(errs...)
+}
+
+// clearProjectSchedule removes the publish or unpublish time once it has run
+func (s *Service) clearProjectSchedule(ctx context.Context, id uint, publish, unpublish bool) error {
+	schedule, err := s.repo.GetProjectSchedule(ctx, id)
+	if err != nil {
+		return err
+	}
+	if publish {
+		schedule.PublishAt = nil
+	}
+	if unpublish {
+		schedule.UnpublishAt = nil
+	}
+	return s.repo.SetProjectSchedule(ctx, id, *schedule)
+}
+
+// DiscardProjectDraft throws away the unpublished changes to a project
+func (s *Service) DiscardProjectDraft(ctx context.Context, id uint) error {
+	return s.versions.DiscardDraft(ctx, models.EntityProject, id)
 }
 
 func (s *Service) DeleteProject(ctx context.Context, id uint) error {
-	return s.repo.DeleteProject(ctx, id)
+	before, err := s.repo.GetProject(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// The draft stays so a restored project comes back with it; purging
+	// the trash deletes it
+	if err := s.repo.DeleteProject(ctx, id); err != nil {
+		return err
+	}
+
+	s.recorder.Record(ctx, models.AuditDelete, models.EntityProject, id, before, nil)
+	return nil
+}
+
+// GetTrashedProjects lists the deleted projects that have not been purged yet
+func (s *Service) GetTrashedProjects(ctx context.Context) ([]TrashedProjectDto, error) {
+	return s.repo.GetTrashedProjects(ctx)
+}
+
+// RestoreTrashedProject brings a deleted project back as it was
+func (s *Service) RestoreTrashedProject(ctx context.Context, id uint) error {
+	if err := s.repo.RestoreTrashedProject(ctx, id); err != nil {
+		return err
+	}
+
+	after, err := s.repo.GetProject(ctx, id)
+	if err != nil {
+		return err
+	}
+	s.recorder.Record(ctx, models.AuditUndelete, models.EntityProject, id, nil, after)
+	return nil
+}
+
+// PurgeProjects permanently deletes the projects trashed before the given time
+func (s *Service) PurgeProjects(ctx context.Context, before time.Time) (int64, error) {
+	return s.repo.PurgeProjects(ctx, before)
 }
@@ -0,0 +1,88 @@
+package project
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/othersidedrl/portfolio/backend/internal/content"
+	"github.com/othersidedrl/portfolio/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// fakeProjectRepository holds live and trashed projects. Methods the tests
+// do not use panic through the nil embedded interface.
+type fakeProjectRepository struct {
+	ProjectRepository
+	trashed map[uint]bool
+	due     []uint
+}
+
+func (r *fakeProjectRepository) GetProject(ctx context.Context, id uint) (*ProjectItemDto, error) {
+	if r.trashed[id] {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &ProjectItemDto{ID: int(id), Name: "Portfolio", Status: models.Unpublished}, nil
+}
+
+func (r *fakeProjectRepository) DeleteProject(ctx context.Context, id uint) error {
+	r.trashed[id] = true
+	return nil
+}
+
+func (r *fakeProjectRepository) GetDueProjects(ctx context.Context, now time.Time) ([]uint, []uint, error) {
+	return r.due, nil, nil
+}
+
+// fakeDraftRepository holds drafts by entity ID
+type fakeDraftRepository struct {
+	content.DraftRepository
+	drafts map[uint]bool
+}
+
+func (r *fakeDraftRepository) FindDraft(ctx context.Context, entityType models.EntityType, entityID uint) (*models.ContentDraft, error) {
+	if !r.drafts[entityID] {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &models.ContentDraft{EntityType: entityType, EntityID: entityID, Data: []byte(`{"name":"Portfolio v2"}`)}, nil
+}
+
+func (r *fakeDraftRepository) DeleteDraft(ctx context.Context, entityType models.EntityType, entityID uint) (bool, error) {
+	found := r.drafts[entityID]
+	delete(r.drafts, entityID)
+	return found, nil
+}
+
+func TestDeleteProjectKeepsDraft(t *testing.T) {
+	repo := &fakeProjectRepository{trashed: map[uint]bool{}}
+	drafts := &fakeDraftRepository{drafts: map[uint]bool{1: true}}
+	service := NewService(repo, content.NewService(drafts, nil, nopRecorder{}), nil, nil, nopRecorder{})
+
+	if err := service.DeleteProject(context.Background(), 1); err != nil {
+		t.Fatal(err)
+	}
+	if !repo.trashed[1] {
+		t.Error("project not trashed")
+	}
+	if !drafts.drafts[1] {
+		t.Error("trashing the project deleted its draft")
+	}
+}
+
+func TestRunProjectScheduleSkipsTrashed(t *testing.T) {
+	// Listed as due, then trashed before the run got to it
+	repo := &fakeProjectRepository{trashed: map[uint]bool{1: true}, due: []uint{1}}
+	drafts := &fakeDraftRepository{drafts: map[uint]bool{1: true}}
+	service := NewService(repo, content.NewService(drafts, nil, nopRecorder{}), nil, nil, nopRecorder{})
+
+	changed, err := service.RunProjectSchedule(context.Background(), time.Time{}, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if changed {
+		t.Error("a trashed project was published")
+	}
+	if !drafts.drafts[1] {
+		t.Error("the trashed project's draft was used up")
+	}
+}
@@ -0,0 +1,97 @@
+package resume
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/othersidedrl/portfolio/backend/internal/project"
+	"github.com/othersidedrl/portfolio/backend/internal/technology"
+	"github.com/othersidedrl/portfolio/backend/internal/utils"
+)
+
+type Handler struct {
+	service *Service
+}
+
+func NewHandler(service *Service) *Handler {
+	return &Handler{service}
+}
+
+// GetResume serves the public site as a JSON Resume document
+func (h *Handler) GetResume(w http.ResponseWriter, r *http.Request) {
+	resume, err := h.service.Export(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resume)
+}
+
+// ImportResume reads a JSON Resume document, sent as the request body or
+// uploaded as the "file" form field. With ?dry_run=true it only returns
+// what would change.
+func (h *Handler) ImportResume(w http.ResponseWriter, r *http.Request) {
+	dryRun, err := parseDryRun(r)
+	if err != nil {
+		http.Error(w, "Invalid dry_run, expected true or false", http.StatusBadRequest)
+		return
+	}
+
+	var body io.Reader = r.Body
+	if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType == "multipart/form-data" {
+		file, _, err := r.FormFile("file")
+		if err != nil {
+			http.Error(w, "Failed to get file: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		defer file.Close()
+		body = file
+	}
+
+	// Unknown fields are allowed, JSON Resume has sections the site does not use
+	var doc Resume
+	if err := json.NewDecoder(body).Decode(&doc); err != nil {
+		http.Error(w, utils.ErrInvalidJSON.Error(), http.StatusBadRequest)
+		return
+	}
+
+	result, err := h.service.Import(r.Context(), doc, dryRun)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+// IsDryRun reports whether an import request only asks for the changes
+func IsDryRun(r *http.Request) bool {
+	dryRun, err := parseDryRun(r)
+	return err == nil && dryRun
+}
+
+func parseDryRun(r *http.Request) (bool, error) {
+	value := r.URL.Query().Get("dry_run")
+	if value == "" {
+		return false, nil
+	}
+	return strconv.ParseBool(value)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, ErrInvalidResume), errors.Is(err, technology.ErrInvalidName):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, project.ErrSlugTaken):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,437 @@
+package resume
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/othersidedrl/portfolio/backend/internal/about"
+	"github.com/othersidedrl/portfolio/backend/internal/database"
+	"github.com/othersidedrl/portfolio/backend/internal/hero"
+	"github.com/othersidedrl/portfolio/backend/internal/models"
+	"github.com/othersidedrl/portfolio/backend/internal/project"
+	"github.com/othersidedrl/portfolio/backend/internal/utils"
+	"gorm.io/gorm"
+)
+
+var ErrInvalidResume = errors.New("invalid resume")
+
+// invalid reports a problem with one entry of the uploaded resume
+func invalid(entry string, index int, reason interface{}) error {
+	return fmt.Errorf("%w: %s[%d]: %v", ErrInvalidResume, entry, index, reason)
+}
+
+// importPlan is the changes an import makes, each with the write applying it
+type importPlan struct {
+	changes []ChangeDto
+	writes  []func(ctx context.Context) error
+}
+
+// add records a change. An entry that changes nothing is listed without a write.
+func (p *importPlan) add(change ChangeDto, before, after interface{}, write func(ctx context.Context) error) error {
+	changes, err := utils.DiffJSON(before, after)
+	if err != nil {
+		return err
+	}
+	change.Changes = changes
+	if change.Action == ActionUpdate && len(changes) == 0 {
+		change.Action = ActionUnchanged
+		write = nil
+	}
+
+	p.changes = append(p.changes, change)
+	if write != nil {
+		p.writes = append(p.writes, write)
+	}
+	return nil
+}
+
+// Import upserts the hero page, about page, careers, skills and projects
+// from a JSON Resume document. Entries are matched to existing content by
+// name, and fields the resume leaves empty keep their current value; nothing
+// is deleted. Every entry is checked before anything is written, the writes
+// commit or roll back together, and a dry run only returns the changes.
+func (s *Service) Import(ctx context.Context, doc Resume, dryRun bool) (*ImportResultDto, error) {
+	plan := &importPlan{}
+	for _, step := range []func(context.Context, Resume, *importPlan) error{
+		s.planHero,
+		s.planAbout,
+		s.planCareers,
+		s.planSkills,
+		s.planProjects,
+	} {
+		if err := step(ctx, doc, plan); err != nil {
+			return nil, err
+		}
+	}
+
+	if !dryRun {
+		err := database.Transaction(ctx, s.db, func(ctx context.Context) error {
+			for _, write := range plan.writes {
+				if err := write(ctx); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	result := &ImportResultDto{
+		DryRun:  dryRun,
+		Summary: map[ChangeAction]int{ActionCreate: 0, ActionUpdate: 0, ActionUnchanged: 0},
+		Changes: plan.changes,
+	}
+	for _, change := range plan.changes {
+		result.Summary[change.Action]++
+	}
+	if result.Changes == nil {
+		result.Changes = []ChangeDto{}
+	}
+	return result, nil
+}
+
+func (s *Service) planHero(ctx context.Context, doc Resume, plan *importPlan) error {
+	current, err := s.hero.Find(ctx)
+	action := ActionUpdate
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		current, action = &hero.HeroPageDto{}, ActionCreate
+	} else if err != nil {
+		return err
+	}
+
+	next := *current
+	basics := doc.Basics
+	setIfPresent(&next.Name, basics.Name)
+	setIfPresent(&next.Title, basics.Label)
+	if image := strings.TrimSpace(basics.Image); image != "" && !slices.Contains(current.ImageUrls, image) {
+		next.ImageUrls = append([]string{image}, current.ImageUrls...)
+	}
+	if email := strings.TrimSpace(basics.Email); email != "" {
+		next.ContactLink = contactEmail + email
+	} else {
+		setIfPresent(&next.ContactLink, basics.URL)
+	}
+	if len(doc.Interests) > 0 {
+		next.Hobbies = make([]string, 0, len(doc.Interests))
+		for _, interest := range doc.Interests {
+			if name := strings.TrimSpace(interest.Name); name != "" {
+				next.Hobbies = append(next.Hobbies, name)
+			}
+		}
+	}
+
+	// A resume without a name has nothing to start a hero page from
+	if action == ActionCreate && next.Name == "" {
+		return nil
+	}
+	var before interface{} = current
+	if action == ActionCreate {
+		before = nil
+	}
+	return plan.add(ChangeDto{Section: SectionHero, Action: action, Key: next.Name}, before, next, func(ctx context.Context) error {
+		return s.hero.Import(ctx, next)
+	})
+}
+
+func (s *Service) planAbout(ctx context.Context, doc Resume, plan *importPlan) error {
+	current, err := s.about.Find(ctx)
+	action := ActionUpdate
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		current, action = &about.AboutPageDto{Cards: []about.CardDto{}}, ActionCreate
+	} else if err != nil {
+		return err
+	}
+
+	next := *current
+	setIfPresent(&next.Description, doc.Basics.Summary)
+	for _, profile := range doc.Basics.Profiles {
+		switch strings.ToLower(strings.TrimSpace(profile.Network)) {
+		case strings.ToLower(networkGitHub):
+			setIfPresent(&next.GithubLink, profile.URL)
+		case strings.ToLower(networkLinkedIn):
+			setIfPresent(&next.LinkedinLink, profile.URL)
+		}
+	}
+
+	if action == ActionCreate && next.Description == "" && next.GithubLink == "" && next.LinkedinLink == "" {
+		return nil
+	}
+	var before interface{} = current
+	if action == ActionCreate {
+		before = nil
+	}
+	return plan.add(ChangeDto{Section: SectionAbout, Action: action, Key: "about"}, before, next, func(ctx context.Context) error {
+		return s.about.ImportAboutPage(ctx, next)
+	})
+}
+
+func (s *Service) planCareers(ctx context.Context, doc Resume, plan *importPlan) error {
+	careers, err := s.about.GetCareers(ctx)
+	if err != nil {
+		return err
+	}
+	existing := make(map[string]about.CareerItemDto, len(careers.Careers))
+	for _, career := range careers.Careers {
+		existing[careerKey(career)] = career
+	}
+
+	type entry struct {
+		name  string
+		index int
+		item  about.CareerItemDto
+	}
+	var entries []entry
+	for i, work := range doc.Work {
+		entries = append(entries, entry{"work", i, about.CareerItemDto{
+			Title:       strings.TrimSpace(work.Position),
+			Affiliation: strings.TrimSpace(work.Name),
+			Description: work.Summary,
+			Location:    work.Location,
+			Type:        string(models.Job),
+			StartedAt:   resumeMonth(work.StartDate),
+			EndedAt:     resumeMonth(work.EndDate),
+			Ongoing:     strings.TrimSpace(work.EndDate) == "",
+		}})
+	}
+	for i, education := range doc.Education {
+		entries = append(entries, entry{"education", i, about.CareerItemDto{
+			Title:       strings.TrimSpace(education.Area),
+			Affiliation: strings.TrimSpace(education.Institution),
+			Type:        string(models.Education),
+			StartedAt:   resumeMonth(education.StartDate),
+			EndedAt:     resumeMonth(education.EndDate),
+			Ongoing:     strings.TrimSpace(education.EndDate) == "",
+		}})
+	}
+
+	seen := make(map[string]bool, len(entries))
+	for _, e := range entries {
+		item := e.item
+		if item.Title == "" || item.Affiliation == "" {
+			return invalid(e.name, e.index, "a name and a title are required")
+		}
+		key := careerKey(item)
+		if seen[key] {
+			return invalid(e.name, e.index, "listed more than once")
+		}
+		seen[key] = true
+		change := ChangeDto{Section: SectionCareer, Key: item.Title + " at " + item.Affiliation}
+
+		current, ok := existing[key]
+		if !ok {
+			if err := about.ValidateCareer(&item); err != nil {
+				return invalid(e.name, e.index, err)
+			}
+			change.Action = ActionCreate
+			err = plan.add(change, nil, item, func(ctx context.Context) error {
+				return s.about.CreateCareer(ctx, item)
+			})
+		} else {
+			merged := about.MergeCareer(current, item)
+			if err := about.ValidateCareer(&merged); err != nil {
+				return invalid(e.name, e.index, err)
+			}
+			change.Action, change.ID = ActionUpdate, current.ID
+			err = plan.add(change, current, merged, func(ctx context.Context) error {
+				return s.about.UpdateCareer(ctx, item, current.ID)
+			})
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Service) planSkills(ctx context.Context, doc Resume, plan *importPlan) error {
+	skills, err := s.about.GetTechnicalSkills(ctx)
+	if err != nil {
+		return err
+	}
+	existing := make(map[string]about.SkillItemDto, len(skills.Skills))
+	for _, skill := range skills.Skills {
+		// Links to projects and careers are left alone
+		skill.ProjectIDs, skill.CareerIDs = nil, nil
+		existing[nameKey(skill.Name)] = skill
+	}
+
+	seen := make(map[string]bool, len(doc.Skills))
+	for i, skill := range doc.Skills {
+		name := strings.TrimSpace(skill.Name)
+		if name == "" {
+			return invalid("skills", i, "a name is required")
+		}
+		if seen[nameKey(name)] {
+			return invalid("skills", i, "listed more than once")
+		}
+		seen[nameKey(name)] = true
+
+		keywords, err := s.technologies.Canonical(ctx, skill.Keywords)
+		if err != nil {
+			return err
+		}
+		level, known := skillLevel(skill.Level)
+		change := ChangeDto{Section: SectionSkill, Key: name}
+
+		current, ok := existing[nameKey(name)]
+		if !ok {
+			if !known {
+				level = models.Intermediate
+			}
+			item := about.SkillItemDto{
+				Name:         name,
+				Specialities: keywords,
+				Level:        string(level),
+				Category:     string(models.Other),
+			}
+			change.Action = ActionCreate
+			err = plan.add(change, nil, item, func(ctx context.Context) error {
+				return s.about.CreateTechnicalSkill(ctx, item)
+			})
+		} else {
+			next := current
+			if known {
+				next.Level = string(level)
+			}
+			if len(keywords) > 0 {
+				next.Specialities = keywords
+			}
+			change.Action, change.ID = ActionUpdate, current.ID
+			err = plan.add(change, current, next, func(ctx context.Context) error {
+				return s.about.UpdateTechnicalSkill(ctx, next, current.ID)
+			})
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Service) planProjects(ctx context.Context, doc Resume, plan *importPlan) error {
+	projects, err := s.projects.GetAllProjects(ctx)
+	if err != nil {
+		return err
+	}
+	existing := make(map[string]project.ProjectItemDto, len(projects.Projects))
+	for _, p := range projects.Projects {
+		existing[nameKey(p.Name)] = p
+	}
+
+	seen := make(map[string]bool, len(doc.Projects))
+	for i, p := range doc.Projects {
+		name := strings.TrimSpace(p.Name)
+		if name == "" {
+			return invalid("projects", i, "a name is required")
+		}
+		if seen[nameKey(name)] {
+			return invalid("projects", i, "listed more than once")
+		}
+		seen[nameKey(name)] = true
+
+		keywords, err := s.technologies.Canonical(ctx, p.Keywords)
+		if err != nil {
+			return err
+		}
+		projectType, known := projectType(p.Type)
+		change := ChangeDto{Section: SectionProject, Key: name}
+
+		current, ok := existing[nameKey(name)]
+		next := current
+		if !ok {
+			// New projects are created unpublished, like any other new project
+			next = project.ProjectItemDto{
+				Name:         name,
+				ImageUrls:    []string{},
+				TechStack:    []string{},
+				Type:         models.Web,
+				Contribution: models.Personal,
+			}
+		}
+		setIfPresent(&next.Description, p.Description)
+		if link := strings.TrimSpace(p.URL); isGitHub(link) {
+			next.GithubLink = link
+		} else {
+			setIfPresent(&next.ProjectLink, link)
+		}
+		if len(keywords) > 0 {
+			next.TechStack = keywords
+		}
+		if known {
+			next.Type = projectType
+		}
+
+		if !ok {
+			change.Action = ActionCreate
+			err = plan.add(change, nil, next, func(ctx context.Context) error {
+				return s.projects.CreateProject(ctx, &next)
+			})
+		} else {
+			change.Action, change.ID = ActionUpdate, uint(current.ID)
+			err = plan.add(change, current, next, func(ctx context.Context) error {
+				return s.projects.ImportProject(ctx, &next, uint(current.ID))
+			})
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setIfPresent overwrites field unless the imported value is blank
+func setIfPresent(field *string, value string) {
+	if value = strings.TrimSpace(value); value != "" {
+		*field = value
+	}
+}
+
+func nameKey(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// careerKey matches career entries by type, affiliation and title
+func careerKey(career about.CareerItemDto) string {
+	return career.Type + "\x00" + nameKey(career.Affiliation) + "\x00" + nameKey(career.Title)
+}
+
+// resumeMonth cuts a JSON Resume date down to what career dates accept.
+// A bare year means its first month.
+func resumeMonth(value string) string {
+	value = strings.TrimSpace(value)
+	if len(value) == len("2006") {
+		return value + "-01"
+	}
+	return value
+}
+
+// skillLevel reads the free-form JSON Resume level into one of the site's levels
+func skillLevel(level string) (models.SkillLevel, bool) {
+	switch nameKey(level) {
+	case "beginner", "novice", "basic", "elementary":
+		return models.Beginner, true
+	case "intermediate", "competent", "proficient":
+		return models.Intermediate, true
+	case "advanced", "expert", "master":
+		return models.Advanced, true
+	}
+	return "", false
+}
+
+func projectType(value string) (models.ProjectType, bool) {
+	for _, t := range []models.ProjectType{models.Web, models.Mobile, models.MachineLearning} {
+		if nameKey(value) == nameKey(string(t)) {
+			return t, true
+		}
+	}
+	return "", false
+}
+
+func isGitHub(link string) bool {
+	return strings.Contains(strings.ToLower(link), "github.com/")
+}
@@ -0,0 +1,175 @@
+package resume
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/othersidedrl/portfolio/backend/internal/about"
+	"github.com/othersidedrl/portfolio/backend/internal/hero"
+	"github.com/othersidedrl/portfolio/backend/internal/middleware"
+	"github.com/othersidedrl/portfolio/backend/internal/models"
+	"github.com/othersidedrl/portfolio/backend/internal/project"
+	"github.com/othersidedrl/portfolio/backend/internal/technology"
+	"github.com/othersidedrl/portfolio/backend/internal/translation"
+	"gorm.io/gorm"
+)
+
+// The fakes only read. A dry run that tried to write would hit the nil
+// embedded interface and panic.
+
+type fakeHeroRepository struct {
+	hero.HeroRepository
+}
+
+func (fakeHeroRepository) Find(ctx context.Context) (*hero.HeroPageDto, error) {
+	return &hero.HeroPageDto{Name: "Ada", Title: "Engineer", ImageUrls: []string{}, Hobbies: []string{}}, nil
+}
+
+type fakeAboutRepository struct {
+	about.AboutRepository
+}
+
+func (fakeAboutRepository) Find(ctx context.Context) (*about.AboutPageDto, error) {
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (fakeAboutRepository) GetCareers(ctx context.Context) (*about.CareerJourneyDto, error) {
+	return &about.CareerJourneyDto{Careers: []about.CareerItemDto{
+		{ID: 1, Title: "Engineer", Affiliation: "Acme", Type: string(models.Job), StartedAt: "2020-01", Ongoing: true},
+	}}, nil
+}
+
+func (fakeAboutRepository) GetTechnicalSkills(ctx context.Context) (*about.TechnicalSkillDto, error) {
+	return &about.TechnicalSkillDto{Skills: []about.SkillItemDto{
+		{ID: 1, Name: "Go", Specialities: []string{"Go"}, Level: string(models.Advanced), Category: string(models.Other), ProjectIDs: []uint{3}},
+	}}, nil
+}
+
+type fakeProjectRepository struct {
+	project.ProjectRepository
+}
+
+func (fakeProjectRepository) GetAllProjects(ctx context.Context) (*project.ProjectDto, error) {
+	return &project.ProjectDto{Projects: []project.ProjectItemDto{
+		{ID: 3, Name: "Portfolio", Description: "My site", TechStack: []string{"Go"}, Type: models.Web, Contribution: models.Personal},
+	}}, nil
+}
+
+// fakeTechnologyRepository knows Go (alias golang) and React
+type fakeTechnologyRepository struct {
+	technology.TechnologyRepository
+}
+
+func (fakeTechnologyRepository) FindByKeys(ctx context.Context, keys []string) ([]models.Technology, error) {
+	return []models.Technology{
+		{Name: "Go", Aliases: []string{"golang"}},
+		{Name: "React"},
+	}, nil
+}
+
+type nopRecorder struct{}
+
+func (nopRecorder) Record(ctx context.Context, action models.AuditAction, entityType models.EntityType, entityID interface{}, before, after interface{}) {
+}
+
+func newTestService() *Service {
+	translations := translation.NewService(nil, middleware.LocaleConfig{Default: "en"}, nopRecorder{})
+	technologies := technology.NewService(fakeTechnologyRepository{}, nopRecorder{})
+	return NewService(
+		nil,
+		hero.NewService(fakeHeroRepository{}, nil, translations, nopRecorder{}),
+		about.NewService(fakeAboutRepository{}, nil, translations, technologies, nopRecorder{}),
+		project.NewService(fakeProjectRepository{}, nil, translations, technologies, nopRecorder{}),
+		technologies,
+	)
+}
+
+func TestImportDryRunDiff(t *testing.T) {
+	doc := Resume{
+		Basics: Basics{Name: "Ada", Label: "Engineer", Summary: "I build things"},
+		Work: []Work{
+			{Name: "Acme", Position: "Engineer", StartDate: "2020-01-01"},
+			{Name: "Globex", Position: "Developer", StartDate: "2018", EndDate: "2019-12"},
+		},
+		Skills: []Skill{
+			{Name: "go", Level: "Expert", Keywords: []string{"golang"}},
+			{Name: "React", Keywords: []string{"react"}},
+		},
+		Projects: []Project{
+			{Name: "Portfolio", Description: "My new site", Keywords: []string{"golang"}},
+		},
+	}
+
+	result, err := newTestService().Import(context.Background(), doc, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type change struct {
+		section Section
+		action  ChangeAction
+		key     string
+		fields  []string
+	}
+	want := []change{
+		{SectionHero, ActionUnchanged, "Ada", nil},
+		{SectionAbout, ActionCreate, "about", []string{"available", "cards", "description", "github_link", "linkedin_link"}},
+		{SectionCareer, ActionUnchanged, "Engineer at Acme", nil},
+		{SectionCareer, ActionCreate, "Developer at Globex", []string{"affiliation", "description", "ended_at", "id", "location", "ongoing", "started_at", "title", "type"}},
+		{SectionSkill, ActionUnchanged, "go", nil},
+		{SectionSkill, ActionCreate, "React", []string{"category", "description", "id", "level", "name", "specialities"}},
+		{SectionProject, ActionUpdate, "Portfolio", []string{"description"}},
+	}
+
+	if !result.DryRun {
+		t.Error("result is not marked as a dry run")
+	}
+	if len(result.Changes) != len(want) {
+		t.Fatalf("%d changes, want %d: %+v", len(result.Changes), len(want), result.Changes)
+	}
+	for i, w := range want {
+		got := result.Changes[i]
+		var fields []string
+		for field := range got.Changes {
+			fields = append(fields, field)
+		}
+		slices.Sort(fields)
+		if got.Section != w.section || got.Action != w.action || got.Key != w.key || !reflect.DeepEqual(fields, w.fields) {
+			t.Errorf("change %d = %s %s %q %v, want %s %s %q %v", i, got.Section, got.Action, got.Key, fields, w.section, w.action, w.key, w.fields)
+		}
+	}
+
+	description := result.Changes[6].Changes["description"]
+	if description.From != "My site" || description.To != "My new site" {
+		t.Errorf("project description change = %+v", description)
+	}
+	wantSummary := map[ChangeAction]int{ActionCreate: 3, ActionUpdate: 1, ActionUnchanged: 3}
+	if !reflect.DeepEqual(result.Summary, wantSummary) {
+		t.Errorf("summary = %v, want %v", result.Summary, wantSummary)
+	}
+}
+
+func TestImportRejectsInvalidEntries(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  Resume
+	}{
+		{"work without a title", Resume{Work: []Work{{Name: "Acme", StartDate: "2020-01"}}}},
+		{"work ending before it starts", Resume{Work: []Work{{Name: "Globex", Position: "Developer", StartDate: "2020-01", EndDate: "2019-01"}}}},
+		{"work with a bad date", Resume{Work: []Work{{Name: "Globex", Position: "Developer", StartDate: "last year"}}}},
+		{"repeated skill", Resume{Skills: []Skill{{Name: "React"}, {Name: "react "}}}},
+		{"skill without a name", Resume{Skills: []Skill{{Name: " "}}}},
+		{"repeated project", Resume{Projects: []Project{{Name: "Blog"}, {Name: "BLOG"}}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := newTestService().Import(context.Background(), tt.doc, true); !errors.Is(err, ErrInvalidResume) {
+				t.Errorf("err = %v, want %v", err, ErrInvalidResume)
+			}
+		})
+	}
+}
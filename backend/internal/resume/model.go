@@ -0,0 +1,113 @@
+package resume
+
+import "github.com/othersidedrl/portfolio/backend/internal/utils"
+
+// SchemaURL is the JSON Resume schema exported documents follow
+const SchemaURL = "https://raw.githubusercontent.com/jsonresume/resume-schema/v1.0.0/schema.json"
+
+// Resume is a JSON Resume document. Only the sections the site has content
+// for are read and written; the rest are accepted on import and ignored.
+type Resume struct {
+	Schema    string      `json:"$schema,omitempty"`
+	Basics    Basics      `json:"basics"`
+	Work      []Work      `json:"work"`
+	Education []Education `json:"education"`
+	Skills    []Skill     `json:"skills"`
+	Projects  []Project   `json:"projects"`
+	Interests []Interest  `json:"interests"`
+	Meta      *Meta       `json:"meta,omitempty"`
+}
+
+type Basics struct {
+	Name     string    `json:"name"`
+	Label    string    `json:"label,omitempty"`
+	Image    string    `json:"image,omitempty"`
+	Email    string    `json:"email,omitempty"`
+	URL      string    `json:"url,omitempty"`
+	Summary  string    `json:"summary,omitempty"`
+	Profiles []Profile `json:"profiles"`
+}
+
+type Profile struct {
+	Network  string `json:"network"`
+	Username string `json:"username,omitempty"`
+	URL      string `json:"url"`
+}
+
+// Work is a job career entry. Dates are YYYY-MM; an ongoing job has no end date.
+type Work struct {
+	Name      string `json:"name"`
+	Position  string `json:"position"`
+	Location  string `json:"location,omitempty"`
+	Summary   string `json:"summary,omitempty"`
+	StartDate string `json:"startDate,omitempty"`
+	EndDate   string `json:"endDate,omitempty"`
+}
+
+// Education is an education career entry, dated like Work
+type Education struct {
+	Institution string `json:"institution"`
+	Area        string `json:"area"`
+	StartDate   string `json:"startDate,omitempty"`
+	EndDate     string `json:"endDate,omitempty"`
+}
+
+type Skill struct {
+	Name     string   `json:"name"`
+	Level    string   `json:"level,omitempty"`
+	Keywords []string `json:"keywords"`
+}
+
+type Project struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	URL         string   `json:"url,omitempty"`
+	Keywords    []string `json:"keywords"`
+	Type        string   `json:"type,omitempty"`
+}
+
+type Interest struct {
+	Name string `json:"name"`
+}
+
+type Meta struct {
+	Version      string `json:"version,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+}
+
+// ChangeAction is what an import does to one entry
+type ChangeAction string
+
+const (
+	ActionCreate    ChangeAction = "create"
+	ActionUpdate    ChangeAction = "update"
+	ActionUnchanged ChangeAction = "unchanged"
+)
+
+// Section is the part of the site an imported entry is written to
+type Section string
+
+const (
+	SectionHero    Section = "hero"
+	SectionAbout   Section = "about"
+	SectionCareer  Section = "career"
+	SectionSkill   Section = "skill"
+	SectionProject Section = "project"
+)
+
+// ChangeDto is one entry of an import. ID is the existing row it updates;
+// Changes holds the fields that differ from it.
+type ChangeDto struct {
+	Section Section                      `json:"section"`
+	Action  ChangeAction                 `json:"action"`
+	Key     string                       `json:"key"`
+	ID      uint                         `json:"id,omitempty"`
+	Changes map[string]utils.FieldChange `json:"changes"`
+}
+
+// ImportResultDto lists what an import changed, or would change on a dry run
+type ImportResultDto struct {
+	DryRun  bool                 `json:"dry_run"`
+	Summary map[ChangeAction]int `json:"summary"`
+	Changes []ChangeDto          `json:"changes"`
+}
@@ -0,0 +1,169 @@
+package resume
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/othersidedrl/portfolio/backend/internal/about"
+	"github.com/othersidedrl/portfolio/backend/internal/hero"
+	"github.com/othersidedrl/portfolio/backend/internal/models"
+	"github.com/othersidedrl/portfolio/backend/internal/project"
+	"github.com/othersidedrl/portfolio/backend/internal/technology"
+	"gorm.io/gorm"
+)
+
+// Service maps the site's content to and from JSON Resume. It goes through
+// the section services so imports keep their revisions, audit entries and
+// technology links, and runs them in one transaction on db.
+type Service struct {
+	db           *gorm.DB
+	hero         *hero.Service
+	about        *about.Service
+	projects     *project.Service
+	technologies *technology.Service
+}
+
+func NewService(db *gorm.DB, hero *hero.Service, about *about.Service, projects *project.Service, technologies *technology.Service) *Service {
+	return &Service{
+		db:           db,
+		hero:         hero,
+		about:        about,
+		projects:     projects,
+		technologies: technologies,
+	}
+}
+
+// contactEmail is how an email address is stored in the hero contact link
+const contactEmail = "mailto:"
+
+// Export builds the resume from the public site. A hero or about page that
+// is not live yet leaves its fields out.
+func (s *Service) Export(ctx context.Context) (*Resume, error) {
+	resume := &Resume{
+		Schema:    SchemaURL,
+		Basics:    Basics{Profiles: []Profile{}},
+		Work:      []Work{},
+		Education: []Education{},
+		Skills:    []Skill{},
+		Projects:  []Project{},
+		Interests: []Interest{},
+		Meta:      &Meta{Version: "v1.0.0"},
+	}
+
+	heroPage, err := s.hero.FindPublic(ctx)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	if heroPage != nil {
+		resume.Basics.Name = heroPage.Name
+		resume.Basics.Label = heroPage.Title
+		if len(heroPage.ImageUrls) > 0 {
+			resume.Basics.Image = heroPage.ImageUrls[0]
+		}
+		if email, ok := strings.CutPrefix(heroPage.ContactLink, contactEmail); ok {
+			resume.Basics.Email = email
+		} else {
+			resume.Basics.URL = heroPage.ContactLink
+		}
+		for _, hobby := range heroPage.Hobbies {
+			resume.Interests = append(resume.Interests, Interest{Name: hobby})
+		}
+	}
+
+	aboutPage, err := s.about.FindPublic(ctx)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	if aboutPage != nil {
+		resume.Basics.Summary = aboutPage.Description
+		for _, p := range []struct{ network, link string }{
+			{networkGitHub, aboutPage.GithubLink},
+			{networkLinkedIn, aboutPage.LinkedinLink},
+		} {
+			if p.link != "" {
+				resume.Basics.Profiles = append(resume.Basics.Profiles, Profile{Network: p.network, Username: username(p.link), URL: p.link})
+			}
+		}
+	}
+
+	careers, err := s.about.GetCareers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, career := range careers.Careers {
+		switch models.CareerType(career.Type) {
+		case models.Job:
+			resume.Work = append(resume.Work, Work{
+				Name:      career.Affiliation,
+				Position:  career.Title,
+				Location:  career.Location,
+				Summary:   career.Description,
+				StartDate: career.StartedAt,
+				EndDate:   career.EndedAt,
+			})
+		case models.Education:
+			resume.Education = append(resume.Education, Education{
+				Institution: career.Affiliation,
+				Area:        career.Title,
+				StartDate:   career.StartedAt,
+				EndDate:     career.EndedAt,
+			})
+		}
+	}
+
+	skills, err := s.about.GetTechnicalSkills(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, skill := range skills.Skills {
+		keywords := skill.Specialities
+		if keywords == nil {
+			keywords = []string{}
+		}
+		resume.Skills = append(resume.Skills, Skill{Name: skill.Name, Level: skill.Level, Keywords: keywords})
+	}
+
+	projects, err := s.projects.GetPublicProjects(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range projects.Projects {
+		link := p.ProjectLink
+		if link == "" {
+			link = p.GithubLink
+		}
+		keywords := p.TechStack
+		if keywords == nil {
+			keywords = []string{}
+		}
+		resume.Projects = append(resume.Projects, Project{
+			Name:        p.Name,
+			Description: p.Description,
+			URL:         link,
+			Keywords:    keywords,
+			Type:        string(p.Type),
+		})
+	}
+	return resume, nil
+}
+
+const (
+	networkGitHub   = "GitHub"
+	networkLinkedIn = "LinkedIn"
+)
+
+// username is the last path segment of a profile link
+func username(link string) string {
+	u, err := url.Parse(link)
+	if err != nil || u.Host == "" {
+		return ""
+	}
+	name := path.Base(strings.TrimRight(u.Path, "/"))
+	if name == "." || name == "/" {
+		return ""
+	}
+	return name
+}
@@ -0,0 +1,29 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// DefaultTrashRetention is how long deleted rows stay restorable when
+// TRASH_RETENTION is not set
+const DefaultTrashRetention = 30 * 24 * time.Hour
+
+// PurgeJob permanently deletes rows that have been in the trash for longer
+// than retention. Trashed rows are never public, so it touches no cache.
+func PurgeJob(name string, retention time.Duration, purge func(ctx context.Context, before time.Time) (int64, error)) Job {
+	return Job{
+		Name: "purge " + name,
+		Run: func(ctx context.Context, _, now time.Time) (bool, error) {
+			purged, err := purge(ctx, now.Add(-retention))
+			if err != nil {
+				return false, err
+			}
+			if purged > 0 {
+				log.Printf("🗑️ Purged %d trashed %s", purged, name)
+			}
+			return false, nil
+		},
+	}
+}
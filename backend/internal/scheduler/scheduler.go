@@ -0,0 +1,96 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/othersidedrl/portfolio/backend/internal/utils"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	defaultInterval = time.Minute
+	lockKey         = "scheduler:lock"
+)
+
+// Job is run on every tick. Run gets the time of the previous tick and
+// reports whether it changed public content, in which case the scheduler
+// drops CacheKeys.
+type Job struct {
+	Name      string
+	Run       func(ctx context.Context, since, now time.Time) (bool, error)
+	CacheKeys []string
+}
+
+// Scheduler runs background jobs inside the API process. A Redis lock makes
+// sure only one instance runs them when the API is scaled out.
+type Scheduler struct {
+	client   *redis.Client
+	interval time.Duration
+	jobs     []Job
+}
+
+func New(client *redis.Client, jobs ...Job) *Scheduler {
+	return &Scheduler{
+		client:   client,
+		interval: utils.DurationFromEnv("SCHEDULER_INTERVAL", defaultInterval),
+		jobs:     jobs,
+	}
+}
+
+// Start runs the jobs every interval until ctx is cancelled
+func (s *Scheduler) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		// The zero time lets the first tick catch up on anything that
+		// happened while the API was down
+		var since time.Time
+		for {
+			now := time.Now()
+			if s.acquire(ctx) {
+				s.runJobs(ctx, since, now)
+			}
+			since = now
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	log.Printf("⏰ Scheduler running every %s", s.interval)
+}
+
+// acquire takes the lock for this tick. It expires shortly before the next one.
+func (s *Scheduler) acquire(ctx context.Context) bool {
+	ok, err := s.client.SetNX(ctx, lockKey, 1, s.interval*9/10).Result()
+	if err != nil {
+		log.Println("⚠️ Scheduler failed to take lock:", err)
+		return false
+	}
+	return ok
+}
+
+func (s *Scheduler) runJobs(ctx context.Context, since, now time.Time) {
+	for _, job := range s.jobs {
+		// A job can fail part way and still have changed something, so
+		// the cache is refreshed either way
+		changed, err := job.Run(ctx, since, now)
+		if err != nil {
+			log.Printf("⚠️ Scheduled job %q failed: %v", job.Name, err)
+		}
+		if !changed || len(job.CacheKeys) == 0 {
+			continue
+		}
+
+		if err := utils.DeleteCache(ctx, s.client, job.CacheKeys...); err != nil {
+			log.Printf("⚠️ Scheduled job %q failed to refresh cache: %v", job.Name, err)
+		} else {
+			log.Printf("✅ Scheduled job %q refreshed cache keys: %v", job.Name, job.CacheKeys)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package search
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// MaxQueryLength caps the search text, in characters
+const MaxQueryLength = 200
+
+type Handler struct {
+	service *Service
+}
+
+func NewHandler(service *Service) *Handler {
+	return &Handler{service}
+}
+
+// Search handles ?q= with optional repeatable or comma-separated ?type= and ?limit=
+func (h *Handler) Search(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	query := Query{Text: q.Get("q"), Limit: 20}
+
+	if strings.TrimSpace(query.Text) == "" {
+		http.Error(w, "Missing search query", http.StatusBadRequest)
+		return
+	}
+	if utf8.RuneCountInString(query.Text) > MaxQueryLength {
+		http.Error(w, fmt.Sprintf("Search query may be at most %d characters", MaxQueryLength), http.StatusBadRequest)
+		return
+	}
+	for _, value := range q["type"] {
+		for _, t := range strings.Split(value, ",") {
+			resultType := ResultType(strings.TrimSpace(t))
+			if resultType == "" {
+				continue
+			}
+			if _, ok := targets[resultType]; !ok {
+				http.Error(w, "Invalid type, expected project, skill, career or testimony", http.StatusBadRequest)
+				return
+			}
+			query.Types = append(query.Types, resultType)
+		}
+	}
+	if limit, err := strconv.Atoi(q.Get("limit")); err == nil && limit > 0 && limit <= 50 {
+		query.Limit = limit
+	}
+
+	results, err := h.service.Search(r.Context(), query)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	response := map[string]interface{}{
+		"length": len(results),
+		"data":   results,
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
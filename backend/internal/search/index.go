@@ -0,0 +1,72 @@
+package search
+
+import (
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// Config is the text search configuration documents and queries are parsed with
+const Config = "english"
+
+// Source is a table with a search column. Its repository refreshes the
+// column whenever it writes a row, in the same transaction.
+type Source struct {
+	Table    string
+	Document string
+}
+
+// weighted builds a tsvector from a text expression, ranked by weight A to D
+func weighted(weight, expr string) string {
+	return fmt.Sprintf("setweight(to_tsvector('%s', coalesce(%s, '')), '%s')", Config, expr, weight)
+}
+
+func document(parts ...string) string {
+	return strings.Join(parts, " || ")
+}
+
+var (
+	Projects = Source{"projects", document(
+		weighted("A", "name"),
+		weighted("B", "description"),
+		weighted("B", "array_to_string(tech_stack, ' ')"),
+		weighted("C", "body"),
+	)}
+	Skills = Source{"technical_skills", document(
+		weighted("A", "name"),
+		weighted("B", "array_to_string(specialities, ' ')"),
+		weighted("C", "description"),
+	)}
+	Careers = Source{"career_journeys", document(
+		weighted("A", "title"),
+		weighted("B", "affiliation"),
+		weighted("C", "description"),
+		weighted("D", "location"),
+	)}
+	Testimonies = Source{"testimonies", document(
+		weighted("A", "name"),
+		weighted("B", "affiliation"),
+		weighted("C", "description"),
+	)}
+)
+
+// Sources lists every searchable table
+var Sources = []Source{Projects, Skills, Careers, Testimonies}
+
+// Refresh recomputes the search column of the given rows, a single ID, a
+// list of IDs or a subquery selecting them
+func (s Source) Refresh(tx *gorm.DB, ids interface{}) error {
+	return tx.Exec(fmt.Sprintf("UPDATE %s SET search = %s WHERE id IN (?)", s.Table, s.Document), ids).Error
+}
+
+// Rebuild recomputes the search column of every row, for rows written
+// before search existed or after the documents change
+func Rebuild(db *gorm.DB) error {
+	for _, source := range Sources {
+		if err := db.Exec(fmt.Sprintf("UPDATE %s SET search = %s", source.Table, source.Document)).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package search
+
+// ResultType is the kind of content a search result points to
+type ResultType string
+
+const (
+	ResultProject   ResultType = "project"
+	ResultSkill     ResultType = "skill"
+	ResultCareer    ResultType = "career"
+	ResultTestimony ResultType = "testimony"
+)
+
+// ResultTypes lists every result type, in the order ties are broken
+var ResultTypes = []ResultType{ResultProject, ResultSkill, ResultCareer, ResultTestimony}
+
+// Query is a search request. Empty Types searches every type.
+type Query struct {
+	Text  string
+	Types []ResultType
+	Limit int
+}
+
+// ResultDto is one match. Snippet is HTML: the matched text is escaped and
+// the matching words are wrapped in <mark>. Slug is only set for projects.
+type ResultDto struct {
+	Type    ResultType `json:"type"`
+	ID      uint       `json:"id"`
+	Title   string     `json:"title"`
+	Slug    string     `json:"slug,omitempty"`
+	Snippet string     `json:"snippet"`
+	Rank    float64    `json:"rank"`
+}
@@ -0,0 +1,145 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strings"
+	"time"
+
+	"github.com/othersidedrl/portfolio/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+type SearchRepository interface {
+	Search(ctx context.Context, query Query) ([]ResultDto, error)
+}
+
+type GormSearchRepository struct {
+	db *gorm.DB
+}
+
+func NewGormSearchRepository(db *gorm.DB) *GormSearchRepository {
+	return &GormSearchRepository{db: db}
+}
+
+// target describes how a result type is searched: the columns its results
+// are built from and which rows the public may see
+type target struct {
+	model   interface{}
+	title   string
+	slug    string
+	body    string
+	visible func(now time.Time) func(db *gorm.DB) *gorm.DB
+}
+
+var targets = map[ResultType]target{
+	ResultProject: {
+		model:   &models.Project{},
+		title:   "name",
+		slug:    "slug",
+		body:    "concat_ws(' ', description, body)",
+		visible: models.PublicProjects,
+	},
+	ResultSkill: {
+		model: &models.TechnicalSkills{},
+		title: "name",
+		slug:  "''",
+		body:  "concat_ws(' ', array_to_string(specialities, ', '), description)",
+	},
+	ResultCareer: {
+		model: &models.CareerJourney{},
+		title: "title",
+		slug:  "''",
+		body:  "concat_ws(' ', affiliation, description)",
+	},
+	ResultTestimony: {
+		model: &models.Testimony{},
+		title: "name",
+		slug:  "''",
+		body:  "concat_ws(' ', affiliation, description)",
+		visible: func(time.Time) func(db *gorm.DB) *gorm.DB {
+			return func(db *gorm.DB) *gorm.DB { return db.Where("approved = ?", true) }
+		},
+	},
+}
+
+// Private use characters mark the matches in ts_headline output, so the
+// snippet can be escaped before the marks become HTML
+const (
+	startMark = "\uE000"
+	stopMark  = "\uE001"
+)
+
+var headlineOptions = fmt.Sprintf(
+	`StartSel="%s", StopSel="%s", MinWords=15, MaxWords=35, MaxFragments=2, FragmentDelimiter=" … "`,
+	startMark, stopMark,
+)
+
+// Search ranks the public rows of every requested type against the query.
+// Snippets are only built for the rows that make the cut.
+func (r *GormSearchRepository) Search(ctx context.Context, query Query) ([]ResultDto, error) {
+	db := r.db.WithContext(ctx)
+	now := time.Now()
+
+	branches := make([]string, 0, len(query.Types))
+	args := []interface{}{Config, Config, query.Text, headlineOptions}
+	for _, t := range query.Types {
+		target := targets[t]
+		branch := db.Model(target.model).
+			Select(fmt.Sprintf(
+				"'%s' AS type, id, %s AS title, %s AS slug, %s AS body, ts_rank(search, websearch_to_tsquery(?, ?)) AS rank",
+				t, target.title, target.slug, target.body,
+			), Config, query.Text).
+			Where("search @@ websearch_to_tsquery(?, ?)", Config, query.Text)
+		if target.visible != nil {
+			branch = branch.Scopes(target.visible(now))
+		}
+		branches = append(branches, "?")
+		args = append(args, branch)
+	}
+	args = append(args, query.Limit)
+
+	var rows []struct {
+		Type    ResultType
+		ID      uint
+		Title   string
+		Slug    string
+		Rank    float64
+		Snippet string
+	}
+	err := db.Raw(fmt.Sprintf(`
+SELECT type, id, title, slug, rank,
+	ts_headline(?, body, websearch_to_tsquery(?, ?), ?) AS snippet
+FROM (
+	SELECT * FROM (%s) matches
+	ORDER BY rank DESC
+	LIMIT ?
+) top
+ORDER BY rank DESC, type, id`, strings.Join(branches, " UNION ALL ")),
+		args...,
+	).Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]ResultDto, 0, len(rows))
+	for _, row := range rows {
+		results = append(results, ResultDto{
+			Type:    row.Type,
+			ID:      row.ID,
+			Title:   row.Title,
+			Slug:    row.Slug,
+			Snippet: highlight(row.Snippet),
+			Rank:    row.Rank,
+		})
+	}
+	return results, nil
+}
+
+// highlight escapes a ts_headline snippet and turns its marks into <mark> tags
+func highlight(snippet string) string {
+	escaped := html.EscapeString(snippet)
+	escaped = strings.ReplaceAll(escaped, startMark, "<mark>")
+	return strings.ReplaceAll(escaped, stopMark, "</mark>")
+}
@@ -0,0 +1,37 @@
+package search
+
+import (
+	"context"
+	"strings"
+)
+
+type Service struct {
+	repo SearchRepository
+}
+
+func NewService(repo SearchRepository) *Service {
+	return &Service{repo}
+}
+
+// Search returns the public content matching the query, best match first.
+// The text is read like a web search: quoted phrases, "or" and -excluded words.
+func (s *Service) Search(ctx context.Context, query Query) ([]ResultDto, error) {
+	query.Text = strings.TrimSpace(query.Text)
+	if query.Text == "" {
+		return []ResultDto{}, nil
+	}
+	if len(query.Types) == 0 {
+		query.Types = ResultTypes
+	}
+
+	seen := make(map[ResultType]bool, len(query.Types))
+	types := make([]ResultType, 0, len(query.Types))
+	for _, t := range query.Types {
+		if !seen[t] {
+			seen[t] = true
+			types = append(types, t)
+		}
+	}
+	query.Types = types
+	return s.repo.Search(ctx, query)
+}
@@ -11,13 +11,22 @@ import (
 	chiMiddleware "github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/cors"
 	"github.com/othersidedrl/portfolio/backend/internal/about"
+	"github.com/othersidedrl/portfolio/backend/internal/apikey"
+	"github.com/othersidedrl/portfolio/backend/internal/audit"
 	"github.com/othersidedrl/portfolio/backend/internal/auth"
+	"github.com/othersidedrl/portfolio/backend/internal/content"
 	"github.com/othersidedrl/portfolio/backend/internal/health"
 	"github.com/othersidedrl/portfolio/backend/internal/hero"
 	"github.com/othersidedrl/portfolio/backend/internal/image"
 	customMiddleware "github.com/othersidedrl/portfolio/backend/internal/middleware"
+	"github.com/othersidedrl/portfolio/backend/internal/models"
 	"github.com/othersidedrl/portfolio/backend/internal/project"
+	"github.com/othersidedrl/portfolio/backend/internal/resume"
+	"github.com/othersidedrl/portfolio/backend/internal/search"
+	"github.com/othersidedrl/portfolio/backend/internal/technology"
 	"github.com/othersidedrl/portfolio/backend/internal/testimony"
+	"github.com/othersidedrl/portfolio/backend/internal/translation"
+	"github.com/othersidedrl/portfolio/backend/internal/user"
 	"github.com/othersidedrl/portfolio/backend/internal/utils"
 )
 
@@ -27,8 +36,18 @@ func NewRouter(
 	aboutHandler *about.Handler,
 	testimonyHandler *testimony.Handler,
 	projectHandler *project.Handler,
+	technologyHandler *technology.Handler,
+	searchHandler *search.Handler,
+	resumeHandler *resume.Handler,
 	imageHandler *image.Handler,
+	userHandler *user.Handler,
+	apiKeyHandler *apikey.Handler,
+	auditHandler *audit.Handler,
+	contentHandler *content.Handler,
+	translationHandler *translation.Handler,
 	jwtService *utils.JWTService,
+	tokenStore customMiddleware.RevocationChecker,
+	apiKeyAuthenticator customMiddleware.APIKeyAuthenticator,
 ) http.Handler {
 	r := chi.NewRouter()
 
@@ -47,6 +66,7 @@ func NewRouter(
 	r.Use(customMiddleware.SecurityHeaders)
 	r.Use(customMiddleware.RequestSizeLimit(10 << 20)) // 10MB limit
 	r.Use(customMiddleware.SanitizeInput)
+	r.Use(customMiddleware.ClientIPContext)
 
 	// Rate limiting (60 requests per minute)
 	rateLimiter := customMiddleware.NewRateLimiter(60)
@@ -56,7 +76,7 @@ func NewRouter(
 	r.Use(cors.Handler(cors.Options{
 		AllowedOrigins:   allowedOrigins,
 		AllowedMethods:   []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token", "X-Requested-With"},
+		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-API-Key", "X-CSRF-Token", "X-Requested-With"},
 		ExposedHeaders:   []string{"Link"},
 		AllowCredentials: true,
 		MaxAge:           300, // 5 mins
@@ -72,13 +92,43 @@ func NewRouter(
 	r.Use(customMiddleware.ValidateContentType)
 
 	// Auth middleware
-	authGuard := customMiddleware.AuthGuard(jwtService)
+	authGuard := customMiddleware.AuthGuard(jwtService, tokenStore, apiKeyAuthenticator)
+	userGuard := chi.Chain(authGuard, customMiddleware.RequireUser).Handler
 
 	// Redis
 	redis := utils.RedisClient
 	// Cache TTLs
 	pageTTL := time.Hour
 	sectionTTL := 30 * time.Minute
+	// Public responses that contain translated text
+	translatedCacheKeys := []string{
+		"hero_page_cache",
+		"about_page_cache",
+		"about_careers_cache",
+		"about_skills_cache",
+		"testimony_page_cache",
+		"project_page_cache",
+		"project_items_cache",
+		"project_item_cache",
+		"project_featured_cache",
+	}
+	// Public project list, featured projects and the per-slug project details,
+	// plus the technology usage counts and skill evidence they feed
+	projectCacheKeys := []string{"project_items_cache", "project_featured_cache", "project_item_cache", "technologies_cache", "about_skills_cache"}
+	// Public skills, the technology usage counts they feed and the project
+	// details listing them
+	skillCacheKeys := []string{"about_skills_cache", "technologies_cache", "project_item_cache"}
+	// Public careers and the skill evidence listing them
+	careerCacheKeys := []string{"about_careers_cache", "about_skills_cache"}
+	// Renaming or merging a technology rewrites the projects and skills using it
+	technologyCacheKeys := projectCacheKeys
+	// A resume import can write every section it maps to
+	resumeCacheKeys := append([]string{"hero_page_cache", "about_page_cache", "about_careers_cache"}, projectCacheKeys...)
+	// Query parameters the public project list is cached per
+	projectQueryParams := []string{"type", "contribution", "tech", "sort", "cursor", "limit"}
+
+	// Public verification keys for access tokens
+	r.Get("/.well-known/jwks.json", authHandler.JWKS)
 
 	r.Route("/api/v1", func(r chi.Router) {
 		r.Get("/health", health.Health)
@@ -87,24 +137,36 @@ func NewRouter(
 		r.Group(func(r chi.Router) {
 			publicRateLimiter := customMiddleware.NewRateLimiter(30) // 30 requests per minute for public
 			r.Use(publicRateLimiter.Handler)
+			r.Use(customMiddleware.Locale(customMiddleware.LocaleConfigFromEnv()))
 
 			// Hero Section (public)
-			r.Get("/hero", customMiddleware.RedisCache(redis, "hero_page_cache", pageTTL, heroHandler.GetHeroPage))
+			r.Get("/hero", customMiddleware.RedisCache(redis, "hero_page_cache", pageTTL, heroHandler.GetPublicHeroPage))
 
 			// About Section (public)
-			r.Get("/about", customMiddleware.RedisCache(redis, "about_page_cache", pageTTL, aboutHandler.GetAboutPage))
-			r.Get("/about/skills", customMiddleware.RedisCache(redis, "about_skills_cache", sectionTTL, aboutHandler.GetTechnicalSkills))
+			r.Get("/about", customMiddleware.RedisCache(redis, "about_page_cache", pageTTL, aboutHandler.GetPublicAboutPage))
+			r.Get("/about/skills", customMiddleware.RedisCache(redis, "about_skills_cache", sectionTTL, aboutHandler.GetPublicTechnicalSkills))
 			r.Get("/about/careers", customMiddleware.RedisCache(redis, "about_careers_cache", sectionTTL, aboutHandler.GetCareers))
 
 			// Testimonies (public)
-			r.Get("/testimony", customMiddleware.RedisCache(redis, "testimony_page_cache", pageTTL, testimonyHandler.GetTestimonyPage))
+			r.Get("/testimony", customMiddleware.RedisCache(redis, "testimony_page_cache", pageTTL, testimonyHandler.GetPublicTestimonyPage))
 			r.Post("/image", imageHandler.UploadProfileImage)
 			r.Post("/testimony/items", customMiddleware.RemoveCache(redis, "testimony_approved_cache", testimonyHandler.CreateTestimony))
 			r.Get("/testimony/items/approved", customMiddleware.RedisCache(redis, "testimony_approved_cache", sectionTTL, testimonyHandler.GetApprovedTestimonies))
 
 			// Projects (public)
-			r.Get("/project", customMiddleware.RedisCache(redis, "project_page_cache", pageTTL, projectHandler.GetProjectPage))
-			r.Get("/project/items", customMiddleware.RedisCache(redis, "project_items_cache", sectionTTL, projectHandler.GetProjects))
+			r.Get("/project", customMiddleware.RedisCache(redis, "project_page_cache", pageTTL, projectHandler.GetPublicProjectPage))
+			r.Get("/project/items", customMiddleware.RedisCacheNormalizedQuery(redis, "project_items_cache", projectQueryParams, projectHandler.CacheQuery, sectionTTL, projectHandler.GetProjects))
+			r.Get("/project/featured", customMiddleware.RedisCache(redis, "project_featured_cache", sectionTTL, projectHandler.GetFeaturedProjects))
+			r.Get("/project/items/{slug}", customMiddleware.RedisCacheParam(redis, "project_item_cache", "slug", sectionTTL, projectHandler.GetProjectBySlug))
+
+			// Search (public, not cached since every write changes the results)
+			r.Get("/search", searchHandler.Search)
+
+			// Resume (public, not cached since it is built from every section)
+			r.Get("/resume.json", resumeHandler.GetResume)
+
+			// Technologies (public)
+			r.Get("/technologies", customMiddleware.RedisCache(redis, "technologies_cache", sectionTTL, technologyHandler.GetPublicTechnologies))
 		})
 
 		// Auth
@@ -113,7 +175,37 @@ func NewRouter(
 			r.Use(authRateLimiter.Handler)
 
 			r.Post("/login", authHandler.Login)
+			r.Post("/login/verify", authHandler.VerifyLogin)
+			r.Post("/refresh", authHandler.Refresh)
+			r.With(userGuard).Post("/logout", authHandler.Logout)
 			r.With(authGuard).Get("/me", authHandler.Me)
+
+			// Sessions
+			r.Route("/sessions", func(r chi.Router) {
+				r.Use(userGuard)
+
+				r.Get("/", authHandler.GetSessions)
+				r.Delete("/", authHandler.RevokeOtherSessions)
+				r.Delete("/{id}", authHandler.RevokeSession)
+			})
+
+			// OpenID Connect
+			r.Get("/oidc/login", authHandler.OIDCLogin)
+			r.Get("/oidc/callback", authHandler.OIDCCallback)
+
+			// Passwords
+			r.With(userGuard).Post("/password", authHandler.ChangePassword)
+			r.Post("/password/forgot", authHandler.ForgotPassword)
+			r.Post("/password/reset", authHandler.ResetPassword)
+
+			// Two-factor enrollment
+			r.Route("/2fa", func(r chi.Router) {
+				r.Use(userGuard)
+
+				r.Post("/setup", authHandler.SetupTOTP)
+				r.Post("/confirm", authHandler.ConfirmTOTP)
+				r.Post("/disable", authHandler.DisableTOTP)
+			})
 		})
 
 		// Admin
@@ -121,61 +213,178 @@ func NewRouter(
 			r.Use(authGuard)
 			r.Use(customMiddleware.NoCache) // Prevent caching of admin data
 
+			// Permissions
+			canEditHero := customMiddleware.RequirePermission(models.PermHeroWrite)
+			canEditAbout := customMiddleware.RequirePermission(models.PermAboutWrite)
+			canEditProjects := customMiddleware.RequirePermission(models.PermProjectsWrite)
+			canEditTestimonies := customMiddleware.RequirePermission(models.PermTestimoniesWrite)
+			canModerateTestimonies := customMiddleware.RequirePermission(models.PermTestimoniesModerate)
+			canUploadImages := customMiddleware.RequirePermission(models.PermImagesWrite)
+			canManageUsers := customMiddleware.RequirePermission(models.PermUsersManage)
+			canReadSecurity := customMiddleware.RequirePermission(models.PermSecurityRead)
+			canManageAPIKeys := customMiddleware.RequirePermission(models.PermAPIKeysManage)
+			canReadAudit := customMiddleware.RequirePermission(models.PermAuditRead)
+			canEditTranslations := customMiddleware.RequirePermission(models.PermTranslationsWrite)
+
+			// Every admin read needs content:read, which all roles have and
+			// API keys only get when granted it. Writes check their own
+			// permission below.
+			r.Use(customMiddleware.RequirePermissionToRead(models.PermContentRead))
+
 			// Hero Section (admin)
 			r.Route("/hero", func(r chi.Router) {
 				r.Get("/", heroHandler.GetHeroPage)
-				r.Post("/image", imageHandler.UploadHeroImage)
-				r.Patch("/", customMiddleware.RemoveCache(redis, "hero_page_cache", heroHandler.UpdateHeroPage))
+				r.With(canUploadImages).Post("/image", imageHandler.UploadHeroImage)
+				r.Get("/preview", heroHandler.PreviewHeroPage)
+				r.With(canEditHero).Patch("/", heroHandler.UpdateHeroPage)
+				r.With(canEditHero).Post("/publish", customMiddleware.RemoveCache(redis, "hero_page_cache", heroHandler.PublishHeroPage))
+				r.With(canEditHero).Delete("/draft", heroHandler.DiscardHeroPageDraft)
+				r.Get("/schedule", heroHandler.GetHeroPageSchedule)
+				r.With(canEditHero).Put("/schedule", customMiddleware.RemoveCache(redis, "hero_page_cache", heroHandler.UpdateHeroPageSchedule))
+				r.With(canEditHero).Post("/revisions/{revisionID}/restore", customMiddleware.RemoveCache(redis, "hero_page_cache", heroHandler.RestoreHeroPage))
 			})
 
 			// About Section (admin)
 			r.Route("/about", func(r chi.Router) {
 				r.Get("/", aboutHandler.GetAboutPage)
-				r.Patch("/", customMiddleware.RemoveCache(redis, "about_page_cache", aboutHandler.UpdateAboutPage))
+				r.Get("/preview", aboutHandler.PreviewAboutPage)
+				r.With(canEditAbout).Patch("/", aboutHandler.UpdateAboutPage)
+				r.With(canEditAbout).Post("/publish", customMiddleware.RemoveCache(redis, "about_page_cache", aboutHandler.PublishAboutPage))
+				r.With(canEditAbout).Delete("/draft", aboutHandler.DiscardAboutPageDraft)
+				r.Get("/schedule", aboutHandler.GetAboutPageSchedule)
+				r.With(canEditAbout).Put("/schedule", customMiddleware.RemoveCache(redis, "about_page_cache", aboutHandler.UpdateAboutPageSchedule))
+				r.With(canEditAbout).Post("/revisions/{revisionID}/restore", customMiddleware.RemoveCache(redis, "about_page_cache", aboutHandler.RestoreAboutPage))
 
 				// About Skills (admin)
 				r.Route("/skills", func(r chi.Router) {
 					r.Get("/", aboutHandler.GetTechnicalSkills)
-					r.Post("/", customMiddleware.RemoveCache(redis, "about_skills_cache", aboutHandler.CreateTechnicalSkill))
-					r.Patch("/{id}", customMiddleware.RemoveCache(redis, "about_skills_cache", aboutHandler.UpdateTechnicalSkill))
-					r.Delete("/{id}", customMiddleware.RemoveCache(redis, "about_skills_cache", aboutHandler.DeleteTechnicalSkill))
+					r.With(canEditAbout).Post("/", customMiddleware.RemoveCaches(redis, skillCacheKeys, aboutHandler.CreateTechnicalSkill))
+					r.With(canEditAbout).Patch("/{id}", customMiddleware.RemoveCaches(redis, skillCacheKeys, aboutHandler.UpdateTechnicalSkill))
+					r.With(canEditAbout).Delete("/{id}", customMiddleware.RemoveCaches(redis, skillCacheKeys, aboutHandler.DeleteTechnicalSkill))
+					r.Get("/trash", aboutHandler.GetTrashedTechnicalSkills)
+					r.With(canEditAbout).Post("/{id}/restore", customMiddleware.RemoveCaches(redis, skillCacheKeys, aboutHandler.RestoreTrashedTechnicalSkill))
 				})
 
 				// About Careers (admin)
 				r.Route("/careers", func(r chi.Router) {
 					r.Get("/", aboutHandler.GetCareers)
-					r.Post("/", customMiddleware.RemoveCache(redis, "about_careers_cache", aboutHandler.CreateCareer))
-					r.Patch("/{id}", customMiddleware.RemoveCache(redis, "about_careers_cache", aboutHandler.UpdateCareer))
-					r.Delete("/{id}", customMiddleware.RemoveCache(redis, "about_careers_cache", aboutHandler.DeleteCareer))
+					r.With(canEditAbout).Post("/", customMiddleware.RemoveCaches(redis, careerCacheKeys, aboutHandler.CreateCareer))
+					r.With(canEditAbout).Patch("/{id}", customMiddleware.RemoveCaches(redis, careerCacheKeys, aboutHandler.UpdateCareer))
+					r.With(canEditAbout).Delete("/{id}", customMiddleware.RemoveCaches(redis, careerCacheKeys, aboutHandler.DeleteCareer))
+					r.Get("/trash", aboutHandler.GetTrashedCareers)
+					r.With(canEditAbout).Post("/{id}/restore", customMiddleware.RemoveCaches(redis, careerCacheKeys, aboutHandler.RestoreTrashedCareer))
 				})
 			})
 
 			// Testimonies (admin)
 			r.Route("/testimony", func(r chi.Router) {
 				r.Get("/", testimonyHandler.GetTestimonyPage)
-				r.Patch("/", customMiddleware.RemoveCache(redis, "testimony_page_cache", testimonyHandler.UpdateTestimonyPage))
+				r.Get("/preview", testimonyHandler.PreviewTestimonyPage)
+				r.With(canEditTestimonies).Patch("/", testimonyHandler.UpdateTestimonyPage)
+				r.With(canEditTestimonies).Post("/publish", customMiddleware.RemoveCache(redis, "testimony_page_cache", testimonyHandler.PublishTestimonyPage))
+				r.With(canEditTestimonies).Delete("/draft", testimonyHandler.DiscardTestimonyPageDraft)
+				r.Get("/schedule", testimonyHandler.GetTestimonyPageSchedule)
+				r.With(canEditTestimonies).Put("/schedule", customMiddleware.RemoveCache(redis, "testimony_page_cache", testimonyHandler.UpdateTestimonyPageSchedule))
+				r.With(canEditTestimonies).Post("/revisions/{revisionID}/restore", customMiddleware.RemoveCache(redis, "testimony_page_cache", testimonyHandler.RestoreTestimonyPage))
 
 				r.Route("/items", func(r chi.Router) {
 					r.Get("/", testimonyHandler.GetTestimonies)
-					r.Patch("/{id}", customMiddleware.RemoveCache(redis, "testimony_approved_cache", testimonyHandler.UpdateTestimony))
-					r.Patch("/{id}/approve", customMiddleware.RemoveCache(redis, "testimony_approved_cache", testimonyHandler.ApproveTestimony))
-					r.Delete("/{id}", customMiddleware.RemoveCache(redis, "testimony_approved_cache", testimonyHandler.DeleteTestimony))
+					r.With(canEditTestimonies).Patch("/{id}", customMiddleware.RemoveCache(redis, "testimony_approved_cache", testimonyHandler.UpdateTestimony))
+					r.With(canModerateTestimonies).Patch("/{id}/approve", customMiddleware.RemoveCache(redis, "testimony_approved_cache", testimonyHandler.ApproveTestimony))
+					r.With(canModerateTestimonies).Delete("/{id}", customMiddleware.RemoveCache(redis, "testimony_approved_cache", testimonyHandler.DeleteTestimony))
+					r.Get("/trash", testimonyHandler.GetTrashedTestimonies)
+					r.With(canModerateTestimonies).Post("/{id}/restore", customMiddleware.RemoveCache(redis, "testimony_approved_cache", testimonyHandler.RestoreTrashedTestimony))
 				})
 			})
 
 			// Projects (admin)
 			r.Route("/project", func(r chi.Router) {
 				r.Get("/", projectHandler.GetProjectPage)
-				r.Patch("/", customMiddleware.RemoveCache(redis, "cache:/api/v1/project", projectHandler.UpdateProjectPage))
+				r.Get("/preview", projectHandler.PreviewProjectPage)
+				r.With(canEditProjects).Patch("/", projectHandler.UpdateProjectPage)
+				r.With(canEditProjects).Post("/publish", customMiddleware.RemoveCache(redis, "project_page_cache", projectHandler.PublishProjectPage))
+				r.With(canEditProjects).Delete("/draft", projectHandler.DiscardProjectPageDraft)
+				r.Get("/schedule", projectHandler.GetProjectPageSchedule)
+				r.With(canEditProjects).Put("/schedule", customMiddleware.RemoveCache(redis, "project_page_cache", projectHandler.UpdateProjectPageSchedule))
+				r.With(canEditProjects).Post("/revisions/{revisionID}/restore", customMiddleware.RemoveCache(redis, "project_page_cache", projectHandler.RestoreProjectPage))
 
 				r.Route("/items", func(r chi.Router) {
-					r.Get("/", projectHandler.GetProjects)
-					r.Post("/image", imageHandler.UploadProjectImage)
-					r.Post("/", customMiddleware.RemoveCache(redis, "cache:/api/v1/project/items", projectHandler.CreateProject))
-					r.Patch("/{id}", customMiddleware.RemoveCache(redis, "cache:/api/v1/project/items", projectHandler.UpdateProject))
-					r.Delete("/{id}", customMiddleware.RemoveCache(redis, "cache:/api/v1/project/items", projectHandler.DeleteProject))
+					r.Get("/", projectHandler.GetAllProjects)
+					r.With(canUploadImages).Post("/image", imageHandler.UploadProjectImage)
+					r.With(canEditProjects).Post("/", projectHandler.CreateProject)
+					r.With(canEditProjects).Put("/order", customMiddleware.RemoveCaches(redis, projectCacheKeys, projectHandler.ReorderProjects))
+					r.Get("/{id}/preview", projectHandler.PreviewProject)
+					r.With(canEditProjects).Patch("/{id}", projectHandler.UpdateProject)
+					r.With(canEditProjects).Post("/{id}/publish", customMiddleware.RemoveCaches(redis, projectCacheKeys, projectHandler.PublishProject))
+					r.With(canEditProjects).Post("/{id}/unpublish", customMiddleware.RemoveCaches(redis, projectCacheKeys, projectHandler.UnpublishProject))
+					r.With(canEditProjects).Delete("/{id}/draft", projectHandler.DiscardProjectDraft)
+					r.With(canEditProjects).Put("/{id}/featured", customMiddleware.RemoveCaches(redis, projectCacheKeys, projectHandler.SetProjectFeatured))
+					r.Get("/{id}/schedule", projectHandler.GetProjectSchedule)
+					r.With(canEditProjects).Put("/{id}/schedule", customMiddleware.RemoveCaches(redis, projectCacheKeys, projectHandler.UpdateProjectSchedule))
+					r.With(canEditProjects).Post("/{id}/revisions/{revisionID}/restore", customMiddleware.RemoveCaches(redis, projectCacheKeys, projectHandler.RestoreProject))
+					r.With(canEditProjects).Delete("/{id}", customMiddleware.RemoveCaches(redis, projectCacheKeys, projectHandler.DeleteProject))
+					r.Get("/trash", projectHandler.GetTrashedProjects)
+					r.With(canEditProjects).Post("/{id}/restore", customMiddleware.RemoveCaches(redis, projectCacheKeys, projectHandler.RestoreTrashedProject))
 				})
 			})
+
+			// Technologies (admin)
+			r.Route("/technologies", func(r chi.Router) {
+				r.Get("/", technologyHandler.GetTechnologies)
+				r.With(canEditProjects).Post("/", technologyHandler.CreateTechnology)
+				r.With(canEditProjects).Patch("/{id}", customMiddleware.RemoveCaches(redis, technologyCacheKeys, technologyHandler.UpdateTechnology))
+				r.With(canEditProjects).Post("/{id}/merge", customMiddleware.RemoveCaches(redis, technologyCacheKeys, technologyHandler.MergeTechnology))
+				r.With(canEditProjects).Delete("/{id}", technologyHandler.DeleteTechnology)
+			})
+
+			// Resume (admin)
+			r.With(canEditHero, canEditAbout, canEditProjects).Post("/resume/import", customMiddleware.RemoveCachesUnless(redis, resumeCacheKeys, resume.IsDryRun, resumeHandler.ImportResume))
+
+			// Users (admin)
+			r.Route("/users", func(r chi.Router) {
+				r.Use(canManageUsers)
+
+				r.Get("/", userHandler.GetUsers)
+				r.Post("/", userHandler.CreateUser)
+				r.Get("/{id}", userHandler.GetUser)
+				r.Patch("/{id}", userHandler.UpdateUser)
+				r.Delete("/{id}", userHandler.DeleteUser)
+			})
+
+			// Security (admin)
+			r.Route("/security", func(r chi.Router) {
+				r.Use(canReadSecurity)
+
+				r.Get("/logins", authHandler.GetLoginAttempts)
+			})
+
+			// API keys (admin)
+			r.Route("/api-keys", func(r chi.Router) {
+				r.Use(canManageAPIKeys)
+
+				r.Get("/", apiKeyHandler.GetAPIKeys)
+				r.Post("/", apiKeyHandler.CreateAPIKey)
+				r.Delete("/{id}", apiKeyHandler.DeleteAPIKey)
+			})
+
+			// Revisions (admin)
+			r.Route("/revisions", func(r chi.Router) {
+				r.Get("/", contentHandler.GetRevisions)
+				r.Get("/diff", contentHandler.DiffRevisions)
+				r.Get("/{id}", contentHandler.GetRevision)
+			})
+
+			// Translations (admin)
+			r.Route("/translations", func(r chi.Router) {
+				r.Get("/", translationHandler.GetTranslations)
+				r.Get("/locales", translationHandler.GetLocales)
+				r.Get("/missing", translationHandler.GetMissing)
+				r.With(canEditTranslations).Put("/", customMiddleware.RemoveCaches(redis, translatedCacheKeys, translationHandler.SaveTranslation))
+				r.With(canEditTranslations).Delete("/{id}", customMiddleware.RemoveCaches(redis, translatedCacheKeys, translationHandler.DeleteTranslation))
+			})
+
+			// Audit log (admin)
+			r.With(canReadAudit).Get("/audit", auditHandler.GetAuditLogs)
 		})
 	})
 
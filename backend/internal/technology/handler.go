@@ -0,0 +1,132 @@
+package technology
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/othersidedrl/portfolio/backend/internal/utils"
+	"gorm.io/gorm"
+)
+
+type Handler struct {
+	service *Service
+}
+
+func NewHandler(service *Service) *Handler {
+	return &Handler{service}
+}
+
+func (h *Handler) GetTechnologies(w http.ResponseWriter, r *http.Request) {
+	technologies, err := h.service.GetTechnologies(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeList(w, technologies)
+}
+
+func (h *Handler) GetPublicTechnologies(w http.ResponseWriter, r *http.Request) {
+	technologies, err := h.service.GetPublicTechnologies(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeList(w, technologies)
+}
+
+func writeList(w http.ResponseWriter, technologies []TechnologyUsageDto) {
+	response := map[string]interface{}{
+		"length": len(technologies),
+		"data":   technologies,
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+func (h *Handler) CreateTechnology(w http.ResponseWriter, r *http.Request) {
+	var body TechnologyDto
+	if err := utils.DecodeBody(r, &body); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := h.service.CreateTechnology(r.Context(), &body); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(body)
+}
+
+func (h *Handler) UpdateTechnology(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid technology ID", http.StatusBadRequest)
+		return
+	}
+	var body TechnologyDto
+	if err := utils.DecodeBody(r, &body); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := h.service.UpdateTechnology(r.Context(), body, uint(id)); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"message": "Technology updated"})
+}
+
+// MergeTechnology handles POST /admin/technologies/{id}/merge with the ID
+// of the technology to merge it into
+func (h *Handler) MergeTechnology(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid technology ID", http.StatusBadRequest)
+		return
+	}
+	var body MergeDto
+	if err := utils.DecodeBody(r, &body); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := h.service.MergeTechnology(r.Context(), uint(id), body.Into); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"message": "Technologies merged"})
+}
+
+func (h *Handler) DeleteTechnology(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid technology ID", http.StatusBadRequest)
+		return
+	}
+	if err := h.service.DeleteTechnology(r.Context(), uint(id)); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeError maps service errors to HTTP status codes
+func writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, ErrInvalidName), errors.Is(err, ErrInvalidCategory), errors.Is(err, ErrSelfMerge):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, ErrConflict), errors.Is(err, ErrInUse):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,39 @@
+package technology
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// LinkTable is a join table between technologies and the content using them
+type LinkTable struct {
+	Name        string
+	OwnerColumn string
+}
+
+var (
+	ProjectLinks = LinkTable{Name: "project_technologies", OwnerColumn: "project_id"}
+	SkillLinks   = LinkTable{Name: "skill_technologies", OwnerColumn: "technical_skills_id"}
+)
+
+// Link replaces the owner's links with the technologies of the given
+// canonical names. Run it in the transaction that writes the owner.
+func (t LinkTable) Link(tx *gorm.DB, ownerID uint, names []string) error {
+	if err := t.Unlink(tx, []uint{ownerID}); err != nil {
+		return err
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	return tx.Exec(
+		fmt.Sprintf("INSERT INTO %s (%s, technology_id) SELECT ?, id FROM technologies WHERE name IN ?", t.Name, t.OwnerColumn),
+		ownerID, names,
+	).Error
+}
+
+// Unlink removes the links of the given owners, a list of IDs or a subquery
+// selecting them
+func (t LinkTable) Unlink(tx *gorm.DB, ownerIDs interface{}) error {
+	return tx.Exec(fmt.Sprintf("DELETE FROM %s WHERE %s IN (?)", t.Name, t.OwnerColumn), ownerIDs).Error
+}
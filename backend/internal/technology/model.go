@@ -0,0 +1,36 @@
+package technology
+
+import "github.com/othersidedrl/portfolio/backend/internal/models"
+
+type TechnologyDto struct {
+	ID       uint     `json:"id"`
+	Name     string   `json:"name"`
+	Aliases  []string `json:"aliases"`
+	Icon     string   `json:"icon"`
+	Category string   `json:"category"`
+}
+
+// TechnologyUsageDto is a technology with how many projects and skills use it
+type TechnologyUsageDto struct {
+	TechnologyDto
+	ProjectCount int64 `json:"project_count"`
+	SkillCount   int64 `json:"skill_count"`
+}
+
+type MergeDto struct {
+	Into uint `json:"into"`
+}
+
+func ToTechnologyDto(tech models.Technology) TechnologyDto {
+	aliases := []string(tech.Aliases)
+	if aliases == nil {
+		aliases = []string{}
+	}
+	return TechnologyDto{
+		ID:       tech.ID,
+		Name:     tech.Name,
+		Aliases:  aliases,
+		Icon:     tech.Icon,
+		Category: string(tech.Category),
+	}
+}
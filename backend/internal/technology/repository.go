@@ -0,0 +1,209 @@
+package technology
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/othersidedrl/portfolio/backend/internal/database"
+	"github.com/othersidedrl/portfolio/backend/internal/models"
+	"github.com/othersidedrl/portfolio/backend/internal/search"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TechnologyRepository interface {
+	FindAll(ctx context.Context) ([]TechnologyUsageDto, error)
+	FindUsed(ctx context.Context) ([]TechnologyUsageDto, error)
+	Find(ctx context.Context, id uint) (*models.Technology, error)
+	FindByKeys(ctx context.Context, keys []string) ([]models.Technology, error)
+	Create(ctx context.Context, tech *models.Technology) error
+	CreateIfMissing(ctx context.Context, tech *models.Technology) error
+	Update(ctx context.Context, tech *models.Technology, oldName string) error
+	Delete(ctx context.Context, id uint) error
+	Merge(ctx context.Context, from, into *models.Technology) error
+	CountLinks(ctx context.Context, id uint) (int64, error)
+}
+
+type GormTechnologyRepository struct {
+	db *gorm.DB
+}
+
+func NewGormTechnologyRepository(db *gorm.DB) *GormTechnologyRepository {
+	return &GormTechnologyRepository{db: db}
+}
+
+// technologyUsage is a technology with its usage counts, as scanned from usageQuery
+type technologyUsage struct {
+	models.Technology
+	ProjectCount int64
+	SkillCount   int64
+}
+
+// usageQuery counts the live projects and skills linked to each technology.
+// projectFilter narrows the counted projects further.
+const usageQuery = `
+SELECT technologies.*,
+	(SELECT COUNT(*) FROM project_technologies pt
+		JOIN projects p ON p.id = pt.project_id
+		WHERE pt.technology_id = technologies.id AND p.deleted_at IS NULL %s) AS project_count,
+	(SELECT COUNT(*) FROM skill_technologies st
+		JOIN technical_skills s ON s.id = st.technical_skills_id
+		WHERE st.technology_id = technologies.id AND s.deleted_at IS NULL) AS skill_count
+FROM technologies`
+
+// FindAll returns every technology, counting unpublished projects too
+func (r *GormTechnologyRepository) FindAll(ctx context.Context) ([]TechnologyUsageDto, error) {
+	var usage []technologyUsage
+	err := database.Conn(ctx, r.db).
+		Raw(fmt.Sprintf(usageQuery, "") + " ORDER BY technologies.name").
+		Scan(&usage).Error
+	return toUsageDtos(usage), err
+}
+
+// FindUsed returns the technologies used by a public project or a skill,
+// most used first
+func (r *GormTechnologyRepository) FindUsed(ctx context.Context) ([]TechnologyUsageDto, error) {
+	now := time.Now()
+	publicOnly := "AND (p.status = ? OR p.publish_at <= ?) AND (p.unpublish_at IS NULL OR p.unpublish_at > ?)"
+
+	var usage []technologyUsage
+	err := database.Conn(ctx, r.db).
+		Raw("SELECT * FROM ("+fmt.Sprintf(usageQuery, publicOnly)+") counted "+
+			"WHERE project_count + skill_count > 0 "+
+			"ORDER BY project_count + skill_count DESC, name",
+			models.Published, now, now).
+		Scan(&usage).Error
+	return toUsageDtos(usage), err
+}
+
+func toUsageDtos(usage []technologyUsage) []TechnologyUsageDto {
+	dtos := make([]TechnologyUsageDto, 0, len(usage))
+	for _, u := range usage {
+		dtos = append(dtos, TechnologyUsageDto{
+			TechnologyDto: ToTechnologyDto(u.Technology),
+			ProjectCount:  u.ProjectCount,
+			SkillCount:    u.SkillCount,
+		})
+	}
+	return dtos
+}
+
+func (r *GormTechnologyRepository) Find(ctx context.Context, id uint) (*models.Technology, error) {
+	var tech models.Technology
+	if err := database.Conn(ctx, r.db).First(&tech, id).Error; err != nil {
+		return nil, err
+	}
+	return &tech, nil
+}
+
+// FindByKeys returns the technologies whose lowercased name or one of whose
+// aliases is among the keys
+func (r *GormTechnologyRepository) FindByKeys(ctx context.Context, keys []string) ([]models.Technology, error) {
+	var techs []models.Technology
+	if len(keys) == 0 {
+		return techs, nil
+	}
+	err := database.Conn(ctx, r.db).
+		Where("LOWER(name) IN ? OR aliases && ?", keys, pq.StringArray(keys)).
+		Find(&techs).Error
+	return techs, err
+}
+
+func (r *GormTechnologyRepository) Create(ctx context.Context, tech *models.Technology) error {
+	return database.Conn(ctx, r.db).Create(tech).Error
+}
+
+// CreateIfMissing inserts the technology unless one with the same name was
+// created concurrently, in which case tech is left without an ID
+func (r *GormTechnologyRepository) CreateIfMissing(ctx context.Context, tech *models.Technology) error {
+	return database.Conn(ctx, r.db).
+		Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "name"}}, DoNothing: true}).
+		Create(tech).Error
+}
+
+// Update saves the technology. A renamed technology is renamed in the
+// project tech stacks and skill specialities that list it too.
+func (r *GormTechnologyRepository) Update(ctx context.Context, tech *models.Technology, oldName string) error {
+	return database.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(tech).Error; err != nil {
+			return err
+		}
+		if oldName == tech.Name {
+			return nil
+		}
+		return renameInLists(tx, oldName, tech.Name)
+	})
+}
+
+func (r *GormTechnologyRepository) Delete(ctx context.Context, id uint) error {
+	return database.Conn(ctx, r.db).Delete(&models.Technology{}, id).Error
+}
+
+// Merge moves every link of from over to into, renames from to into in the
+// tech stack and speciality lists, saves into and deletes from
+func (r *GormTechnologyRepository) Merge(ctx context.Context, from, into *models.Technology) error {
+	return database.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		for _, links := range []LinkTable{ProjectLinks, SkillLinks} {
+			err := tx.Exec(fmt.Sprintf(
+				"INSERT INTO %[1]s (%[2]s, technology_id) SELECT %[2]s, ? FROM %[1]s WHERE technology_id = ? ON CONFLICT DO NOTHING",
+				links.Name, links.OwnerColumn,
+			), into.ID, from.ID).Error
+			if err != nil {
+				return err
+			}
+			if err := tx.Exec(fmt.Sprintf("DELETE FROM %s WHERE technology_id = ?", links.Name), from.ID).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := renameInLists(tx, from.Name, into.Name); err != nil {
+			return err
+		}
+		if err := tx.Delete(&models.Technology{}, from.ID).Error; err != nil {
+			return err
+		}
+		return tx.Save(into).Error
+	})
+}
+
+// renameInLists replaces a technology name in every project tech stack and
+// skill speciality list, dropping it where the new name is already listed
+func renameInLists(tx *gorm.DB, from, to string) error {
+	for _, list := range []struct {
+		source search.Source
+		column string
+	}{
+		{search.Projects, "tech_stack"},
+		{search.Skills, "specialities"},
+	} {
+		err := tx.Exec(fmt.Sprintf(
+			"UPDATE %[1]s SET %[2]s = CASE WHEN ? = ANY(%[2]s) THEN array_remove(%[2]s, ?) ELSE array_replace(%[2]s, ?, ?) END WHERE ? = ANY(%[2]s)",
+			list.source.Table, list.column,
+		), to, from, from, to, from).Error
+		if err != nil {
+			return err
+		}
+
+		renamed := tx.Table(list.source.Table).Select("id").Where(fmt.Sprintf("? = ANY(%s)", list.column), to)
+		if err := list.source.Refresh(tx, renamed); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CountLinks counts the projects and skills linked to the technology,
+// trashed ones included
+func (r *GormTechnologyRepository) CountLinks(ctx context.Context, id uint) (int64, error) {
+	var total int64
+	for _, links := range []LinkTable{ProjectLinks, SkillLinks} {
+		var count int64
+		if err := database.Conn(ctx, r.db).Table(links.Name).Where("technology_id = ?", id).Count(&count).Error; err != nil {
+			return 0, err
+		}
+		total += count
+	}
+	return total, nil
+}
@@ -0,0 +1,284 @@
+package technology
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/othersidedrl/portfolio/backend/internal/audit"
+	"github.com/othersidedrl/portfolio/backend/internal/models"
+)
+
+var (
+	ErrInvalidName     = errors.New("technology name must be 1 to 64 characters")
+	ErrInvalidCategory = errors.New("invalid technology category")
+	ErrConflict        = errors.New("name or alias already belongs to another technology")
+	ErrInUse           = errors.New("technology is still used by projects or skills, merge it instead")
+	ErrSelfMerge       = errors.New("cannot merge a technology into itself")
+)
+
+var categories = map[models.TechnologyCategory]bool{
+	models.TechLanguage:  true,
+	models.TechFramework: true,
+	models.TechLibrary:   true,
+	models.TechDatabase:  true,
+	models.TechPlatform:  true,
+	models.TechTool:      true,
+	models.TechOther:     true,
+}
+
+type Service struct {
+	repo     TechnologyRepository
+	recorder audit.Recorder
+}
+
+func NewService(repo TechnologyRepository, recorder audit.Recorder) *Service {
+	return &Service{repo, recorder}
+}
+
+func key(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// Resolve maps free-form technology names to their canonical names,
+// matching names and aliases regardless of case. Unknown names become new
+// technologies. Duplicates and blanks are dropped; the order is kept.
+func (s *Service) Resolve(ctx context.Context, names []string) ([]string, error) {
+	return s.resolve(ctx, names, true)
+}
+
+// Canonical maps names like Resolve but creates nothing: unknown names are
+// kept as written. It previews what Resolve would store.
+func (s *Service) Canonical(ctx context.Context, names []string) ([]string, error) {
+	return s.resolve(ctx, names, false)
+}
+
+func (s *Service) resolve(ctx context.Context, names []string, create bool) ([]string, error) {
+	var keys []string
+	for _, name := range names {
+		if k := key(name); k != "" {
+			keys = append(keys, k)
+		}
+	}
+	if len(keys) == 0 {
+		return []string{}, nil
+	}
+
+	canonical, err := s.lookup(ctx, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	resolved := make([]string, 0, len(keys))
+	seen := make(map[string]bool, len(keys))
+	for _, name := range names {
+		k := key(name)
+		if k == "" {
+			continue
+		}
+		if _, ok := canonical[k]; !ok && !create {
+			canonical[k] = strings.TrimSpace(name)
+		} else if !ok {
+			tech := models.Technology{Name: strings.TrimSpace(name), Category: models.TechOther}
+			if len(tech.Name) > 64 {
+				return nil, ErrInvalidName
+			}
+			if err := s.repo.CreateIfMissing(ctx, &tech); err != nil {
+				return nil, err
+			}
+			// Created concurrently under the same name
+			if tech.ID == 0 {
+				found, err := s.lookup(ctx, []string{k})
+				if err != nil {
+					return nil, err
+				}
+				tech.Name = found[k]
+			}
+			canonical[k] = tech.Name
+		}
+
+		if name := canonical[k]; !seen[name] {
+			seen[name] = true
+			resolved = append(resolved, name)
+		}
+	}
+	return resolved, nil
+}
+
+// lookup maps each key that matches a technology to its canonical name
+func (s *Service) lookup(ctx context.Context, keys []string) (map[string]string, error) {
+	techs, err := s.repo.FindByKeys(ctx, keys)
+	if err != nil {
+		return nil, err
+	}
+	canonical := make(map[string]string, len(keys))
+	for _, tech := range techs {
+		canonical[key(tech.Name)] = tech.Name
+		for _, alias := range tech.Aliases {
+			canonical[alias] = tech.Name
+		}
+	}
+	return canonical, nil
+}
+
+// GetTechnologies lists every technology for the admin
+func (s *Service) GetTechnologies(ctx context.Context) ([]TechnologyUsageDto, error) {
+	return s.repo.FindAll(ctx)
+}
+
+// GetPublicTechnologies lists the technologies used on the public site
+func (s *Service) GetPublicTechnologies(ctx context.Context) ([]TechnologyUsageDto, error) {
+	return s.repo.FindUsed(ctx)
+}
+
+func (s *Service) CreateTechnology(ctx context.Context, data *TechnologyDto) error {
+	tech := models.Technology{}
+	if err := s.apply(ctx, &tech, *data); err != nil {
+		return err
+	}
+	if err := s.repo.Create(ctx, &tech); err != nil {
+		return err
+	}
+
+	*data = ToTechnologyDto(tech)
+	s.recorder.Record(ctx, models.AuditCreate, models.EntityTechnology, tech.ID, nil, data)
+	return nil
+}
+
+// UpdateTechnology edits a technology. Only the fields that are set change:
+// a blank name, icon or category and nil aliases keep their stored value. A
+// renamed technology keeps its old name as an alias so drafts and revisions
+// listing it still resolve.
+func (s *Service) UpdateTechnology(ctx context.Context, data TechnologyDto, id uint) error {
+	tech, err := s.repo.Find(ctx, id)
+	if err != nil {
+		return err
+	}
+	before := ToTechnologyDto(*tech)
+
+	oldName := tech.Name
+	if strings.TrimSpace(data.Name) == "" {
+		data.Name = oldName
+	}
+	if data.Aliases == nil {
+		data.Aliases = append([]string{}, tech.Aliases...)
+	}
+	if strings.TrimSpace(data.Icon) == "" {
+		data.Icon = tech.Icon
+	}
+	if data.Category == "" {
+		data.Category = string(tech.Category)
+	}
+	if strings.TrimSpace(data.Name) != oldName {
+		data.Aliases = append(data.Aliases, oldName)
+	}
+	if err := s.apply(ctx, tech, data); err != nil {
+		return err
+	}
+	if err := s.repo.Update(ctx, tech, oldName); err != nil {
+		return err
+	}
+
+	s.recorder.Record(ctx, models.AuditUpdate, models.EntityTechnology, id, before, ToTechnologyDto(*tech))
+	return nil
+}
+
+// apply validates data and copies it onto tech
+func (s *Service) apply(ctx context.Context, tech *models.Technology, data TechnologyDto) error {
+	name := strings.TrimSpace(data.Name)
+	if name == "" || len(name) > 64 {
+		return ErrInvalidName
+	}
+
+	category := models.TechnologyCategory(data.Category)
+	if category == "" {
+		category = models.TechOther
+	}
+	if !categories[category] {
+		return ErrInvalidCategory
+	}
+
+	aliases := []string{}
+	seen := map[string]bool{key(name): true}
+	for _, alias := range data.Aliases {
+		if k := key(alias); k != "" && !seen[k] {
+			seen[k] = true
+			aliases = append(aliases, k)
+		}
+	}
+
+	keys := append([]string{key(name)}, aliases...)
+	taken, err := s.repo.FindByKeys(ctx, keys)
+	if err != nil {
+		return err
+	}
+	for _, other := range taken {
+		if other.ID != tech.ID {
+			return ErrConflict
+		}
+	}
+
+	tech.Name = name
+	tech.Aliases = aliases
+	tech.Icon = strings.TrimSpace(data.Icon)
+	tech.Category = category
+	return nil
+}
+
+// DeleteTechnology removes a technology nothing uses anymore
+func (s *Service) DeleteTechnology(ctx context.Context, id uint) error {
+	tech, err := s.repo.Find(ctx, id)
+	if err != nil {
+		return err
+	}
+	links, err := s.repo.CountLinks(ctx, id)
+	if err != nil {
+		return err
+	}
+	if links > 0 {
+		return ErrInUse
+	}
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+	s.recorder.Record(ctx, models.AuditDelete, models.EntityTechnology, id, ToTechnologyDto(*tech), nil)
+	return nil
+}
+
+// MergeTechnology folds a duplicate into another technology: its projects
+// and skills move over, and its name and aliases become aliases of the other
+func (s *Service) MergeTechnology(ctx context.Context, id, intoID uint) error {
+	if id == intoID {
+		return ErrSelfMerge
+	}
+	from, err := s.repo.Find(ctx, id)
+	if err != nil {
+		return err
+	}
+	into, err := s.repo.Find(ctx, intoID)
+	if err != nil {
+		return err
+	}
+	before := ToTechnologyDto(*into)
+
+	candidates := append([]string{}, into.Aliases...)
+	candidates = append(candidates, key(from.Name))
+	candidates = append(candidates, from.Aliases...)
+	aliases := []string{}
+	seen := map[string]bool{key(into.Name): true}
+	for _, alias := range candidates {
+		if !seen[alias] {
+			seen[alias] = true
+			aliases = append(aliases, alias)
+		}
+	}
+	into.Aliases = aliases
+
+	if err := s.repo.Merge(ctx, from, into); err != nil {
+		return err
+	}
+	s.recorder.Record(ctx, models.AuditDelete, models.EntityTechnology, id, ToTechnologyDto(*from), nil)
+	s.recorder.Record(ctx, models.AuditUpdate, models.EntityTechnology, intoID, before, ToTechnologyDto(*into))
+	return nil
+}
@@ -0,0 +1,107 @@
+package technology
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/othersidedrl/portfolio/backend/internal/models"
+)
+
+// fakeTechnologyRepository holds one stored technology and records updates.
+// Methods the tests do not use panic through the nil embedded interface.
+type fakeTechnologyRepository struct {
+	TechnologyRepository
+	tech    models.Technology
+	updated *models.Technology
+	oldName string
+}
+
+func (r *fakeTechnologyRepository) Find(ctx context.Context, id uint) (*models.Technology, error) {
+	tech := r.tech
+	tech.Aliases = append([]string{}, r.tech.Aliases...)
+	return &tech, nil
+}
+
+func (r *fakeTechnologyRepository) FindByKeys(ctx context.Context, keys []string) ([]models.Technology, error) {
+	return []models.Technology{r.tech}, nil
+}
+
+func (r *fakeTechnologyRepository) Update(ctx context.Context, tech *models.Technology, oldName string) error {
+	r.updated, r.oldName = tech, oldName
+	return nil
+}
+
+type nopRecorder struct{}
+
+func (nopRecorder) Record(ctx context.Context, action models.AuditAction, entityType models.EntityType, entityID interface{}, before, after interface{}) {
+}
+
+func TestUpdateTechnologyKeepsUnsetFields(t *testing.T) {
+	stored := models.Technology{
+		ID:       1,
+		Name:     "Go",
+		Aliases:  []string{"golang"},
+		Icon:     "go.svg",
+		Category: models.TechLanguage,
+	}
+
+	tests := []struct {
+		name string
+		data TechnologyDto
+		want models.Technology
+	}{
+		{"rename only", TechnologyDto{Name: "Go Lang"},
+			models.Technology{ID: 1, Name: "Go Lang", Aliases: []string{"golang", "go"}, Icon: "go.svg", Category: models.TechLanguage}},
+		{"icon only", TechnologyDto{Icon: "gopher.svg"},
+			models.Technology{ID: 1, Name: "Go", Aliases: []string{"golang"}, Icon: "gopher.svg", Category: models.TechLanguage}},
+		{"category only", TechnologyDto{Category: string(models.TechTool)},
+			models.Technology{ID: 1, Name: "Go", Aliases: []string{"golang"}, Icon: "go.svg", Category: models.TechTool}},
+		{"empty aliases clear them", TechnologyDto{Aliases: []string{}},
+			models.Technology{ID: 1, Name: "Go", Aliases: []string{}, Icon: "go.svg", Category: models.TechLanguage}},
+		{"new aliases replace them", TechnologyDto{Aliases: []string{"Go1", "golang"}},
+			models.Technology{ID: 1, Name: "Go", Aliases: []string{"go1", "golang"}, Icon: "go.svg", Category: models.TechLanguage}},
+		{"case change adds no alias", TechnologyDto{Name: "GO"},
+			models.Technology{ID: 1, Name: "GO", Aliases: []string{"golang"}, Icon: "go.svg", Category: models.TechLanguage}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeTechnologyRepository{tech: stored}
+			service := NewService(repo, nopRecorder{})
+
+			if err := service.UpdateTechnology(context.Background(), tt.data, 1); err != nil {
+				t.Fatal(err)
+			}
+			if repo.updated == nil {
+				t.Fatal("technology not saved")
+			}
+			if !reflect.DeepEqual(*repo.updated, tt.want) {
+				t.Errorf("saved %+v, want %+v", *repo.updated, tt.want)
+			}
+			if repo.oldName != "Go" {
+				t.Errorf("old name = %q, want %q", repo.oldName, "Go")
+			}
+		})
+	}
+}
+
+func TestUpdateTechnologyRejectsTakenAlias(t *testing.T) {
+	repo := &takenRepository{&fakeTechnologyRepository{tech: models.Technology{ID: 2, Name: "Rust"}}}
+	service := NewService(repo, nopRecorder{})
+
+	err := service.UpdateTechnology(context.Background(), TechnologyDto{Aliases: []string{"golang"}}, 2)
+	if !errors.Is(err, ErrConflict) {
+		t.Errorf("err = %v, want %v", err, ErrConflict)
+	}
+}
+
+// takenRepository reports every key as belonging to another technology
+type takenRepository struct {
+	*fakeTechnologyRepository
+}
+
+func (r *takenRepository) FindByKeys(ctx context.Context, keys []string) ([]models.Technology, error) {
+	return []models.Technology{{ID: 1, Name: "Go", Aliases: []string{"golang"}}}, nil
+}
@@ -2,11 +2,14 @@ package testimony
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/othersidedrl/portfolio/backend/internal/content"
 	"github.com/othersidedrl/portfolio/backend/internal/utils"
+	"gorm.io/gorm"
 )
 
 type Handler struct {
@@ -27,19 +30,101 @@ func (h *Handler) GetTestimonyPage(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(page)
 }
 
+func (h *Handler) GetPublicTestimonyPage(w http.ResponseWriter, r *http.Request) {
+	page, err := h.service.GetPublicTestimonyPage(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(page)
+}
+
+func (h *Handler) GetTestimonyPageSchedule(w http.ResponseWriter, r *http.Request) {
+	schedule, err := h.service.GetTestimonyPageSchedule(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(schedule)
+}
+
+func (h *Handler) UpdateTestimonyPageSchedule(w http.ResponseWriter, r *http.Request) {
+	var body content.ScheduleDto
+	if err := utils.DecodeBody(r, &body); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.SetTestimonyPageSchedule(r.Context(), body); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"message": "Testimony page schedule updated"})
+}
+
 func (h *Handler) UpdateTestimonyPage(w http.ResponseWriter, r *http.Request) {
 	var body TestimonyPageDto
 	if err := utils.DecodeBody(r, &body); err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
-	if err := h.service.UpdateTestimonyPage(r.Context(), &body); err != nil {
+	if err := h.service.SaveTestimonyPageDraft(r.Context(), &body); err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 	w.WriteHeader(http.StatusOK)
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]string{"message": "Testimony page updated"})
+	json.NewEncoder(w).Encode(map[string]string{"message": "Testimony page draft saved"})
+}
+
+func (h *Handler) PreviewTestimonyPage(w http.ResponseWriter, r *http.Request) {
+	page, err := h.service.PreviewTestimonyPage(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(page)
+}
+
+func (h *Handler) PublishTestimonyPage(w http.ResponseWriter, r *http.Request) {
+	if err := h.service.PublishTestimonyPage(r.Context()); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"message": "Testimony page published"})
+}
+
+func (h *Handler) DiscardTestimonyPageDraft(w http.ResponseWriter, r *http.Request) {
+	if err := h.service.DiscardTestimonyPageDraft(r.Context()); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) RestoreTestimonyPage(w http.ResponseWriter, r *http.Request) {
+	revisionID, err := strconv.Atoi(chi.URLParam(r, "revisionID"))
+	if err != nil {
+		http.Error(w, "Invalid revision ID", http.StatusBadRequest)
+		return
+	}
+	if err := h.service.RestoreTestimonyPage(r.Context(), uint(revisionID)); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"message": "Testimony page restored"})
 }
 
 func (h *Handler) GetTestimonies(w http.ResponseWriter, r *http.Request) {
@@ -105,7 +190,7 @@ func (h *Handler) UpdateTestimony(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if err := h.service.UpdateTestimony(r.Context(), &body, uint(id)); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, err)
 		return
 	}
 	w.WriteHeader(http.StatusOK)
@@ -125,7 +210,7 @@ func (h *Handler) ApproveTestimony(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if err := h.service.ApproveTestimony(r.Context(), &body, uint(id)); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, err)
 		return
 	}
 	w.WriteHeader(http.StatusNoContent)
@@ -139,9 +224,49 @@ func (h *Handler) DeleteTestimony(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if err := h.service.DeleteTestimony(r.Context(), uint(id)); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, err)
 		return
 	}
 	w.WriteHeader(http.StatusNoContent)
 	w.Header().Set("Content-Type", "application/json")
 }
+
+func (h *Handler) GetTrashedTestimonies(w http.ResponseWriter, r *http.Request) {
+	trashed, err := h.service.GetTrashedTestimonies(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	response := map[string]interface{}{
+		"length": len(trashed),
+		"data":   trashed,
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+func (h *Handler) RestoreTrashedTestimony(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid testimony ID", http.StatusBadRequest)
+		return
+	}
+	if err := h.service.RestoreTrashedTestimony(r.Context(), uint(id)); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"message": "Testimony restored"})
+}
+
+// writeError maps service errors to HTTP status codes
+func writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	default:
+		content.WriteError(w, err)
+	}
+}
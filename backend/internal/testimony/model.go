@@ -1,5 +1,7 @@
 package testimony
 
+import "time"
+
 type TestimonyPageDto struct {
 	Title       string `json:"title"`
 	Description string `json:"description"`
@@ -16,6 +18,11 @@ type TestimonyItemDto struct {
 	Approved    bool   `json:"approved"`
 }
 
+type TrashedTestimonyDto struct {
+	TestimonyItemDto
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
 type TestimonyDto struct {
 	Testimonies []TestimonyItemDto `json:"testimonies"`
 }
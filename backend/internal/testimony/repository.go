@@ -3,20 +3,29 @@ package testimony
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/othersidedrl/portfolio/backend/internal/models"
+	"github.com/othersidedrl/portfolio/backend/internal/search"
 	"gorm.io/gorm"
 )
 
 type TestimonyRepository interface {
 	GetTestimonyPage(ctx context.Context) (*TestimonyPageDto, error)
+	GetPublicTestimonyPage(ctx context.Context) (*TestimonyPageDto, error)
+	GetTestimonyPageSchedule(ctx context.Context) (*models.Schedule, error)
+	SetTestimonyPageSchedule(ctx context.Context, schedule models.Schedule) error
 	UpdateTestimonyPage(ctx context.Context, data *TestimonyPageDto) error
 	GetTestimonies(ctx context.Context) (*TestimonyDto, error)
 	GetApprovedTestimonies(ctx context.Context) (*TestimonyDto, error)
+	GetTestimony(ctx context.Context, id uint) (*TestimonyItemDto, error)
 	CreateTestimony(ctx context.Context, data *TestimonyItemDto) error
 	UpdateTestimony(ctx context.Context, data *TestimonyItemDto, id uint) error
 	ApproveTestimony(ctx context.Context, data *ApproveTestimonyDto, id uint) error
 	DeleteTestimony(ctx context.Context, id uint) error
+	GetTrashedTestimonies(ctx context.Context) ([]TrashedTestimonyDto, error)
+	RestoreTrashedTestimony(ctx context.Context, id uint) error
+	PurgeTestimonies(ctx context.Context, before time.Time) (int64, error)
 }
 
 type GormTestimonyRepository struct {
@@ -28,8 +37,17 @@ func NewGormTestimonyRepository(db *gorm.DB) *GormTestimonyRepository {
 }
 
 func (r *GormTestimonyRepository) GetTestimonyPage(ctx context.Context) (*TestimonyPageDto, error) {
+	return r.findTestimonyPage(ctx)
+}
+
+// GetPublicTestimonyPage retrieves the testimony page unless its unpublish time has passed
+func (r *GormTestimonyRepository) GetPublicTestimonyPage(ctx context.Context) (*TestimonyPageDto, error) {
+	return r.findTestimonyPage(ctx, models.NotUnpublished(time.Now()))
+}
+
+func (r *GormTestimonyRepository) findTestimonyPage(ctx context.Context, scopes ...func(*gorm.DB) *gorm.DB) (*TestimonyPageDto, error) {
 	var page models.TestimonyPage
-	if err := r.db.WithContext(ctx).First(&page).Error; err != nil {
+	if err := r.db.WithContext(ctx).Scopes(scopes...).First(&page).Error; err != nil {
 		return nil, err
 	}
 	return &TestimonyPageDto{
@@ -38,6 +56,24 @@ func (r *GormTestimonyRepository) GetTestimonyPage(ctx context.Context) (*Testim
 	}, nil
 }
 
+func (r *GormTestimonyRepository) GetTestimonyPageSchedule(ctx context.Context) (*models.Schedule, error) {
+	var page models.TestimonyPage
+	if err := r.db.WithContext(ctx).First(&page).Error; err != nil {
+		return nil, err
+	}
+	return &page.Schedule, nil
+}
+
+func (r *GormTestimonyRepository) SetTestimonyPageSchedule(ctx context.Context, schedule models.Schedule) error {
+	var page models.TestimonyPage
+	if err := r.db.WithContext(ctx).First(&page).Error; err != nil {
+		return err
+	}
+	return r.db.WithContext(ctx).Model(&page).
+		Select("publish_at", "unpublish_at").
+		Updates(&models.TestimonyPage{Schedule: schedule}).Error
+}
+
 func (r *GormTestimonyRepository) UpdateTestimonyPage(ctx context.Context, data *TestimonyPageDto) error {
 	var page models.TestimonyPage
 	if err := r.db.WithContext(ctx).First(&page).Error; err != nil {
@@ -61,16 +97,7 @@ func (r *GormTestimonyRepository) GetTestimonies(ctx context.Context) (*Testimon
 	}
 	var dtoTestimonies []TestimonyItemDto
 	for _, t := range testimonies {
-		dtoTestimonies = append(dtoTestimonies, TestimonyItemDto{
-			ID:          int(t.ID),
-			Name:        t.Name,
-			ProfileUrl:  t.ProfileUrl,
-			Affiliation: t.Affiliation,
-			Rating:      t.Rating,
-			Description: t.Description,
-			AISummary:   t.AISummary,
-			Approved:    t.Approved,
-		})
+		dtoTestimonies = append(dtoTestimonies, toTestimonyItemDto(t))
 	}
 	return &TestimonyDto{Testimonies: dtoTestimonies}, nil
 }
@@ -82,20 +109,33 @@ func (r *GormTestimonyRepository) GetApprovedTestimonies(ctx context.Context) (*
 	}
 	var dtoTestimonies []TestimonyItemDto
 	for _, t := range testimonies {
-		dtoTestimonies = append(dtoTestimonies, TestimonyItemDto{
-			ID:          int(t.ID),
-			Name:        t.Name,
-			ProfileUrl:  t.ProfileUrl,
-			Affiliation: t.Affiliation,
-			Rating:      t.Rating,
-			Description: t.Description,
-			AISummary:   t.AISummary,
-			Approved:    t.Approved,
-		})
+		dtoTestimonies = append(dtoTestimonies, toTestimonyItemDto(t))
 	}
 	return &TestimonyDto{Testimonies: dtoTestimonies}, nil
 }
 
+func (r *GormTestimonyRepository) GetTestimony(ctx context.Context, id uint) (*TestimonyItemDto, error) {
+	var t models.Testimony
+	if err := r.db.WithContext(ctx).First(&t, id).Error; err != nil {
+		return nil, err
+	}
+	dto := toTestimonyItemDto(t)
+	return &dto, nil
+}
+
+func toTestimonyItemDto(t models.Testimony) TestimonyItemDto {
+	return TestimonyItemDto{
+		ID:          int(t.ID),
+		Name:        t.Name,
+		ProfileUrl:  t.ProfileUrl,
+		Affiliation: t.Affiliation,
+		Rating:      t.Rating,
+		Description: t.Description,
+		AISummary:   t.AISummary,
+		Approved:    t.Approved,
+	}
+}
+
 func (r *GormTestimonyRepository) CreateTestimony(ctx context.Context, data *TestimonyItemDto) error {
 	testimony := models.Testimony{
 		Name:        data.Name,
@@ -106,25 +146,83 @@ func (r *GormTestimonyRepository) CreateTestimony(ctx context.Context, data *Tes
 		AISummary:   data.AISummary,
 		Approved:    false,
 	}
-	return r.db.WithContext(ctx).Create(&testimony).Error
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&testimony).Error; err != nil {
+			return err
+		}
+		return search.Testimonies.Refresh(tx, testimony.ID)
+	})
 }
 
 func (r *GormTestimonyRepository) UpdateTestimony(ctx context.Context, data *TestimonyItemDto, id uint) error {
-	return r.db.WithContext(ctx).Where("id = ?", id).Updates(&models.Testimony{
-		Name:        data.Name,
-		ProfileUrl:  data.ProfileUrl,
-		Affiliation: data.Affiliation,
-		Rating:      data.Rating,
-		Description: data.Description,
-		AISummary:   data.AISummary,
-		Approved:    data.Approved,
-	}).Error
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("id = ?", id).Updates(&models.Testimony{
+			Name:        data.Name,
+			ProfileUrl:  data.ProfileUrl,
+			Affiliation: data.Affiliation,
+			Rating:      data.Rating,
+			Description: data.Description,
+			AISummary:   data.AISummary,
+			Approved:    data.Approved,
+		}).Error
+		if err != nil {
+			return err
+		}
+		return search.Testimonies.Refresh(tx, id)
+	})
 }
 
 func (r *GormTestimonyRepository) ApproveTestimony(ctx context.Context, data *ApproveTestimonyDto, id uint) error {
 	return r.db.WithContext(ctx).Model(&models.Testimony{}).Where("id = ?", id).Update("approved", data.Approved).Error
 }
 
+// DeleteTestimony moves the testimony to the trash
 func (r *GormTestimonyRepository) DeleteTestimony(ctx context.Context, id uint) error {
-	return r.db.WithContext(ctx).Where("id = ?", id).Unscoped().Delete(&models.Testimony{}).Error
+	return r.db.WithContext(ctx).Where("id = ?", id).Delete(&models.Testimony{}).Error
+}
+
+func (r *GormTestimonyRepository) GetTrashedTestimonies(ctx context.Context) ([]TrashedTestimonyDto, error) {
+	var testimonies []models.Testimony
+	if err := r.db.WithContext(ctx).Scopes(models.Trashed).Order("deleted_at DESC").Find(&testimonies).Error; err != nil {
+		return nil, err
+	}
+	trashed := make([]TrashedTestimonyDto, 0, len(testimonies))
+	for _, t := range testimonies {
+		trashed = append(trashed, TrashedTestimonyDto{
+			TestimonyItemDto: toTestimonyItemDto(t),
+			DeletedAt:        t.DeletedAt.Time,
+		})
+	}
+	return trashed, nil
+}
+
+// RestoreTrashedTestimony takes the testimony out of the trash
+func (r *GormTestimonyRepository) RestoreTrashedTestimony(ctx context.Context, id uint) error {
+	result := r.db.WithContext(ctx).Model(&models.Testimony{}).
+		Scopes(models.Trashed).
+		Where("id = ?", id).
+		Update("deleted_at", nil)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// PurgeTestimonies permanently deletes the testimonies trashed before the
+// given time, along with their drafts, revisions and translations
+func (r *GormTestimonyRepository) PurgeTestimonies(ctx context.Context, before time.Time) (int64, error) {
+	var purged int64
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		trashed := tx.Model(&models.Testimony{}).Scopes(models.TrashedBefore(before)).Select("id")
+		if err := models.PurgeEntityContent(tx, models.EntityTestimony, trashed); err != nil {
+			return err
+		}
+		result := tx.Scopes(models.TrashedBefore(before)).Delete(&models.Testimony{})
+		purged = result.RowsAffected
+		return result.Error
+	})
+	return purged, err
 }
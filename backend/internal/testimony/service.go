@@ -4,26 +4,184 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
+	"time"
+
+	"github.com/othersidedrl/portfolio/backend/internal/audit"
+	"github.com/othersidedrl/portfolio/backend/internal/content"
+	"github.com/othersidedrl/portfolio/backend/internal/models"
+	"github.com/othersidedrl/portfolio/backend/internal/translation"
+	"gorm.io/gorm"
 )
 
 type Service struct {
-	repo TestimonyRepository
+	repo         TestimonyRepository
+	versions     *content.Service
+	translations *translation.Service
+	recorder     audit.Recorder
 }
 
-func NewService(repo TestimonyRepository) *Service {
-	return &Service{repo}
+func NewService(repo TestimonyRepository, versions *content.Service, translations *translation.Service, recorder audit.Recorder) *Service {
+	return &Service{repo, versions, translations, recorder}
 }
 
 func (s *Service) GetTestimonyPage(ctx context.Context) (*TestimonyPageDto, error) {
 	return s.repo.GetTestimonyPage(ctx)
 }
 
-func (s *Service) UpdateTestimonyPage(ctx context.Context, data *TestimonyPageDto) error {
-	return s.repo.UpdateTestimonyPage(ctx, data)
+// GetPublicTestimonyPage returns the testimony page for the public site
+func (s *Service) GetPublicTestimonyPage(ctx context.Context) (*TestimonyPageDto, error) {
+	page, err := s.repo.GetPublicTestimonyPage(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.translations.Translate(ctx, models.EntityTestimonyPage, 0, pageFields(page)); err != nil {
+		return nil, err
+	}
+	return page, nil
+}
+
+// TranslatableFields lists the testimony page text that can be translated
+func (s *Service) TranslatableFields(ctx context.Context) ([]translation.SourceField, error) {
+	page, err := s.repo.GetTestimonyPage(ctx)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return translation.SourceFields(models.EntityTestimonyPage, 0, pageFields(page)), nil
+}
+
+func pageFields(page *TestimonyPageDto) translation.Fields {
+	return translation.Fields{"title": &page.Title, "description": &page.Description}
+}
+
+func (s *Service) GetTestimonyPageSchedule(ctx context.Context) (*content.ScheduleDto, error) {
+	schedule, err := s.repo.GetTestimonyPageSchedule(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return content.ToScheduleDto(*schedule), nil
+}
+
+// SetTestimonyPageSchedule sets when the testimony page draft goes live and when the page goes offline
+func (s *Service) SetTestimonyPageSchedule(ctx context.Context, data content.ScheduleDto) error {
+	schedule, err := content.ToSchedule(data)
+	if err != nil {
+		return err
+	}
+
+	before, err := s.GetTestimonyPageSchedule(ctx)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.SetTestimonyPageSchedule(ctx, schedule); err != nil {
+		return err
+	}
+
+	s.recorder.Record(ctx, models.AuditSchedule, models.EntityTestimonyPage, nil, before, data)
+	return nil
+}
+
+// RunTestimonyPageSchedule publishes the testimony page draft once its publish time has come. It
+// reports whether the public page changed since the previous run.
+func (s *Service) RunTestimonyPageSchedule(ctx context.Context, since, now time.Time) (bool, error) {
+	schedule, err := s.repo.GetTestimonyPageSchedule(ctx)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	changed := schedule.UnpublishedBetween(since, now)
+	if schedule.PublishDue(now) {
+		if err := s.PublishTestimonyPage(ctx); err != nil && !errors.Is(err, content.ErrNoDraft) {
+			return false, err
+		}
+		schedule.PublishAt = nil
+		if err := s.repo.SetTestimonyPageSchedule(ctx, *schedule); err != nil {
+			return false, err
+		}
+		changed = true
+	}
+	return changed, nil
+}
+
+// SaveTestimonyPageDraft stores an edit of the testimony page without changing the live page
+func (s *Service) SaveTestimonyPageDraft(ctx context.Context, data *TestimonyPageDto) error {
+	return s.versions.SaveDraft(ctx, models.EntityTestimonyPage, 0, data)
+}
+
+// PreviewTestimonyPage returns the draft of the testimony page, or the live page when there is no draft
+func (s *Service) PreviewTestimonyPage(ctx context.Context) (*TestimonyPageDto, error) {
+	var draft TestimonyPageDto
+	found, err := s.versions.LoadDraft(ctx, models.EntityTestimonyPage, 0, &draft)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return s.repo.GetTestimonyPage(ctx)
+	}
+	return &draft, nil
+}
+
+// PublishTestimonyPage makes the draft the live testimony page
+func (s *Service) PublishTestimonyPage(ctx context.Context) error {
+	var draft TestimonyPageDto
+	found, err := s.versions.LoadDraft(ctx, models.EntityTestimonyPage, 0, &draft)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return content.ErrNoDraft
+	}
+
+	if err := s.applyTestimonyPage(ctx, &draft, models.AuditPublish); err != nil {
+		return err
+	}
+	return s.versions.DeleteDraft(ctx, models.EntityTestimonyPage, 0)
+}
+
+// RestoreTestimonyPage rolls the live testimony page back to one of its revisions
+func (s *Service) RestoreTestimonyPage(ctx context.Context, revisionID uint) error {
+	var revision TestimonyPageDto
+	if err := s.versions.LoadRevision(ctx, models.EntityTestimonyPage, 0, revisionID, &revision); err != nil {
+		return err
+	}
+	return s.applyTestimonyPage(ctx, &revision, models.AuditRestore)
+}
+
+// applyTestimonyPage writes data to the live testimony page and keeps a revision of the result
+func (s *Service) applyTestimonyPage(ctx context.Context, data *TestimonyPageDto, action models.AuditAction) error {
+	before, err := s.repo.GetTestimonyPage(ctx)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	if err := s.repo.UpdateTestimonyPage(ctx, data); err != nil {
+		return err
+	}
+
+	after, err := s.repo.GetTestimonyPage(ctx)
+	if err != nil {
+		return err
+	}
+	if err := s.versions.SaveRevision(ctx, models.EntityTestimonyPage, 0, before, after); err != nil {
+		return err
+	}
+	s.recorder.Record(ctx, action, models.EntityTestimonyPage, nil, before, after)
+	return nil
+}
+
+// DiscardTestimonyPageDraft throws away the unpublished changes to the testimony page
+func (s *Service) DiscardTestimonyPageDraft(ctx context.Context) error {
+	return s.versions.DiscardDraft(ctx, models.EntityTestimonyPage, 0)
 }
 
 func (s *Service) GetTestimonies(ctx context.Context) (*TestimonyDto, error) {
@@ -92,13 +250,80 @@ func (s *Service) CreateTestimony(ctx context.Context, data *TestimonyItemDto) e
 }
 
 func (s *Service) UpdateTestimony(ctx context.Context, data *TestimonyItemDto, id uint) error {
-	return s.repo.UpdateTestimony(ctx, data, id)
+	before, err := s.repo.GetTestimony(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.UpdateTestimony(ctx, data, id); err != nil {
+		return err
+	}
+
+	after, err := s.repo.GetTestimony(ctx, id)
+	if err != nil {
+		return err
+	}
+	s.recorder.Record(ctx, models.AuditUpdate, models.EntityTestimony, id, before, after)
+	return nil
 }
 
 func (s *Service) ApproveTestimony(ctx context.Context, data *ApproveTestimonyDto, id uint) error {
-	return s.repo.ApproveTestimony(ctx, data, id)
+	before, err := s.repo.GetTestimony(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.ApproveTestimony(ctx, data, id); err != nil {
+		return err
+	}
+
+	after, err := s.repo.GetTestimony(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	action := models.AuditApprove
+	if !data.Approved {
+		action = models.AuditUnapprove
+	}
+	s.recorder.Record(ctx, action, models.EntityTestimony, id, before, after)
+	return nil
 }
 
 func (s *Service) DeleteTestimony(ctx context.Context, id uint) error {
-	return s.repo.DeleteTestimony(ctx, id)
+	before, err := s.repo.GetTestimony(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.DeleteTestimony(ctx, id); err != nil {
+		return err
+	}
+
+	s.recorder.Record(ctx, models.AuditDelete, models.EntityTestimony, id, before, nil)
+	return nil
+}
+
+// GetTrashedTestimonies lists the deleted testimonies that have not been purged yet
+func (s *Service) GetTrashedTestimonies(ctx context.Context) ([]TrashedTestimonyDto, error) {
+	return s.repo.GetTrashedTestimonies(ctx)
+}
+
+// RestoreTrashedTestimony brings a deleted testimony back, approved or not as before
+func (s *Service) RestoreTrashedTestimony(ctx context.Context, id uint) error {
+	if err := s.repo.RestoreTrashedTestimony(ctx, id); err != nil {
+		return err
+	}
+
+	after, err := s.repo.GetTestimony(ctx, id)
+	if err != nil {
+		return err
+	}
+	s.recorder.Record(ctx, models.AuditUndelete, models.EntityTestimony, id, nil, after)
+	return nil
+}
+
+// PurgeTestimonies permanently deletes the testimonies trashed before the given time
+func (s *Service) PurgeTestimonies(ctx context.Context, before time.Time) (int64, error) {
+	return s.repo.PurgeTestimonies(ctx, before)
 }
@@ -0,0 +1,125 @@
+package translation
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/othersidedrl/portfolio/backend/internal/utils"
+	"gorm.io/gorm"
+)
+
+type Handler struct {
+	service *Service
+}
+
+func NewHandler(service *Service) *Handler {
+	return &Handler{service}
+}
+
+func (h *Handler) GetLocales(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(h.service.GetLocales())
+}
+
+// GetTranslations handles GET /admin/translations with optional
+// entity_type, entity_id and locale filters
+func (h *Handler) GetTranslations(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	query := &TranslationQuery{
+		EntityType: q.Get("entity_type"),
+		Locale:     q.Get("locale"),
+	}
+	if raw := q.Get("entity_id"); raw != "" {
+		id, err := strconv.Atoi(raw)
+		if err != nil || id < 0 {
+			http.Error(w, "Invalid entity ID", http.StatusBadRequest)
+			return
+		}
+		entityID := uint(id)
+		query.EntityID = &entityID
+	}
+
+	translations, err := h.service.GetTranslations(r.Context(), query)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response := map[string]interface{}{
+		"length": len(translations),
+		"data":   translations,
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// GetMissing handles GET /admin/translations/missing?locale=
+func (h *Handler) GetMissing(w http.ResponseWriter, r *http.Request) {
+	locale := r.URL.Query().Get("locale")
+	if locale == "" {
+		http.Error(w, "locale is required", http.StatusBadRequest)
+		return
+	}
+
+	missing, err := h.service.GetMissing(r.Context(), locale)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"length": len(missing),
+		"data":   missing,
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+func (h *Handler) SaveTranslation(w http.ResponseWriter, r *http.Request) {
+	var body TranslationDto
+	if err := utils.DecodeBody(r, &body); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.SaveTranslation(r.Context(), &body); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(body)
+}
+
+func (h *Handler) DeleteTranslation(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid translation ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.DeleteTranslation(r.Context(), uint(id)); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeError maps service errors to HTTP status codes
+func writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, ErrUnsupportedLocale), errors.Is(err, ErrUnknownField), errors.Is(err, ErrEmptyTranslation):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
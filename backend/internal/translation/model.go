@@ -0,0 +1,36 @@
+package translation
+
+import "github.com/othersidedrl/portfolio/backend/internal/models"
+
+// Fields points at the text fields of a DTO by field name, so a translation
+// can be written over them
+type Fields map[string]*string
+
+// SourceField is a translatable field with its text in the default locale
+type SourceField struct {
+	EntityType models.EntityType `json:"entity_type"`
+	EntityID   uint              `json:"entity_id"`
+	Field      string            `json:"field"`
+	Source     string            `json:"source"`
+}
+
+type TranslationQuery struct {
+	EntityType string
+	EntityID   *uint
+	Locale     string
+}
+
+type TranslationDto struct {
+	ID         uint              `json:"id"`
+	EntityType models.EntityType `json:"entity_type"`
+	EntityID   uint              `json:"entity_id"`
+	Field      string            `json:"field"`
+	Locale     string            `json:"locale"`
+	Value      string            `json:"value"`
+	UpdatedAt  string            `json:"updated_at"`
+}
+
+type LocalesDto struct {
+	Default   string   `json:"default"`
+	Supported []string `json:"supported"`
+}
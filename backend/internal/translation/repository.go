@@ -0,0 +1,89 @@
+package translation
+
+import (
+	"context"
+
+	"github.com/othersidedrl/portfolio/backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TranslationRepository interface {
+	FindForEntities(ctx context.Context, entityType models.EntityType, entityIDs []uint, locale string) ([]models.Translation, error)
+	FindAll(ctx context.Context, query *TranslationQuery) ([]models.Translation, error)
+	Find(ctx context.Context, id uint) (*models.Translation, error)
+	FindByKey(ctx context.Context, entityType models.EntityType, entityID uint, field, locale string) (*models.Translation, error)
+	Save(ctx context.Context, translation *models.Translation) error
+	Delete(ctx context.Context, id uint) error
+}
+
+type GormTranslationRepository struct {
+	db *gorm.DB
+}
+
+func NewGormTranslationRepository(db *gorm.DB) *GormTranslationRepository {
+	return &GormTranslationRepository{db: db}
+}
+
+func (r *GormTranslationRepository) FindForEntities(ctx context.Context, entityType models.EntityType, entityIDs []uint, locale string) ([]models.Translation, error) {
+	var translations []models.Translation
+	err := r.db.WithContext(ctx).
+		Where("entity_type = ? AND entity_id IN ? AND locale = ?", entityType, entityIDs, locale).
+		Find(&translations).Error
+	return translations, err
+}
+
+func (r *GormTranslationRepository) FindAll(ctx context.Context, query *TranslationQuery) ([]models.Translation, error) {
+	tx := r.db.WithContext(ctx)
+	if query.EntityType != "" {
+		tx = tx.Where("entity_type = ?", query.EntityType)
+	}
+	if query.EntityID != nil {
+		tx = tx.Where("entity_id = ?", *query.EntityID)
+	}
+	if query.Locale != "" {
+		tx = tx.Where("locale = ?", query.Locale)
+	}
+
+	var translations []models.Translation
+	err := tx.Order("entity_type, entity_id, field, locale").Find(&translations).Error
+	return translations, err
+}
+
+func (r *GormTranslationRepository) Find(ctx context.Context, id uint) (*models.Translation, error) {
+	var translation models.Translation
+	if err := r.db.WithContext(ctx).First(&translation, id).Error; err != nil {
+		return nil, err
+	}
+	return &translation, nil
+}
+
+func (r *GormTranslationRepository) FindByKey(ctx context.Context, entityType models.EntityType, entityID uint, field, locale string) (*models.Translation, error) {
+	var translation models.Translation
+	err := r.db.WithContext(ctx).
+		Where("entity_type = ? AND entity_id = ? AND field = ? AND locale = ?", entityType, entityID, field, locale).
+		First(&translation).Error
+	if err != nil {
+		return nil, err
+	}
+	return &translation, nil
+}
+
+// Save inserts the translation or replaces the value of the existing one
+func (r *GormTranslationRepository) Save(ctx context.Context, translation *models.Translation) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "entity_type"}, {Name: "entity_id"}, {Name: "field"}, {Name: "locale"}},
+		DoUpdates: clause.AssignmentColumns([]string{"value", "updated_at"}),
+	}).Create(translation).Error
+}
+
+func (r *GormTranslationRepository) Delete(ctx context.Context, id uint) error {
+	result := r.db.WithContext(ctx).Delete(&models.Translation{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -0,0 +1,236 @@
+package translation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/othersidedrl/portfolio/backend/internal/audit"
+	"github.com/othersidedrl/portfolio/backend/internal/middleware"
+	"github.com/othersidedrl/portfolio/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrUnsupportedLocale = errors.New("locale is not supported or is the default locale")
+	ErrUnknownField      = errors.New("field is not translatable")
+	ErrEmptyTranslation  = errors.New("translation value is required")
+)
+
+// Source lists the translatable fields of a domain with their current text
+type Source func(ctx context.Context) ([]SourceField, error)
+
+// Service overlays translations on public responses and manages them for
+// the admin. Text stored on the entities themselves is the default locale.
+type Service struct {
+	repo     TranslationRepository
+	locales  middleware.LocaleConfig
+	sources  []Source
+	recorder audit.Recorder
+}
+
+func NewService(repo TranslationRepository, locales middleware.LocaleConfig, recorder audit.Recorder) *Service {
+	return &Service{repo: repo, locales: locales, recorder: recorder}
+}
+
+// RegisterSource adds the translatable fields of a domain. The domains
+// register themselves once they are built, since they also use the service.
+func (s *Service) RegisterSource(source Source) {
+	s.sources = append(s.sources, source)
+}
+
+// Translate writes the translations of one entity over its fields
+func (s *Service) Translate(ctx context.Context, entityType models.EntityType, entityID uint, fields Fields) error {
+	return s.TranslateMany(ctx, entityType, map[uint]Fields{entityID: fields})
+}
+
+// TranslateMany writes the translations of several entities of the same type
+// over their fields, in the locale negotiated for the request. Fields without
+// a translation keep the default locale text.
+func (s *Service) TranslateMany(ctx context.Context, entityType models.EntityType, items map[uint]Fields) error {
+	locale := middleware.GetLocaleFromContext(ctx)
+	if locale == "" || locale == s.locales.Default || len(items) == 0 {
+		return nil
+	}
+
+	ids := make([]uint, 0, len(items))
+	for id := range items {
+		ids = append(ids, id)
+	}
+
+	translations, err := s.repo.FindForEntities(ctx, entityType, ids, locale)
+	if err != nil {
+		return err
+	}
+	for _, translation := range translations {
+		if field, ok := items[translation.EntityID][translation.Field]; ok && field != nil {
+			*field = translation.Value
+		}
+	}
+	return nil
+}
+
+// SourceFields lists the fields of one entity with their current text
+func SourceFields(entityType models.EntityType, entityID uint, fields Fields) []SourceField {
+	sourceFields := make([]SourceField, 0, len(fields))
+	for name, value := range fields {
+		sourceFields = append(sourceFields, SourceField{
+			EntityType: entityType,
+			EntityID:   entityID,
+			Field:      name,
+			Source:     *value,
+		})
+	}
+	sort.Slice(sourceFields, func(i, j int) bool {
+		return sourceFields[i].Field < sourceFields[j].Field
+	})
+	return sourceFields
+}
+
+func (s *Service) GetLocales() LocalesDto {
+	return LocalesDto{Default: s.locales.Default, Supported: s.locales.Supported}
+}
+
+func (s *Service) GetTranslations(ctx context.Context, query *TranslationQuery) ([]TranslationDto, error) {
+	translations, err := s.repo.FindAll(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	dtos := make([]TranslationDto, 0, len(translations))
+	for _, translation := range translations {
+		dtos = append(dtos, toTranslationDto(translation))
+	}
+	return dtos, nil
+}
+
+// SaveTranslation creates or replaces the translation of a field in a locale
+func (s *Service) SaveTranslation(ctx context.Context, data *TranslationDto) error {
+	locale, ok := s.locales.Match(data.Locale)
+	if !ok || locale == s.locales.Default {
+		return ErrUnsupportedLocale
+	}
+	if strings.TrimSpace(data.Value) == "" {
+		return ErrEmptyTranslation
+	}
+	if _, err := s.findSourceField(ctx, data.EntityType, data.EntityID, data.Field); err != nil {
+		return err
+	}
+
+	var before interface{}
+	previous, err := s.repo.FindByKey(ctx, data.EntityType, data.EntityID, data.Field, locale)
+	if err == nil {
+		before = toTranslationDto(*previous)
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	translation := &models.Translation{
+		EntityType: data.EntityType,
+		EntityID:   data.EntityID,
+		Field:      data.Field,
+		Locale:     locale,
+		Value:      data.Value,
+	}
+	if err := s.repo.Save(ctx, translation); err != nil {
+		return err
+	}
+	*data = toTranslationDto(*translation)
+
+	action := models.AuditUpdate
+	if before == nil {
+		action = models.AuditCreate
+	}
+	s.recorder.Record(ctx, action, models.EntityTranslation, translation.ID, before, data)
+	return nil
+}
+
+func (s *Service) DeleteTranslation(ctx context.Context, id uint) error {
+	before, err := s.repo.Find(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	s.recorder.Record(ctx, models.AuditDelete, models.EntityTranslation, id, toTranslationDto(*before), nil)
+	return nil
+}
+
+// GetMissing lists the translatable fields that have text in the default
+// locale but no translation in the given one
+func (s *Service) GetMissing(ctx context.Context, locale string) ([]SourceField, error) {
+	locale, ok := s.locales.Match(locale)
+	if !ok || locale == s.locales.Default {
+		return nil, ErrUnsupportedLocale
+	}
+
+	fields, err := s.sourceFields(ctx)
+	if err != nil {
+		return nil, err
+	}
+	translations, err := s.repo.FindAll(ctx, &TranslationQuery{Locale: locale})
+	if err != nil {
+		return nil, err
+	}
+
+	translated := make(map[string]bool, len(translations))
+	for _, translation := range translations {
+		translated[fieldKey(translation.EntityType, translation.EntityID, translation.Field)] = true
+	}
+
+	missing := []SourceField{}
+	for _, field := range fields {
+		if strings.TrimSpace(field.Source) == "" || translated[fieldKey(field.EntityType, field.EntityID, field.Field)] {
+			continue
+		}
+		missing = append(missing, field)
+	}
+	return missing, nil
+}
+
+func (s *Service) sourceFields(ctx context.Context) ([]SourceField, error) {
+	var fields []SourceField
+	for _, source := range s.sources {
+		sourceFields, err := source(ctx)
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, sourceFields...)
+	}
+	return fields, nil
+}
+
+func (s *Service) findSourceField(ctx context.Context, entityType models.EntityType, entityID uint, field string) (*SourceField, error) {
+	fields, err := s.sourceFields(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, candidate := range fields {
+		if candidate.EntityType == entityType && candidate.EntityID == entityID && candidate.Field == field {
+			return &candidate, nil
+		}
+	}
+	return nil, ErrUnknownField
+}
+
+func fieldKey(entityType models.EntityType, entityID uint, field string) string {
+	return fmt.Sprintf("%s/%d/%s", entityType, entityID, field)
+}
+
+func toTranslationDto(translation models.Translation) TranslationDto {
+	return TranslationDto{
+		ID:         translation.ID,
+		EntityType: translation.EntityType,
+		EntityID:   translation.EntityID,
+		Field:      translation.Field,
+		Locale:     translation.Locale,
+		Value:      translation.Value,
+		UpdatedAt:  translation.UpdatedAt.Format(time.RFC3339),
+	}
+}
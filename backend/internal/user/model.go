@@ -0,0 +1,40 @@
+package user
+
+import "github.com/othersidedrl/portfolio/backend/internal/models"
+
+type UserDto struct {
+	ID          uint            `json:"id"`
+	Email       string          `json:"email"`
+	Name        string          `json:"name"`
+	Role        models.UserRole `json:"role"`
+	TOTPEnabled bool            `json:"totp_enabled"`
+	CreatedAt   string          `json:"created_at"`
+}
+
+type CreateUserDto struct {
+	Email    string          `json:"email"`
+	Name     string          `json:"name"`
+	Password string          `json:"password"`
+	Role     models.UserRole `json:"role"`
+}
+
+// UpdateUserDto leaves the password untouched when it is empty
+type UpdateUserDto struct {
+	Email    string          `json:"email"`
+	Name     string          `json:"name"`
+	Password string          `json:"password"`
+	Role     models.UserRole `json:"role"`
+}
+
+type UsersDto struct {
+	Users []UserDto `json:"users"`
+}
+
+type TOTPSetupDto struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+type RecoveryCodesDto struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
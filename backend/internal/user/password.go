@@ -0,0 +1,39 @@
+package user
+
+import "context"
+
+// ChangePassword replaces the password after re-checking the current one
+func (s *Service) ChangePassword(ctx context.Context, id uint, currentPassword, newPassword string) error {
+	user, err := s.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if _, err := s.Authenticate(ctx, user.Email, currentPassword); err != nil {
+		return err
+	}
+	return s.SetPassword(ctx, id, newPassword)
+}
+
+// SetPassword replaces the password without checking the old one.
+// Callers must have verified the user some other way (e.g. a reset token).
+func (s *Service) SetPassword(ctx context.Context, id uint, newPassword string) error {
+	user, err := s.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	hash, err := hashPassword(newPassword)
+	if err != nil {
+		return err
+	}
+	user.PasswordHash = hash
+	return s.repo.Update(ctx, user)
+}
+
+// ValidatePassword checks the password policy without hashing
+func ValidatePassword(password string) error {
+	if len(password) < minPasswordLength {
+		return ErrWeakPassword
+	}
+	return nil
+}
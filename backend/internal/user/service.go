@@ -0,0 +1,270 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/alexedwards/argon2id"
+	"github.com/othersidedrl/portfolio/backend/internal/audit"
+	"github.com/othersidedrl/portfolio/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+const minPasswordLength = 8
+
+var (
+	ErrUserNotFound       = errors.New("user not found")
+	ErrEmailTaken         = errors.New("email is already in use")
+	ErrInvalidEmail       = errors.New("invalid email address")
+	ErrInvalidRole        = errors.New("invalid role")
+	ErrWeakPassword       = errors.New("password must be at least 8 characters")
+	ErrLastOwner          = errors.New("cannot remove the last owner")
+	ErrInvalidCredentials = errors.New("invalid credentials")
+)
+
+type Service struct {
+	repo     UserRepository
+	recorder audit.Recorder
+}
+
+func NewService(repo UserRepository, recorder audit.Recorder) *Service {
+	return &Service{repo, recorder}
+}
+
+// EnsureOwner seeds the first owner account from ADMIN_EMAIL / ADMIN_PASSWORD_HASH
+// when the users table is still empty, so existing deployments keep working.
+func (s *Service) EnsureOwner(ctx context.Context, email, passwordHash string) error {
+	if email == "" || passwordHash == "" {
+		return nil
+	}
+
+	count, err := s.repo.Count(ctx)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+
+	if err := s.repo.Create(ctx, &models.User{
+		Email:        normalizeEmail(email),
+		Name:         "Owner",
+		PasswordHash: passwordHash,
+		Role:         models.Owner,
+	}); err != nil {
+		return err
+	}
+
+	log.Printf("Seeded owner account %s", email)
+	return nil
+}
+
+// Authenticate returns the user matching the email and password
+func (s *Service) Authenticate(ctx context.Context, email, password string) (*models.User, error) {
+	user, err := s.repo.FindByEmail(ctx, normalizeEmail(email))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvalidCredentials
+		}
+		return nil, err
+	}
+
+	match, err := argon2id.ComparePasswordAndHash(password, user.PasswordHash)
+	if err != nil || !match {
+		return nil, ErrInvalidCredentials
+	}
+
+	return user, nil
+}
+
+func (s *Service) FindByID(ctx context.Context, id uint) (*models.User, error) {
+	user, err := s.repo.FindByID(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrUserNotFound
+	}
+	return user, err
+}
+
+// FindByEmail returns the user with the given email, or ErrUserNotFound
+func (s *Service) FindByEmail(ctx context.Context, email string) (*models.User, error) {
+	user, err := s.repo.FindByEmail(ctx, normalizeEmail(email))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrUserNotFound
+	}
+	return user, err
+}
+
+func (s *Service) GetUsers(ctx context.Context) (*UsersDto, error) {
+	users, err := s.repo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	dtoUsers := make([]UserDto, len(users))
+	for i, u := range users {
+		dtoUsers[i] = toDto(&u)
+	}
+	return &UsersDto{Users: dtoUsers}, nil
+}
+
+func (s *Service) GetUser(ctx context.Context, id uint) (*UserDto, error) {
+	user, err := s.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	dto := toDto(user)
+	return &dto, nil
+}
+
+func (s *Service) CreateUser(ctx context.Context, data *CreateUserDto) (*UserDto, error) {
+	email, err := validateEmail(data.Email)
+	if err != nil {
+		return nil, err
+	}
+	if !data.Role.IsValid() {
+		return nil, ErrInvalidRole
+	}
+	if err := s.ensureEmailFree(ctx, email, 0); err != nil {
+		return nil, err
+	}
+
+	hash, err := hashPassword(data.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	user := &models.User{
+		Email:        email,
+		Name:         data.Name,
+		PasswordHash: hash,
+		Role:         data.Role,
+	}
+	if err := s.repo.Create(ctx, user); err != nil {
+		return nil, err
+	}
+
+	dto := toDto(user)
+	s.recorder.Record(ctx, models.AuditCreate, models.EntityUser, user.ID, nil, dto)
+	return &dto, nil
+}
+
+func (s *Service) UpdateUser(ctx context.Context, data *UpdateUserDto, id uint) (*UserDto, error) {
+	user, err := s.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	before := toDto(user)
+
+	email, err := validateEmail(data.Email)
+	if err != nil {
+		return nil, err
+	}
+	if !data.Role.IsValid() {
+		return nil, ErrInvalidRole
+	}
+	if err := s.ensureEmailFree(ctx, email, id); err != nil {
+		return nil, err
+	}
+	if user.Role == models.Owner && data.Role != models.Owner {
+		if err := s.ensureAnotherOwner(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	if data.Password != "" {
+		hash, err := hashPassword(data.Password)
+		if err != nil {
+			return nil, err
+		}
+		user.PasswordHash = hash
+	}
+
+	user.Email = email
+	user.Name = data.Name
+	user.Role = data.Role
+
+	if err := s.repo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+
+	dto := toDto(user)
+	s.recorder.Record(ctx, models.AuditUpdate, models.EntityUser, id, before, dto)
+	return &dto, nil
+}
+
+func (s *Service) DeleteUser(ctx context.Context, id uint) error {
+	user, err := s.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if user.Role == models.Owner {
+		if err := s.ensureAnotherOwner(ctx); err != nil {
+			return err
+		}
+	}
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	s.recorder.Record(ctx, models.AuditDelete, models.EntityUser, id, toDto(user), nil)
+	return nil
+}
+
+func (s *Service) ensureEmailFree(ctx context.Context, email string, id uint) error {
+	existing, err := s.repo.FindByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	if existing.ID != id {
+		return ErrEmailTaken
+	}
+	return nil
+}
+
+func (s *Service) ensureAnotherOwner(ctx context.Context) error {
+	owners, err := s.repo.CountByRole(ctx, models.Owner)
+	if err != nil {
+		return err
+	}
+	if owners <= 1 {
+		return ErrLastOwner
+	}
+	return nil
+}
+
+func hashPassword(password string) (string, error) {
+	if err := ValidatePassword(password); err != nil {
+		return "", err
+	}
+	return argon2id.CreateHash(password, argon2id.DefaultParams)
+}
+
+func validateEmail(email string) (string, error) {
+	email = normalizeEmail(email)
+	if _, err := mail.ParseAddress(email); err != nil {
+		return "", ErrInvalidEmail
+	}
+	return email, nil
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+func toDto(u *models.User) UserDto {
+	return UserDto{
+		ID:          u.ID,
+		Email:       u.Email,
+		Name:        u.Name,
+		Role:        u.Role,
+		TOTPEnabled: u.TOTPEnabled,
+		CreatedAt:   u.CreatedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,168 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/othersidedrl/portfolio/backend/internal/utils"
+)
+
+const recoveryCodeCount = 10
+
+var (
+	ErrTOTPAlreadyEnabled = errors.New("two-factor authentication is already enabled")
+	ErrTOTPNotStarted     = errors.New("two-factor enrollment has not been started")
+	ErrTOTPNotEnabled     = errors.New("two-factor authentication is not enabled")
+	ErrInvalidTOTPCode    = errors.New("invalid two-factor code")
+)
+
+// BeginTOTPEnrollment generates a new secret for the user. It only takes
+// effect once ConfirmTOTP succeeds with a code from the authenticator app.
+func (s *Service) BeginTOTPEnrollment(ctx context.Context, id uint) (*TOTPSetupDto, error) {
+	user, err := s.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if user.TOTPEnabled {
+		return nil, ErrTOTPAlreadyEnabled
+	}
+
+	secret, err := utils.NewTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	user.TOTPSecret = secret
+	user.TOTPLastStep = 0
+	if err := s.repo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+
+	return &TOTPSetupDto{
+		Secret:          secret,
+		ProvisioningURI: utils.TOTPProvisioningURI(totpIssuer(), user.Email, secret),
+	}, nil
+}
+
+// ConfirmTOTP enables two-factor authentication and returns fresh recovery codes
+func (s *Service) ConfirmTOTP(ctx context.Context, id uint, code string) (*RecoveryCodesDto, error) {
+	user, err := s.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if user.TOTPEnabled {
+		return nil, ErrTOTPAlreadyEnabled
+	}
+	if user.TOTPSecret == "" {
+		return nil, ErrTOTPNotStarted
+	}
+
+	step, ok := utils.ValidateTOTP(user.TOTPSecret, code, time.Now())
+	if !ok {
+		return nil, ErrInvalidTOTPCode
+	}
+
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.ReplaceRecoveryCodes(ctx, user.ID, hashes); err != nil {
+		return nil, err
+	}
+
+	user.TOTPEnabled = true
+	user.TOTPLastStep = step
+	if err := s.repo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+
+	return &RecoveryCodesDto{RecoveryCodes: codes}, nil
+}
+
+// DisableTOTP turns two-factor authentication off after re-checking the password
+func (s *Service) DisableTOTP(ctx context.Context, id uint, password, code string) error {
+	user, err := s.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !user.TOTPEnabled {
+		return ErrTOTPNotEnabled
+	}
+	if _, err := s.Authenticate(ctx, user.Email, password); err != nil {
+		return err
+	}
+	if err := s.VerifySecondFactor(ctx, id, code); err != nil {
+		return err
+	}
+
+	if err := s.repo.DeleteRecoveryCodes(ctx, user.ID); err != nil {
+		return err
+	}
+
+	user.TOTPEnabled = false
+	user.TOTPSecret = ""
+	user.TOTPLastStep = 0
+	return s.repo.Update(ctx, user)
+}
+
+// VerifySecondFactor accepts either a current TOTP code or an unused recovery code.
+// A TOTP code can only be used once.
+func (s *Service) VerifySecondFactor(ctx context.Context, id uint, code string) error {
+	user, err := s.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !user.TOTPEnabled {
+		return ErrTOTPNotEnabled
+	}
+
+	if step, ok := utils.ValidateTOTP(user.TOTPSecret, code, time.Now()); ok {
+		advanced, err := s.repo.AdvanceTOTPStep(ctx, user.ID, step)
+		if err != nil {
+			return err
+		}
+		if !advanced {
+			return ErrInvalidTOTPCode
+		}
+		return nil
+	}
+
+	used, err := s.repo.UseRecoveryCode(ctx, user.ID, hashRecoveryCode(code))
+	if err != nil {
+		return err
+	}
+	if !used {
+		return ErrInvalidTOTPCode
+	}
+	return nil
+}
+
+func newRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, recoveryCodeCount)
+	hashes := make([]string, recoveryCodeCount)
+	for i := range codes {
+		raw, err := utils.NewTOTPSecret()
+		if err != nil {
+			return nil, nil, err
+		}
+		code := strings.ToLower(raw[:5] + "-" + raw[5:10])
+		codes[i] = code
+		hashes[i] = hashRecoveryCode(code)
+	}
+	return codes, hashes, nil
+}
+
+func hashRecoveryCode(code string) string {
+	normalized := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(code), "-", ""))
+	return utils.HashToken(normalized)
+}
+
+func totpIssuer() string {
+	if issuer := os.Getenv("TOTP_ISSUER"); issuer != "" {
+		return issuer
+	}
+	return "Portfolio CMS"
+}
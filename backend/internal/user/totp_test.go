@@ -0,0 +1,55 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/othersidedrl/portfolio/backend/internal/models"
+	"github.com/othersidedrl/portfolio/backend/internal/utils"
+)
+
+// fakeUserRepository holds one account with two-factor enabled and applies
+// AdvanceTOTPStep with the same condition as the SQL update
+type fakeUserRepository struct {
+	UserRepository
+	user models.User
+}
+
+func (r *fakeUserRepository) FindByID(ctx context.Context, id uint) (*models.User, error) {
+	user := r.user
+	return &user, nil
+}
+
+func (r *fakeUserRepository) AdvanceTOTPStep(ctx context.Context, userID uint, step int64) (bool, error) {
+	if r.user.TOTPLastStep >= step {
+		return false, nil
+	}
+	r.user.TOTPLastStep = step
+	return true, nil
+}
+
+func (r *fakeUserRepository) UseRecoveryCode(ctx context.Context, userID uint, hash string) (bool, error) {
+	return false, nil
+}
+
+func TestVerifySecondFactorRejectsReplay(t *testing.T) {
+	secret, err := utils.NewTOTPSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo := &fakeUserRepository{user: models.User{ID: 1, TOTPEnabled: true, TOTPSecret: secret}}
+	service := NewService(repo, nil)
+
+	code, err := utils.TOTPCode(secret, utils.TOTPStep(time.Now()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := service.VerifySecondFactor(context.Background(), 1, code); err != nil {
+		t.Fatalf("first use: %v", err)
+	}
+	if err := service.VerifySecondFactor(context.Background(), 1, code); !errors.Is(err, ErrInvalidTOTPCode) {
+		t.Fatalf("replay: err = %v, want %v", err, ErrInvalidTOTPCode)
+	}
+}
@@ -0,0 +1,54 @@
+package utils
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+// FieldChange is the old and new value of a single top-level JSON field
+type FieldChange struct {
+	From interface{} `json:"from"`
+	To   interface{} `json:"to"`
+}
+
+// DiffJSON compares the JSON representations of two values field by field.
+// A nil value is treated as an object without fields.
+func DiffJSON(before, after interface{}) (map[string]FieldChange, error) {
+	from, err := toJSONObject(before)
+	if err != nil {
+		return nil, err
+	}
+	to, err := toJSONObject(after)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := map[string]FieldChange{}
+	for key, value := range from {
+		if next, ok := to[key]; !ok || !reflect.DeepEqual(value, next) {
+			changes[key] = FieldChange{From: value, To: to[key]}
+		}
+	}
+	for key, value := range to {
+		if _, ok := from[key]; !ok {
+			changes[key] = FieldChange{From: nil, To: value}
+		}
+	}
+	return changes, nil
+}
+
+func toJSONObject(value interface{}) (map[string]interface{}, error) {
+	object := map[string]interface{}{}
+	if value == nil || reflect.ValueOf(value).Kind() == reflect.Ptr && reflect.ValueOf(value).IsNil() {
+		return object, nil
+	}
+
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(raw, &object); err != nil {
+		return nil, err
+	}
+	return object, nil
+}
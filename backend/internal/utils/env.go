@@ -0,0 +1,21 @@
+package utils
+
+import (
+	"log"
+	"os"
+	"time"
+)
+
+// DurationFromEnv parses a time.Duration env var, falling back to def
+func DurationFromEnv(key string, def time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return def
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		log.Printf("⚠️ Invalid %s %q, using %s", key, value, def)
+		return def
+	}
+	return d
+}
@@ -2,48 +2,172 @@ package utils
 
 import (
 	"errors"
+	"fmt"
+	"log"
 	"os"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 )
 
+const (
+	defaultAccessTokenTTL = 15 * time.Minute
+	challengeTokenTTL     = 5 * time.Minute
+
+	// PurposeMFAChallenge marks tokens that only prove the password step of a login
+	PurposeMFAChallenge = "mfa_challenge"
+
+	// APIKeySubjectPrefix marks claims built from an X-API-Key instead of a JWT
+	APIKeySubjectPrefix = "apikey:"
+)
+
 type JWTClaims struct {
-	Sub string `json:"sub"`
+	Sub       string   `json:"sub"`
+	Role      string   `json:"role"`
+	SessionID string   `json:"sid"`
+	Purpose   string   `json:"purpose,omitempty"`
+	Scopes    []string `json:"scopes,omitempty"`
 	jwt.RegisteredClaims
 }
 
+// IsAPIKey reports whether the claims belong to an API key rather than a user
+func (c *JWTClaims) IsAPIKey() bool {
+	return strings.HasPrefix(c.Sub, APIKeySubjectPrefix)
+}
+
+// JWTService signs with the active key from JWT_KEYS_DIR and verifies with
+// any key in it, so keys can be rotated without logging everyone out.
+// Without JWT_KEYS_DIR it falls back to HS256 with JWT_SECRET.
 type JWTService struct {
 	secret []byte
+	keys   map[string]*signingKey
+	active *signingKey
+	ttl    time.Duration
 }
 
-func NewJWTService() *JWTService {
-	return &JWTService{
+func NewJWTService() (*JWTService, error) {
+	j := &JWTService{
 		secret: []byte(os.Getenv("JWT_SECRET")),
+		keys:   map[string]*signingKey{},
+		ttl:    DurationFromEnv("ACCESS_TOKEN_TTL", defaultAccessTokenTTL),
+	}
+
+	dir := os.Getenv("JWT_KEYS_DIR")
+	if dir == "" {
+		if len(j.secret) == 0 {
+			return nil, errors.New("either JWT_KEYS_DIR or JWT_SECRET must be set")
+		}
+		return j, nil
+	}
+
+	keys, err := loadKeys(dir)
+	if err != nil {
+		return nil, err
+	}
+	j.keys = keys
+
+	activeKid := os.Getenv("JWT_ACTIVE_KID")
+	if activeKid == "" {
+		activeKid = newestSigningKid(keys)
+	}
+	active, ok := keys[activeKid]
+	if !ok || active.private == nil {
+		return nil, fmt.Errorf("no private key found for active kid %q in %s", activeKid, dir)
 	}
+	j.active = active
+
+	log.Printf("🔑 Signing tokens with %s key %q (%d verification keys)", active.method.Alg(), active.kid, len(keys))
+	return j, nil
+}
+
+// TTL returns how long issued access tokens stay valid
+func (j *JWTService) TTL() time.Duration {
+	return j.ttl
 }
 
-func (j *JWTService) GenerateToken(userID string) (string, error) {
-	claims := JWTClaims{
-		Sub: userID,
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(72 * time.Hour)),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-		},
+// JWKS returns the public verification keys
+func (j *JWTService) JWKS() JWKSet {
+	kids := make([]string, 0, len(j.keys))
+	for kid := range j.keys {
+		kids = append(kids, kid)
+	}
+	sort.Strings(kids)
+
+	set := JWKSet{Keys: make([]JWK, 0, len(kids))}
+	for _, kid := range kids {
+		set.Keys = append(set.Keys, j.keys[kid].jwk())
 	}
+	return set
+}
+
+// GenerateToken issues a short-lived access token bound to a session
+func (j *JWTService) GenerateToken(userID string, role string, sessionID string) (string, error) {
+	return j.sign(JWTClaims{
+		Sub:       userID,
+		Role:      role,
+		SessionID: sessionID,
+	}, j.ttl)
+}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(j.secret)
+// GenerateChallengeToken issues a token that can only be exchanged at /auth/login/verify
+func (j *JWTService) GenerateChallengeToken(userID string) (string, error) {
+	return j.sign(JWTClaims{
+		Sub:     userID,
+		Purpose: PurposeMFAChallenge,
+	}, challengeTokenTTL)
 }
 
+// VerifyToken validates an access token. Purpose-bound tokens are rejected.
 func (j *JWTService) VerifyToken(tokenString string) (*JWTClaims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &JWTClaims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, errors.New("unexpected signing method")
-		}
-		return j.secret, nil
-	})
+	claims, err := j.parse(tokenString)
+	if err != nil {
+		return nil, err
+	}
+	if claims.Purpose != "" {
+		return nil, errors.New("invalid token")
+	}
+	return claims, nil
+}
+
+// VerifyChallengeToken validates a token issued by GenerateChallengeToken
+func (j *JWTService) VerifyChallengeToken(tokenString string) (*JWTClaims, error) {
+	claims, err := j.parse(tokenString)
+	if err != nil {
+		return nil, err
+	}
+	if claims.Purpose != PurposeMFAChallenge {
+		return nil, errors.New("invalid token")
+	}
+	return claims, nil
+}
+
+func (j *JWTService) sign(claims JWTClaims, ttl time.Duration) (string, error) {
+	jti, err := RandomToken(16)
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	claims.RegisteredClaims = jwt.RegisteredClaims{
+		ID:        jti,
+		ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+		IssuedAt:  jwt.NewNumericDate(now),
+	}
+
+	if j.active == nil {
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+		return token.SignedString(j.secret)
+	}
+
+	token := jwt.NewWithClaims(j.active.method, claims)
+	token.Header["kid"] = j.active.kid
+	return token.SignedString(j.active.private)
+}
 
+func (j *JWTService) parse(tokenString string) (*JWTClaims, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &JWTClaims{}, j.keyFor)
 	if err != nil {
 		return nil, err
 	}
@@ -55,3 +179,36 @@ func (j *JWTService) VerifyToken(tokenString string) (*JWTClaims, error) {
 
 	return claims, nil
 }
+
+// keyFor picks the verification key by kid. HS256 tokens without a kid are
+// only accepted while JWT_SECRET is still configured.
+func (j *JWTService) keyFor(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok || len(j.secret) == 0 {
+			return nil, errors.New("unexpected signing method")
+		}
+		return j.secret, nil
+	}
+
+	key, ok := j.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown key id %q", kid)
+	}
+	if token.Method.Alg() != key.method.Alg() {
+		return nil, errors.New("unexpected signing method")
+	}
+	return key.public, nil
+}
+
+// newestSigningKid picks the last kid (by name) that has a private key,
+// so date-prefixed file names rotate naturally
+func newestSigningKid(keys map[string]*signingKey) string {
+	newest := ""
+	for kid, key := range keys {
+		if key.private != nil && kid > newest {
+			newest = kid
+		}
+	}
+	return newest
+}
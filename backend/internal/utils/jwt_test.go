@@ -0,0 +1,221 @@
+package utils
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// writeKey stores key in dir as <kid>.pem, private keys as PKCS#8
+func writeKey(t *testing.T, dir, kid string, key interface{}) {
+	t.Helper()
+	var block *pem.Block
+	switch key.(type) {
+	case crypto.Signer:
+		der, err := x509.MarshalPKCS8PrivateKey(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		block = &pem.Block{Type: "PRIVATE KEY", Bytes: der}
+	default:
+		der, err := x509.MarshalPKIXPublicKey(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		block = &pem.Block{Type: "PUBLIC KEY", Bytes: der}
+	}
+	if err := os.WriteFile(filepath.Join(dir, kid+".pem"), pem.EncodeToMemory(block), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func newKeyedService(t *testing.T, dir string) *JWTService {
+	t.Helper()
+	t.Setenv("JWT_KEYS_DIR", dir)
+	t.Setenv("JWT_SECRET", "")
+	t.Setenv("JWT_ACTIVE_KID", "")
+	j, err := NewJWTService()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return j
+}
+
+func TestJWTKeyRotation(t *testing.T) {
+	dir := t.TempDir()
+	_, oldKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeKey(t, dir, "2024-01", oldKey)
+
+	old := newKeyedService(t, dir)
+	token, err := old.GenerateToken("1", "owner", "session")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Rotate: a newer key signs, the old one is kept for verification only
+	newKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeKey(t, dir, "2024-01", oldKey.Public())
+	writeKey(t, dir, "2025-01", newKey)
+	rotated := newKeyedService(t, dir)
+
+	if rotated.active.kid != "2025-01" {
+		t.Errorf("active kid = %q, want the newest key", rotated.active.kid)
+	}
+	claims, err := rotated.VerifyToken(token)
+	if err != nil {
+		t.Fatalf("token from the retired key: %v", err)
+	}
+	if claims.Sub != "1" || claims.SessionID != "session" {
+		t.Errorf("claims = %+v", claims)
+	}
+
+	fresh, err := rotated.GenerateToken("1", "owner", "session")
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, _, err := jwt.NewParser().ParseUnverified(fresh, &JWTClaims{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsed.Header["kid"] != "2025-01" || parsed.Method.Alg() != "RS256" {
+		t.Errorf("new token signed with %v %v, want 2025-01 RS256", parsed.Header["kid"], parsed.Method.Alg())
+	}
+}
+
+func TestJWKSVerifiesTokens(t *testing.T) {
+	dir := t.TempDir()
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeKey(t, dir, "a-ed25519", edKey)
+	writeKey(t, dir, "b-rsa", rsaKey)
+
+	for _, kid := range []string{"a-ed25519", "b-rsa"} {
+		t.Run(kid, func(t *testing.T) {
+			t.Setenv("JWT_KEYS_DIR", dir)
+			t.Setenv("JWT_SECRET", "")
+			t.Setenv("JWT_ACTIVE_KID", kid)
+			j, err := NewJWTService()
+			if err != nil {
+				t.Fatal(err)
+			}
+			token, err := j.GenerateToken("1", "owner", "session")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// Verify the way a third party would, from the published set only
+			var published *JWK
+			for _, key := range j.JWKS().Keys {
+				if key.Kid == kid {
+					published = &key
+				}
+			}
+			if published == nil {
+				t.Fatalf("kid %q missing from JWKS", kid)
+			}
+			_, err = jwt.ParseWithClaims(token, &JWTClaims{}, func(*jwt.Token) (interface{}, error) {
+				return published.PublicKey()
+			}, jwt.WithValidMethods([]string{published.Alg}))
+			if err != nil {
+				t.Errorf("token does not verify against the JWKS: %v", err)
+			}
+		})
+	}
+}
+
+func TestJWTRejects(t *testing.T) {
+	dir := t.TempDir()
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeKey(t, dir, "rsa", rsaKey)
+	j := newKeyedService(t, dir)
+
+	challenge, err := j.GenerateChallengeToken("1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	access, err := j.GenerateToken("1", "owner", "session")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// HS256 signed with the public key, the classic algorithm confusion
+	publicDER, err := x509.MarshalPKIXPublicKey(&rsaKey.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	confused := jwt.NewWithClaims(jwt.SigningMethodHS256, JWTClaims{Sub: "1", Role: "owner"})
+	confused.Header["kid"] = "rsa"
+	confusedToken, err := confused.SignedString(publicDER)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// HS256 without a kid while no JWT_SECRET is configured
+	unkeyed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, JWTClaims{Sub: "1"}).SignedString([]byte(""))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		verify func(string) (*JWTClaims, error)
+		token  string
+	}{
+		{"challenge used as access token", j.VerifyToken, challenge},
+		{"access token used as challenge", j.VerifyChallengeToken, access},
+		{"algorithm confusion", j.VerifyToken, confusedToken},
+		{"HS256 without a secret", j.VerifyToken, unkeyed},
+		{"tampered", j.VerifyToken, access[:len(access)-4] + "AAAA"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.verify(tt.token); err == nil {
+				t.Error("token accepted")
+			}
+		})
+	}
+}
+
+func TestJWTSecretFallback(t *testing.T) {
+	t.Setenv("JWT_KEYS_DIR", "")
+	t.Setenv("JWT_SECRET", "test-secret")
+	j, err := NewJWTService()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	token, err := j.GenerateToken("1", "owner", "session")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := j.VerifyToken(token); err != nil {
+		t.Fatal(err)
+	}
+	if keys := j.JWKS().Keys; len(keys) != 0 {
+		t.Errorf("JWKS publishes %d keys for a shared secret", len(keys))
+	}
+}
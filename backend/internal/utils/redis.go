@@ -25,3 +25,26 @@ func InitRedis() {
 	}
 	log.Println("✅ Redis connected")
 }
+
+// LocaleCacheKey returns the cache key of a response in the given locale
+func LocaleCacheKey(key, locale string) string {
+	if locale == "" {
+		return key
+	}
+	return key + ":" + locale
+}
+
+// DeleteCache removes cached responses together with their locale variants
+func DeleteCache(ctx context.Context, client *redis.Client, keys ...string) error {
+	toDelete := append([]string{}, keys...)
+	for _, key := range keys {
+		iter := client.Scan(ctx, 0, LocaleCacheKey(key, "*"), 100).Iterator()
+		for iter.Next(ctx) {
+			toDelete = append(toDelete, iter.Val())
+		}
+		if err := iter.Err(); err != nil {
+			return err
+		}
+	}
+	return client.Del(ctx, toDelete...).Err()
+}
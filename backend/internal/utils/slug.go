@@ -0,0 +1,44 @@
+package utils
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// MaxSlugLength keeps slugs short enough for URLs and the slug columns
+const MaxSlugLength = 100
+
+var slugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// Slugify turns a title into a lowercase, hyphen separated slug.
+// Accents are stripped and other non-ASCII characters are dropped.
+func Slugify(s string) string {
+	var b strings.Builder
+	hyphen := false
+	for _, r := range norm.NFKD.String(strings.ToLower(s)) {
+		switch {
+		case r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+			b.WriteRune(r)
+			hyphen = false
+		case unicode.Is(unicode.Mn, r):
+			// combining accent left over from NFKD
+		case !hyphen && b.Len() > 0:
+			b.WriteByte('-')
+			hyphen = true
+		}
+	}
+
+	slug := strings.TrimSuffix(b.String(), "-")
+	if len(slug) > MaxSlugLength {
+		slug = strings.TrimSuffix(slug[:MaxSlugLength], "-")
+	}
+	return slug
+}
+
+// ValidSlug reports whether s is a slug Slugify could have produced
+func ValidSlug(s string) bool {
+	return len(s) <= MaxSlugLength && slugPattern.MatchString(s)
+}
@@ -6,6 +6,7 @@ import { toast } from "sonner";
 import axios from "~lib/axios";
 
 interface AboutCard {
+  id?: number;
   title: string;
   description: string;
 }
@@ -28,14 +29,15 @@ const AboutForm = () => {
   } = useQuery<About>({
     queryKey: ["about"],
     queryFn: async () => {
-      const response = await axios.get("admin/about");
+      const response = await axios.get("admin/about/preview");
       return response.data;
     },
   });
 
   const updateAboutMutation = useMutation({
     mutationFn: async (updatedAbout: Partial<About>) => {
-      const response = await axios.patch("admin/about", updatedAbout);
+      await axios.patch("admin/about", updatedAbout);
+      const response = await axios.post("admin/about/publish");
       return response.data;
     },
     onSuccess: () => {
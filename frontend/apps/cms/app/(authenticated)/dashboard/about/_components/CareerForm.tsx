@@ -12,6 +12,7 @@ interface CareerItem {
   id: number;
   started_at: string;
   ended_at: string;
+  ongoing: boolean;
   title: string;
   affiliation: string;
   description: string;
@@ -28,6 +29,7 @@ const CareerForm = () => {
   const [form, setForm] = useState<Omit<CareerItem, "id">>({
     started_at: "",
     ended_at: "",
+    ongoing: false,
     title: "",
     affiliation: "",
     description: "",
@@ -39,6 +41,7 @@ const CareerForm = () => {
     setForm({
       started_at: "",
       ended_at: "",
+      ongoing: false,
       title: "",
       affiliation: "",
       description: "",
@@ -102,7 +105,8 @@ const CareerForm = () => {
     e.preventDefault();
     const payload = {
       ...form,
-      ended_at: isPresent ? "Present" : form.ended_at,
+      ended_at: isPresent ? "" : form.ended_at,
+      ongoing: isPresent,
     };
     if (editId) {
       updateMutation.mutate({ id: editId, updated: payload });
@@ -114,14 +118,15 @@ const CareerForm = () => {
   const handleEdit = (item: CareerItem) => {
     setForm({
       started_at: item.started_at,
-      ended_at: item.ended_at === "Present" ? "" : item.ended_at,
+      ended_at: item.ended_at,
+      ongoing: item.ongoing,
       title: item.title,
       affiliation: item.affiliation,
       description: item.description,
       location: item.location,
       type: item.type,
     });
-    setIsPresent(item.ended_at === "Present");
+    setIsPresent(item.ongoing);
     setEditId(item.id);
     setOpen(true);
   };
@@ -168,7 +173,7 @@ const CareerForm = () => {
               </div>
             </div>
             <p className="text-sm text-[var(--text-muted)]">
-              {item.started_at} - {item.ended_at} | {item.location} | {item.type}
+              {item.started_at} - {item.ongoing ? "Present" : item.ended_at} | {item.location} | {item.type}
             </p>
             <p className="text-sm text-[var(--text-normal)]">{item.description}</p>
           </div>
@@ -198,7 +203,7 @@ const CareerForm = () => {
               <div className="flex-1">
                 <label className="text-sm font-medium text-[var(--text-muted)]">Start Date</label>
                 <input
-                  type="date"
+                  type="month"
                   value={form.started_at}
                   onChange={(e) => setForm({ ...form, started_at: e.target.value })}
                   className="input w-full"
@@ -216,7 +221,7 @@ const CareerForm = () => {
                   />
                 ) : (
                   <input
-                    type="date"
+                    type="month"
                     value={form.ended_at}
                     onChange={(e) => setForm({ ...form, ended_at: e.target.value })}
                     className="input w-full"
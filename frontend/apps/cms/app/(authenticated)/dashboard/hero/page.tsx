@@ -23,7 +23,7 @@ export default function HeroForm() {
   const { data } = useQuery({
     queryKey: ["hero"],
     queryFn: async () => {
-      const response = await axios.get("/admin/hero");
+      const response = await axios.get("/admin/hero/preview");
       return response.data as HeroData;
     },
   });
@@ -124,7 +124,8 @@ export default function HeroForm() {
         image_urls: formData.imageUrls.filter((url) => url),
         hobbies: formData.hobbies.filter((h) => h),
       };
-      const res = await axios.patch("/admin/hero", payload);
+      await axios.patch("/admin/hero", payload);
+      const res = await axios.post("/admin/hero/publish");
       return res.data;
     },
     onSuccess: () => {
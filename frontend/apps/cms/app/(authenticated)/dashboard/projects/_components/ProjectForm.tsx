@@ -75,6 +75,7 @@ const ProjectForm = () => {
   const createMutation = useMutation({
     mutationFn: async (payload: ProjectItem) => {
       const res = await axios.post("/admin/project/items", payload);
+      await axios.post(`/admin/project/items/${res.data.id}/publish`);
       return res.data;
     },
     onSuccess: () => {
@@ -20,9 +20,11 @@ import Dropdown from "~/components/ui/Dropdown";
 
 interface ProjectItem {
   id: number;
+  slug: string;
   name: string;
   imageUrls: string[];
   description: string;
+  body?: string;
   techStack: string[];
   githubLink: string;
   type: "Web" | "Mobile" | "Machine Learning";
@@ -67,6 +69,7 @@ const ProjectItems = () => {
 
   const [form, setForm] = useState<ProjectItem>({
     id: 0,
+    slug: "",
     name: "",
     imageUrls: [""],
     description: "",
@@ -106,6 +109,7 @@ const ProjectItems = () => {
   const createMutation = useMutation({
     mutationFn: async (payload: ProjectItem) => {
       const res = await axios.post("/admin/project/items", payload);
+      await axios.post(`/admin/project/items/${res.data.id}/publish`);
       return res.data;
     },
     onSuccess: () => {
@@ -119,10 +123,8 @@ const ProjectItems = () => {
 
   const updateMutation = useMutation({
     mutationFn: async (payload: ProjectItem) => {
-      const res = await axios.put(
-        `/admin/project/items/${payload.id}`,
-        payload
-      );
+      await axios.patch(`/admin/project/items/${payload.id}`, payload);
+      const res = await axios.post(`/admin/project/items/${payload.id}/publish`);
       return res.data;
     },
     onSuccess: () => {
@@ -137,6 +139,7 @@ const ProjectItems = () => {
   const resetForm = () => {
     setForm({
       id: 0,
+      slug: "",
       name: "",
       imageUrls: [""],
       description: "",
@@ -258,6 +261,23 @@ const ProjectItems = () => {
                 />
               </div>
 
+              <div>
+                <label
+                  htmlFor="slug"
+                  className="mb-1 text-sm font-medium text-[var(--text-muted)]"
+                >
+                  Slug
+                </label>
+                <input
+                  id="slug"
+                  name="slug"
+                  value={form.slug}
+                  onChange={handleChange}
+                  placeholder="Generated from the name when empty"
+                  className="input w-full"
+                />
+              </div>
+
               <div>
                 <label
                   htmlFor="githubLink"
@@ -337,6 +357,23 @@ const ProjectItems = () => {
                 />
               </div>
 
+              <div>
+                <label
+                  htmlFor="body"
+                  className="mb-1 text-sm font-medium text-[var(--text-muted)]"
+                >
+                  Case Study (Markdown)
+                </label>
+                <textarea
+                  id="body"
+                  name="body"
+                  value={form.body ?? ""}
+                  onChange={handleChange}
+                  placeholder="## Problem&#10;..."
+                  className="input w-full h-48 font-mono"
+                />
+              </div>
+
               <div>
                 <label className="mb-1 text-sm font-medium text-[var(--text-muted)]">
                   Tech Stack
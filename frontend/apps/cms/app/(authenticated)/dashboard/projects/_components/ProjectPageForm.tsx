@@ -25,14 +25,15 @@ const ProjectForm = () => {
   } = useQuery<ProjectPage>({
     queryKey: ["project"],
     queryFn: async () => {
-      const res = await axios.get("/admin/project");
+      const res = await axios.get("/admin/project/preview");
       return res.data;
     },
   });
 
   const updateProjectMutation = useMutation({
     mutationFn: async (updated: ProjectPage) => {
-      const res = await axios.patch("/admin/project", updated);
+      await axios.patch("/admin/project", updated);
+      const res = await axios.post("/admin/project/publish");
       return res.data;
     },
     onSuccess: () => {
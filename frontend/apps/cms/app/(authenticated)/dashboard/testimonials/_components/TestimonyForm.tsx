@@ -25,14 +25,15 @@ const TestimonyForm = () => {
   } = useQuery<TestimonyPage>({
     queryKey: ["testimony"],
     queryFn: async () => {
-      const res = await axios.get("/admin/testimony");
+      const res = await axios.get("/admin/testimony/preview");
       return res.data;
     },
   });
 
   const updateTestimonyMutation = useMutation({
     mutationFn: async (updated: TestimonyPage) => {
-      const res = await axios.patch("/admin/testimony", updated);
+      await axios.patch("/admin/testimony", updated);
+      const res = await axios.post("/admin/testimony/publish");
       return res.data;
     },
     onSuccess: () => {
@@ -0,0 +1,34 @@
+'use client'
+
+import { useEffect } from 'react'
+import { useRouter } from 'next/navigation'
+
+// Landing page after an SSO login: the API has set the session cookies and
+// passes the CSRF token in the URL fragment. Accounts with two-factor get a
+// challenge token instead, which the login page asks the code for.
+export default function LoginCallbackPage() {
+  const router = useRouter()
+
+  useEffect(() => {
+    const params = new URLSearchParams(window.location.hash.slice(1))
+    const challengeToken = params.get('challenge_token')
+    if (challengeToken) {
+      sessionStorage.setItem('challenge_token', challengeToken)
+      router.replace('/login')
+      return
+    }
+    const csrfToken = params.get('csrf_token')
+    if (!csrfToken) {
+      router.replace('/login?error=login_failed')
+      return
+    }
+    localStorage.setItem('csrf_token', csrfToken)
+    router.replace('/dashboard')
+  }, [router])
+
+  return (
+    <main className="min-h-screen flex items-center justify-center bg-[var(--bg-dark)] text-[var(--text-muted)]">
+      Signing in...
+    </main>
+  )
+}
@@ -1,6 +1,6 @@
 'use client'
 
-import { useState } from 'react'
+import { useEffect, useState } from 'react'
 import { useRouter } from 'next/navigation'
 import { useMutation } from '@tanstack/react-query'
 import axios from '~lib/axios'
@@ -9,19 +9,38 @@ import { toast } from 'sonner'
 export default function LoginPage() {
   const [email, setEmail] = useState('')
   const [password, setPassword] = useState('')
+  const [code, setCode] = useState('')
+  const [challengeToken, setChallengeToken] = useState<string | null>(null)
   const router = useRouter()
 
+  // Set by the SSO callback when the account needs its second factor
+  useEffect(() => {
+    const pending = sessionStorage.getItem('challenge_token')
+    if (pending) {
+      sessionStorage.removeItem('challenge_token')
+      setChallengeToken(pending)
+    }
+  }, [])
+
   const loginMutation = useMutation({
     mutationFn: async () => {
-      const res = await axios.post('/auth/login', { email, password })
+      if (challengeToken) {
+        const res = await axios.post('/auth/login/verify', { challenge_token: challengeToken, code, use_cookies: true })
+        return res.data
+      }
+      const res = await axios.post('/auth/login', { email, password, use_cookies: true })
       return res.data
     },
     onSuccess: (data) => {
-      localStorage.setItem('token', data.token)
+      if (data.two_factor_required) {
+        setChallengeToken(data.challenge_token)
+        return
+      }
+      localStorage.setItem('csrf_token', data.csrf_token)
       router.push('/dashboard')
     },
     onError: (err: any) => {
-      toast.error("Wrong credentials!")
+      toast.error(challengeToken ? "Invalid code!" : "Wrong credentials!")
       console.error(err)
     },
   })
@@ -39,33 +58,54 @@ export default function LoginPage() {
       >
         <h1 className="text-2xl font-semibold text-center">CMS Login</h1>
 
-        <div className="space-y-2">
-          <label htmlFor="email" className="block text-sm text-[var(--text-muted)]">
-            Email
-          </label>
-          <input
-            id="email"
-            type="email"
-            value={email}
-            onChange={(e) => setEmail(e.target.value)}
-            className="w-full p-2 rounded bg-[var(--bg-light)] text-[var(--text-strong)] border border-[var(--border-color)] outline-none focus:ring-2 focus:ring-[var(--color-primary)]"
-            required
-          />
-        </div>
+        {!challengeToken && (
+          <>
+            <div className="space-y-2">
+              <label htmlFor="email" className="block text-sm text-[var(--text-muted)]">
+                Email
+              </label>
+              <input
+                id="email"
+                type="email"
+                value={email}
+                onChange={(e) => setEmail(e.target.value)}
+                className="w-full p-2 rounded bg-[var(--bg-light)] text-[var(--text-strong)] border border-[var(--border-color)] outline-none focus:ring-2 focus:ring-[var(--color-primary)]"
+                required
+              />
+            </div>
+
+            <div className="space-y-2">
+              <label htmlFor="password" className="block text-sm text-[var(--text-muted)]">
+                Password
+              </label>
+              <input
+                id="password"
+                type="password"
+                value={password}
+                onChange={(e) => setPassword(e.target.value)}
+                className="w-full p-2 rounded bg-[var(--bg-light)] text-[var(--text-strong)] border border-[var(--border-color)] outline-none focus:ring-2 focus:ring-[var(--color-primary)]"
+                required
+              />
+            </div>
+          </>
+        )}
 
-        <div className="space-y-2">
-          <label htmlFor="password" className="block text-sm text-[var(--text-muted)]">
-            Password
-          </label>
-          <input
-            id="password"
-            type="password"
-            value={password}
-            onChange={(e) => setPassword(e.target.value)}
-            className="w-full p-2 rounded bg-[var(--bg-light)] text-[var(--text-strong)] border border-[var(--border-color)] outline-none focus:ring-2 focus:ring-[var(--color-primary)]"
-            required
-          />
-        </div>
+        {challengeToken && (
+          <div className="space-y-2">
+            <label htmlFor="code" className="block text-sm text-[var(--text-muted)]">
+              Authenticator or recovery code
+            </label>
+            <input
+              id="code"
+              type="text"
+              autoComplete="one-time-code"
+              value={code}
+              onChange={(e) => setCode(e.target.value)}
+              className="w-full p-2 rounded bg-[var(--bg-light)] text-[var(--text-strong)] border border-[var(--border-color)] outline-none focus:ring-2 focus:ring-[var(--color-primary)]"
+              required
+            />
+          </div>
+        )}
 
         <button
           type="submit"
@@ -74,6 +114,17 @@ export default function LoginPage() {
         >
           {loginMutation.isPending ? 'Signing In...' : 'Sign In'}
         </button>
+
+        <a
+          href={`${process.env.NEXT_PUBLIC_API_BASE_URL}/auth/oidc/login`}
+          className="block w-full py-2 text-center border border-[var(--border-color)] rounded hover:opacity-90 transition"
+        >
+          Sign in with SSO
+        </a>
+
+        <a href="/reset-password" className="block text-sm text-center text-[var(--text-muted)] hover:underline">
+          Forgot password?
+        </a>
       </form>
     </main>
   )
@@ -0,0 +1,104 @@
+'use client'
+
+import { Suspense, useState } from 'react'
+import { useRouter, useSearchParams } from 'next/navigation'
+import { useMutation } from '@tanstack/react-query'
+import axios from '~lib/axios'
+import { toast } from 'sonner'
+
+const inputClass =
+  'w-full p-2 rounded bg-[var(--bg-light)] text-[var(--text-strong)] border border-[var(--border-color)] outline-none focus:ring-2 focus:ring-[var(--color-primary)]'
+
+function ResetPasswordForm() {
+  const token = useSearchParams().get('token')
+  const [email, setEmail] = useState('')
+  const [password, setPassword] = useState('')
+  const router = useRouter()
+
+  // Without a token the page asks for the reset mail, with one it sets the new password
+  const resetMutation = useMutation({
+    mutationFn: async () => {
+      if (token) {
+        await axios.post('/auth/password/reset', { token, new_password: password })
+        return
+      }
+      await axios.post('/auth/password/forgot', { email })
+    },
+    onSuccess: () => {
+      if (token) {
+        toast.success('Password changed, please sign in')
+        router.push('/login')
+        return
+      }
+      toast.success('If the account exists, a reset link is on its way')
+    },
+    onError: (err: any) => {
+      toast.error(err.response?.data || 'Something went wrong')
+      console.error(err)
+    },
+  })
+
+  const handleSubmit = (e: React.FormEvent) => {
+    e.preventDefault()
+    resetMutation.mutate()
+  }
+
+  return (
+    <form
+      onSubmit={handleSubmit}
+      className="w-full max-w-sm space-y-6 bg-[var(--bg-mid)] p-6 rounded-xl shadow-[0_2px_10px_var(--shadow-color-strong)] border border-[var(--border-color)]"
+    >
+      <h1 className="text-2xl font-semibold text-center">{token ? 'Choose a new password' : 'Reset password'}</h1>
+
+      {token ? (
+        <div className="space-y-2">
+          <label htmlFor="password" className="block text-sm text-[var(--text-muted)]">
+            New password
+          </label>
+          <input
+            id="password"
+            type="password"
+            minLength={8}
+            autoComplete="new-password"
+            value={password}
+            onChange={(e) => setPassword(e.target.value)}
+            className={inputClass}
+            required
+          />
+        </div>
+      ) : (
+        <div className="space-y-2">
+          <label htmlFor="email" className="block text-sm text-[var(--text-muted)]">
+            Email
+          </label>
+          <input
+            id="email"
+            type="email"
+            value={email}
+            onChange={(e) => setEmail(e.target.value)}
+            className={inputClass}
+            required
+          />
+        </div>
+      )}
+
+      <button
+        type="submit"
+        className="w-full py-2 bg-[var(--color-primary)] text-[var(--color-on-primary)] font-medium rounded hover:opacity-90 transition"
+        disabled={resetMutation.isPending}
+      >
+        {resetMutation.isPending ? 'Sending...' : token ? 'Set Password' : 'Send Reset Link'}
+      </button>
+    </form>
+  )
+}
+
+export default function ResetPasswordPage() {
+  return (
+    <main className="min-h-screen flex items-center justify-center bg-[var(--bg-dark)] text-[var(--text-strong)] px-4">
+      <Suspense>
+        <ResetPasswordForm />
+      </Suspense>
+    </main>
+  )
+}
@@ -3,6 +3,7 @@
 import Link from "next/link";
 import { usePathname } from "next/navigation";
 import { TbLogout2 } from "react-icons/tb";
+import axios from "~lib/axios";
 
 const navItems = [
   { label: "Hero", subpath: "/dashboard/hero" },
@@ -15,8 +16,13 @@ const navItems = [
 export default function Sidebar() {
   const pathname = usePathname();
 
-  const handleLogout = () => {
-    localStorage.removeItem("token");
+  const handleLogout = async () => {
+    try {
+      await axios.post("/auth/logout");
+    } catch (err) {
+      console.error(err);
+    }
+    localStorage.removeItem("csrf_token");
     window.location.href = "/login";
   };
 
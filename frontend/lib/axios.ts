@@ -1,15 +1,57 @@
 import axios from 'axios'
 
+// The CMS uses the cookie session mode: the tokens live in HttpOnly cookies and
+// only the CSRF token is kept in storage, to be echoed back on mutating requests
+const CSRF_STORAGE_KEY = 'csrf_token'
+const SAFE_METHODS = ['get', 'head', 'options']
+
 const axiosInstance = axios.create({
   baseURL: process.env.NEXT_PUBLIC_API_BASE_URL, 
+  withCredentials: true,
 })
 
-axiosInstance.interceptors.request.use((config) => {
-  const token = typeof window !== 'undefined' ? localStorage.getItem('token') : null
-  if (token) {
-    config.headers.Authorization = `Bearer ${token}`
+const withCSRF = (config: any) => {
+  const csrfToken = typeof window !== 'undefined' ? localStorage.getItem(CSRF_STORAGE_KEY) : null
+  if (csrfToken && !SAFE_METHODS.includes((config.method ?? 'get').toLowerCase())) {
+    config.headers['X-CSRF-Token'] = csrfToken
   }
   return config
-})
+}
+
+axiosInstance.interceptors.request.use(withCSRF)
+
+// Access tokens are short-lived: on a 401 try once to rotate the refresh token
+let refreshing: Promise<void> | null = null
+
+const refreshSession = async () => {
+  const res = await axios.post(
+    `${process.env.NEXT_PUBLIC_API_BASE_URL}/auth/refresh`,
+    undefined,
+    withCSRF({ method: 'post', headers: {}, withCredentials: true }),
+  )
+  localStorage.setItem(CSRF_STORAGE_KEY, res.data.csrf_token)
+}
+
+axiosInstance.interceptors.response.use(
+  (response) => response,
+  async (error) => {
+    const original = error.config
+    if (typeof window === 'undefined' || error.response?.status !== 401 || original?._retry) {
+      return Promise.reject(error)
+    }
+    original._retry = true
+
+    try {
+      refreshing = refreshing ?? refreshSession()
+      await refreshing
+      return axiosInstance(original)
+    } catch (refreshError) {
+      localStorage.removeItem(CSRF_STORAGE_KEY)
+      return Promise.reject(refreshError)
+    } finally {
+      refreshing = null
+    }
+  },
+)
 
 export default axiosInstance